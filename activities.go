@@ -0,0 +1,136 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ActivitiesService handles activity API calls.
+// Activities are the entries of the "recent activity" list in the Wise app:
+// transfers, card payments, conversions, deposits and so on.
+type ActivitiesService struct {
+	client *Client
+}
+
+// ActivityType is the kind of resource an activity is about.
+type ActivityType string
+
+const (
+	ActivityTypeTransfer         ActivityType = "TRANSFER"
+	ActivityTypeCardPayment      ActivityType = "CARD_PAYMENT"
+	ActivityTypeCardCashback     ActivityType = "CARD_CASHBACK"
+	ActivityTypeCardCheck        ActivityType = "CARD_CHECK"
+	ActivityTypeConversion       ActivityType = "CONVERSION"
+	ActivityTypeDirectDebit      ActivityType = "DIRECT_DEBIT_TRANSACTION"
+	ActivityTypeBalanceDeposit   ActivityType = "BALANCE_DEPOSIT"
+	ActivityTypeBalanceCashback  ActivityType = "BALANCE_CASHBACK"
+	ActivityTypeBalanceInterest  ActivityType = "BALANCE_INTEREST"
+	ActivityTypeBalanceAdjust    ActivityType = "BALANCE_ADJUSTMENT"
+	ActivityTypeIncomingTransfer ActivityType = "INCOMING_TRANSFER"
+)
+
+// ActivityStatus is the state of an activity.
+type ActivityStatus string
+
+const (
+	ActivityStatusRequiresAttention ActivityStatus = "REQUIRES_ATTENTION"
+	ActivityStatusInProgress        ActivityStatus = "IN_PROGRESS"
+	ActivityStatusUpcoming          ActivityStatus = "UPCOMING"
+	ActivityStatusCompleted         ActivityStatus = "COMPLETED"
+	ActivityStatusCancelled         ActivityStatus = "CANCELLED"
+)
+
+// Activity represents an entry in a profile's activity list.
+// Title, Description and the amounts are display strings and may contain
+// simple HTML markup such as <strong> and <positive>.
+type Activity struct {
+	ID              string           `json:"id"`
+	Type            ActivityType     `json:"type"`
+	Resource        ActivityResource `json:"resource"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description,omitempty"`
+	PrimaryAmount   string           `json:"primaryAmount,omitempty"`   // e.g. "250 EUR"
+	SecondaryAmount string           `json:"secondaryAmount,omitempty"` // e.g. the converted amount
+	Status          ActivityStatus   `json:"status"`
+	CreatedOn       Timestamp        `json:"createdOn"`
+	UpdatedOn       Timestamp        `json:"updatedOn,omitzero"`
+}
+
+// ActivityResource identifies the resource an activity is about.
+type ActivityResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// ActivitiesPage is one page of activities.
+type ActivitiesPage struct {
+	Cursor     string     `json:"cursor,omitempty"` // Empty on the last page
+	Activities []Activity `json:"activities"`
+}
+
+// ListActivitiesParams represents the parameters for listing activities.
+type ListActivitiesParams struct {
+	MonetaryResourceType ActivityType
+	Status               ActivityStatus
+	Since                string // ISO 8601
+	Until                string // ISO 8601
+	Cursor               string // From the previous page
+	Size                 int    // 1-100, default 10
+}
+
+// List returns a page of a profile's activities, newest first.
+// GET /v1/profiles/{profileId}/activities
+func (s *ActivitiesService) List(ctx context.Context, profileID int64, params *ListActivitiesParams) (*ActivitiesPage, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.MonetaryResourceType != "" {
+			query.Set("monetaryResourceType", string(params.MonetaryResourceType))
+		}
+		if params.Status != "" {
+			query.Set("status", string(params.Status))
+		}
+		if params.Since != "" {
+			query.Set("since", params.Since)
+		}
+		if params.Until != "" {
+			query.Set("until", params.Until)
+		}
+		if params.Cursor != "" {
+			query.Set("nextCursor", params.Cursor)
+		}
+		if params.Size > 0 {
+			query.Set("size", strconv.Itoa(params.Size))
+		}
+	}
+
+	var page ActivitiesPage
+	path := fmt.Sprintf("/v1/profiles/%d/activities", profileID)
+	err := s.client.Get(ctx, path, query, &page)
+	if err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListAll returns all activities matching params, following the cursor.
+// params.Cursor is used as the starting point.
+func (s *ActivitiesService) ListAll(ctx context.Context, profileID int64, params *ListActivitiesParams) ([]Activity, error) {
+	var p ListActivitiesParams
+	if params != nil {
+		p = *params
+	}
+	var all []Activity
+	for {
+		page, err := s.List(ctx, profileID, &p)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Activities...)
+		if page.Cursor == "" || len(page.Activities) == 0 {
+			return all, nil
+		}
+		p.Cursor = page.Cursor
+	}
+}
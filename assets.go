@@ -0,0 +1,114 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// AssetsService handles Wise Assets (Interest and Stocks) API calls.
+type AssetsService struct {
+	client *Client
+}
+
+// AssetProduct identifies a Wise Assets product.
+type AssetProduct string
+
+const (
+	AssetProductInterest AssetProduct = "INTEREST"
+	AssetProductStocks   AssetProduct = "STOCKS"
+)
+
+// AssetsStatus describes whether a profile has opted in to Wise Assets.
+type AssetsStatus struct {
+	OptedIn  bool                 `json:"optedIn"`
+	Products []AssetProductStatus `json:"products,omitempty"`
+}
+
+// AssetProductStatus is the opt-in status of a single product.
+type AssetProductStatus struct {
+	Product    AssetProduct `json:"product"`
+	Available  bool         `json:"available"`
+	OptedIn    bool         `json:"optedIn"`
+	Currencies []Currency   `json:"currencies,omitempty"`
+}
+
+// AssetHolding represents money invested through a balance.
+type AssetHolding struct {
+	BalanceID int64        `json:"balanceId"`
+	Currency  Currency     `json:"currency"`
+	Product   AssetProduct `json:"product"`
+	FundName  string       `json:"fundName,omitempty"`
+	FundISIN  string       `json:"fundIsin,omitempty"`
+	Units     float64      `json:"units,omitempty"`
+	Value     Money        `json:"value"`
+	UpdatedAt Timestamp    `json:"updatedAt,omitempty"`
+}
+
+// AssetReturns represents the returns earned on a balance over a period.
+type AssetReturns struct {
+	BalanceID        int64     `json:"balanceId"`
+	Product          string    `json:"product"`
+	PeriodStart      Timestamp `json:"periodStart"`
+	PeriodEnd        Timestamp `json:"periodEnd"`
+	ReturnAmount     Money     `json:"returnAmount"`
+	ReturnPercentage float64   `json:"returnPercentage"`
+	Fees             Money     `json:"fees,omitempty"`
+	InterestRate     float64   `json:"interestRate,omitempty"` // Current variable rate, INTEREST only
+}
+
+// GetStatus returns the Wise Assets opt-in status for a profile.
+// GET /v2/profiles/{profileId}/assets/status
+func (s *AssetsService) GetStatus(ctx context.Context, profileID int64) (*AssetsStatus, error) {
+	var status AssetsStatus
+	path := fmt.Sprintf("/v2/profiles/%d/assets/status", profileID)
+	err := s.client.Get(ctx, path, nil, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ListHoldings returns the asset holdings of a profile across all balances.
+// GET /v2/profiles/{profileId}/assets/holdings
+func (s *AssetsService) ListHoldings(ctx context.Context, profileID int64) ([]AssetHolding, error) {
+	var holdings []AssetHolding
+	path := fmt.Sprintf("/v2/profiles/%d/assets/holdings", profileID)
+	err := s.client.Get(ctx, path, nil, &holdings)
+	if err != nil {
+		return nil, err
+	}
+	return holdings, nil
+}
+
+// GetReturns returns the returns earned on a balance between two dates.
+// GET /v2/profiles/{profileId}/assets/returns
+func (s *AssetsService) GetReturns(ctx context.Context, profileID, balanceID int64, intervalStart, intervalEnd string) (*AssetReturns, error) {
+	query := url.Values{}
+	query.Set("balanceId", strconv.FormatInt(balanceID, 10))
+	if intervalStart != "" {
+		query.Set("intervalStart", intervalStart)
+	}
+	if intervalEnd != "" {
+		query.Set("intervalEnd", intervalEnd)
+	}
+
+	var returns AssetReturns
+	path := fmt.Sprintf("/v2/profiles/%d/assets/returns", profileID)
+	err := s.client.Get(ctx, path, query, &returns)
+	if err != nil {
+		return nil, err
+	}
+	return &returns, nil
+}
+
+// IsEarning reports whether any holding in the given balance has a positive value.
+func IsEarning(holdings []AssetHolding, balanceID int64) bool {
+	for _, h := range holdings {
+		if h.BalanceID == balanceID && h.Value.Value > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// AutoConversionsService handles auto-conversion (standing conversion order) API calls.
+// An auto-conversion converts money between two balances once the exchange
+// rate reaches a target.
+type AutoConversionsService struct {
+	client *Client
+}
+
+// AutoConversionStatus represents the status of an auto-conversion order.
+type AutoConversionStatus string
+
+const (
+	AutoConversionStatusActive    AutoConversionStatus = "ACTIVE"
+	AutoConversionStatusCompleted AutoConversionStatus = "COMPLETED"
+	AutoConversionStatusCancelled AutoConversionStatus = "CANCELLED"
+	AutoConversionStatusExpired   AutoConversionStatus = "EXPIRED"
+)
+
+// AutoConversion represents an order to convert money when a rate is reached.
+type AutoConversion struct {
+	ID              string               `json:"id"`
+	Status          AutoConversionStatus `json:"status"`
+	SourceCurrency  Currency             `json:"sourceCurrency"`
+	TargetCurrency  Currency             `json:"targetCurrency"`
+	SourceAmount    float64              `json:"sourceAmount"`
+	TargetRate      float64              `json:"targetRate"`
+	SourceBalanceID int64                `json:"sourceBalanceId,omitempty"`
+	TargetBalanceID int64                `json:"targetBalanceId,omitempty"`
+	ExpiresAt       Timestamp            `json:"expiresAt,omitempty"`
+	CreatedAt       Timestamp            `json:"createdAt"`
+	ExecutedAt      Timestamp            `json:"executedAt,omitempty"`
+	ExecutedRate    float64              `json:"executedRate,omitempty"`
+}
+
+// CreateAutoConversionRequest represents the request to create an auto-conversion.
+type CreateAutoConversionRequest struct {
+	SourceCurrency  Currency `json:"sourceCurrency"`
+	TargetCurrency  Currency `json:"targetCurrency"`
+	SourceAmount    float64  `json:"sourceAmount"`
+	TargetRate      float64  `json:"targetRate"`
+	SourceBalanceID int64    `json:"sourceBalanceId,omitempty"`
+	TargetBalanceID int64    `json:"targetBalanceId,omitempty"`
+	ExpiresAt       string   `json:"expiresAt,omitempty"` // ISO 8601, optional
+}
+
+// Create creates an auto-conversion order.
+// POST /v2/profiles/{profileId}/auto-conversions
+func (s *AutoConversionsService) Create(ctx context.Context, profileID int64, req *CreateAutoConversionRequest) (*AutoConversion, error) {
+	var order AutoConversion
+	path := fmt.Sprintf("/v2/profiles/%d/auto-conversions", profileID)
+	err := s.client.Post(ctx, path, req, &order)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Get retrieves an auto-conversion order by ID.
+// GET /v2/profiles/{profileId}/auto-conversions/{id}
+func (s *AutoConversionsService) Get(ctx context.Context, profileID int64, id string) (*AutoConversion, error) {
+	var order AutoConversion
+	path := fmt.Sprintf("/v2/profiles/%d/auto-conversions/%s", profileID, id)
+	err := s.client.Get(ctx, path, nil, &order)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// List returns the auto-conversion orders for a profile, optionally filtered by status.
+// GET /v2/profiles/{profileId}/auto-conversions
+func (s *AutoConversionsService) List(ctx context.Context, profileID int64, status AutoConversionStatus) ([]AutoConversion, error) {
+	query := url.Values{}
+	if status != "" {
+		query.Set("status", string(status))
+	}
+
+	var orders []AutoConversion
+	path := fmt.Sprintf("/v2/profiles/%d/auto-conversions", profileID)
+	err := s.client.Get(ctx, path, query, &orders)
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// Cancel cancels an active auto-conversion order.
+// DELETE /v2/profiles/{profileId}/auto-conversions/{id}
+func (s *AutoConversionsService) Cancel(ctx context.Context, profileID int64, id string) error {
+	path := fmt.Sprintf("/v2/profiles/%d/auto-conversions/%s", profileID, id)
+	return s.client.Delete(ctx, path, nil)
+}
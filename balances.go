@@ -2,15 +2,38 @@ package wise
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
+	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrBalanceNotEmpty is returned by Close when the balance still holds money.
+var ErrBalanceNotEmpty = errors.New("wise: balance is not empty")
+
 // BalancesService handles balance-related API calls.
 type BalancesService struct {
 	client *Client
 }
 
+// BalanceType represents the type of a balance.
+type BalanceType string
+
+const (
+	// BalanceTypeStandard is a regular currency balance. Only one exists per currency.
+	BalanceTypeStandard BalanceType = "STANDARD"
+	// BalanceTypeSavings is a savings jar. Several can exist per currency.
+	BalanceTypeSavings BalanceType = "SAVINGS"
+)
+
 // Balance represents a multi-currency balance.
 type Balance struct {
 	ID              int64    `json:"id"`
@@ -20,7 +43,7 @@ type Balance struct {
 	ReservedAmount  Money    `json:"reservedAmount,omitempty"`
 	CashAmount      Money    `json:"cashAmount,omitempty"`
 	TotalWorth      Money    `json:"totalWorth,omitempty"`
-	Type            string   `json:"type,omitempty"` // STANDARD, SAVINGS
+	Type            BalanceType `json:"type,omitempty"`
 	Name            string   `json:"name,omitempty"`
 	Icon            string   `json:"icon,omitempty"`
 	CreationTime    Timestamp `json:"creationTime,omitempty"`
@@ -47,6 +70,25 @@ type StatementDetails struct {
 	SenderName      string `json:"senderName,omitempty"`
 	SenderAccount   string `json:"senderAccount,omitempty"`
 	PaymentReference string `json:"paymentReference,omitempty"`
+	Recipient       *StatementRecipient `json:"recipient,omitempty"` // Set on money sent
+	Merchant        *StatementMerchant  `json:"merchant,omitempty"`  // Set on card payments
+}
+
+// StatementRecipient is the recipient of money sent in a statement entry.
+type StatementRecipient struct {
+	Name        string `json:"name,omitempty"`
+	BankAccount string `json:"bankAccount,omitempty"`
+}
+
+// StatementMerchant is the merchant of a card payment in a statement entry.
+type StatementMerchant struct {
+	Name      string `json:"name,omitempty"`
+	FirstLine string `json:"firstLine,omitempty"`
+	PostCode  string `json:"postCode,omitempty"`
+	City      string `json:"city,omitempty"`
+	State     string `json:"state,omitempty"`
+	Country   string `json:"country,omitempty"`
+	Category  string `json:"category,omitempty"`
 }
 
 // ExchangeDetails contains exchange information for a statement entry.
@@ -56,27 +98,119 @@ type ExchangeDetails struct {
 	Rate         float64 `json:"rate,omitempty"`
 }
 
+// StatementFormat is the file format of a downloaded balance statement.
+type StatementFormat string
+
+const (
+	StatementFormatJSON    StatementFormat = "json"
+	StatementFormatCSV     StatementFormat = "csv"
+	StatementFormatPDF     StatementFormat = "pdf"
+	StatementFormatCAMT053 StatementFormat = "xml" // ISO 20022 CAMT.053
+)
+
+// contentType returns the Accept header value for the format.
+func (f StatementFormat) contentType() string {
+	switch f {
+	case StatementFormatCSV:
+		return "text/csv"
+	case StatementFormatPDF:
+		return "application/pdf"
+	case StatementFormatCAMT053:
+		return "application/xml"
+	default:
+		return "application/json"
+	}
+}
+
 // ConvertBalanceRequest represents a request to convert between balances.
 type ConvertBalanceRequest struct {
 	QuoteID string `json:"quoteId"`
 }
 
+// OpenBalanceRequest represents a request to open a new balance.
+type OpenBalanceRequest struct {
+	Currency Currency    `json:"currency"`
+	Type     BalanceType `json:"type"`
+	Name     string      `json:"name,omitempty"` // Required for SAVINGS
+}
+
+// FundingInstructions describes how to add money to a balance by bank transfer.
+type FundingInstructions struct {
+	BalanceID int64           `json:"balanceId"`
+	Amount    Money           `json:"amount"`
+	Reference string          `json:"reference,omitempty"` // Must be included with the payment
+	Methods   []FundingMethod `json:"methods"`
+	ExpiresAt Timestamp       `json:"expiresAt,omitempty"`
+}
+
+// FundingMethod is a set of bank details the money can be wired to, e.g.
+// a local account (LOCAL) or an international one (SWIFT).
+type FundingMethod struct {
+	Type              string          `json:"type"`
+	AccountHolderName string          `json:"accountHolderName"`
+	BankName          string          `json:"bankName,omitempty"`
+	BankAddress       string          `json:"bankAddress,omitempty"`
+	Details           []FundingDetail `json:"details"`
+}
+
+// FundingDetail is a single labelled bank detail, e.g. IBAN or sort code.
+type FundingDetail struct {
+	Type  string `json:"type"`  // IBAN, BIC, SORT_CODE, ACCOUNT_NUMBER, ROUTING_NUMBER, ...
+	Title string `json:"title"` // Human readable label
+	Value string `json:"value"`
+}
+
+// Detail returns the value of the detail with the given type, or an empty string.
+func (m *FundingMethod) Detail(detailType string) string {
+	for _, d := range m.Details {
+		if d.Type == detailType {
+			return d.Value
+		}
+	}
+	return ""
+}
+
 // ListBalancesParams represents parameters for listing balances.
 type ListBalancesParams struct {
-	Types []string // STANDARD, SAVINGS
+	Types []BalanceType
+}
+
+// MoveBalanceRequest represents a request to move money between two
+// balances of the same currency, such as a balance and a savings jar.
+type MoveBalanceRequest struct {
+	Amount          Money `json:"amount"`
+	SourceBalanceID int64 `json:"sourceBalanceId"`
+	TargetBalanceID int64 `json:"targetBalanceId"`
 }
 
-// List retrieves all balances for a profile.
+// BalanceMovement represents a completed movement of money between balances.
+type BalanceMovement struct {
+	ID            int64     `json:"id"`
+	Type          string    `json:"type"`  // DEPOSIT, WITHDRAWAL, CONVERSION
+	State         string    `json:"state"` // PENDING, COMPLETED, REJECTED
+	SourceAmount  Money     `json:"sourceAmount"`
+	TargetAmount  Money     `json:"targetAmount"`
+	Rate          float64   `json:"rate,omitempty"`
+	BalancesAfter []Balance `json:"balancesAfter,omitempty"`
+	CreationTime  Timestamp `json:"creationTime"`
+}
+
+// List retrieves balances for a profile.
+// When params is nil or has no Types, only STANDARD balances are returned;
+// use ListAll to include savings jars.
 // GET /v4/profiles/{profileId}/balances
 func (s *BalancesService) List(ctx context.Context, profileID int64, params *ListBalancesParams) ([]Balance, error) {
 	query := url.Values{}
 	if params != nil && len(params.Types) > 0 {
-		for _, t := range params.Types {
-			query.Add("types", t)
+		// Wise takes the types as one comma-separated value.
+		types := make([]string, len(params.Types))
+		for i, t := range params.Types {
+			types[i] = string(t)
 		}
+		query.Set("types", strings.Join(types, ","))
 	} else {
 		// Default to STANDARD type if not specified (required by API)
-		query.Add("types", "STANDARD")
+		query.Set("types", string(BalanceTypeStandard))
 	}
 
 	var balances []Balance
@@ -88,6 +222,14 @@ func (s *BalancesService) List(ctx context.Context, profileID int64, params *Lis
 	return balances, nil
 }
 
+// ListAll retrieves both STANDARD balances and SAVINGS jars for a profile.
+// GET /v4/profiles/{profileId}/balances?types=STANDARD,SAVINGS
+func (s *BalancesService) ListAll(ctx context.Context, profileID int64) ([]Balance, error) {
+	return s.List(ctx, profileID, &ListBalancesParams{
+		Types: []BalanceType{BalanceTypeStandard, BalanceTypeSavings},
+	})
+}
+
 // Get retrieves a specific balance.
 // GET /v4/profiles/{profileId}/balances/{balanceId}
 func (s *BalancesService) Get(ctx context.Context, profileID, balanceID int64) (*Balance, error) {
@@ -100,6 +242,71 @@ func (s *BalancesService) Get(ctx context.Context, profileID, balanceID int64) (
 	return &balance, nil
 }
 
+// Open opens a new balance in the given currency.
+// Only one STANDARD balance can exist per currency.
+// POST /v4/profiles/{profileId}/balances
+func (s *BalancesService) Open(ctx context.Context, profileID int64, req *OpenBalanceRequest) (*Balance, error) {
+	header := http.Header{}
+	header.Set(idempotencyHeader, uuid.NewString())
+
+	var balance Balance
+	path := fmt.Sprintf("/v4/profiles/%d/balances", profileID)
+	err := s.client.request(ctx, http.MethodPost, path, nil, req, &balance, header)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// CreateJar opens a named savings jar in the given currency.
+// POST /v4/profiles/{profileId}/balances
+func (s *BalancesService) CreateJar(ctx context.Context, profileID int64, currency Currency, name string) (*Balance, error) {
+	return s.Open(ctx, profileID, &OpenBalanceRequest{
+		Currency: currency,
+		Type:     BalanceTypeSavings,
+		Name:     name,
+	})
+}
+
+// Move moves money between two balances of the same currency, e.g. into or
+// out of a savings jar.
+// POST /v2/profiles/{profileId}/balance-movements
+func (s *BalancesService) Move(ctx context.Context, profileID int64, req *MoveBalanceRequest) (*BalanceMovement, error) {
+	header := http.Header{}
+	header.Set(idempotencyHeader, uuid.NewString())
+
+	var movement BalanceMovement
+	path := fmt.Sprintf("/v2/profiles/%d/balance-movements", profileID)
+	err := s.client.request(ctx, http.MethodPost, path, nil, req, &movement, header)
+	if err != nil {
+		return nil, err
+	}
+	return &movement, nil
+}
+
+// Close closes a balance. The balance must be empty; otherwise the returned
+// error wraps both ErrBalanceNotEmpty and the underlying *APIError.
+// DELETE /v4/profiles/{profileId}/balances/{balanceId}
+func (s *BalancesService) Close(ctx context.Context, profileID, balanceID int64) error {
+	path := fmt.Sprintf("/v4/profiles/%d/balances/%d", profileID, balanceID)
+	err := s.client.Delete(ctx, path, nil)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && isBalanceNotEmpty(apiErr) {
+		return fmt.Errorf("%w: %w", ErrBalanceNotEmpty, apiErr)
+	}
+	return err
+}
+
+// isBalanceNotEmpty reports whether e refuses to close a balance that
+// still holds money, based on its error code.
+func isBalanceNotEmpty(e *APIError) bool {
+	if e.StatusCode < 400 || e.StatusCode >= 500 {
+		return false
+	}
+	return e.hasCode(balanceNotEmptyCodes)
+}
+
 // GetByCurrency retrieves a balance by currency.
 func (s *BalancesService) GetByCurrency(ctx context.Context, profileID int64, currency Currency) (*Balance, error) {
 	balances, err := s.List(ctx, profileID, nil)
@@ -119,12 +326,20 @@ func (s *BalancesService) GetByCurrency(ctx context.Context, profileID int64, cu
 // Convert converts money between balances using a quote.
 // POST /v2/profiles/{profileId}/balance-movements
 func (s *BalancesService) Convert(ctx context.Context, profileID int64, quoteID string) error {
+	header := http.Header{}
+	header.Set(idempotencyHeader, uuid.NewString())
+
 	req := ConvertBalanceRequest{QuoteID: quoteID}
 	path := fmt.Sprintf("/v2/profiles/%d/balance-movements", profileID)
-	return s.client.Post(ctx, path, req, nil)
+	return s.client.request(ctx, http.MethodPost, path, nil, req, nil, header)
 }
 
-// GetStatement retrieves the statement for a balance.
+// MaxStatementInterval is the longest interval a single statement request
+// may cover. Wise rejects requests for longer intervals.
+const MaxStatementInterval = 469 * 24 * time.Hour
+
+// GetStatement retrieves the statement for a balance. The interval may not
+// be longer than MaxStatementInterval.
 // GET /v1/profiles/{profileId}/balance-statements/{balanceId}/statement.json
 func (s *BalancesService) GetStatement(ctx context.Context, profileID, balanceID int64, currency Currency, intervalStart, intervalEnd string) ([]BalanceStatement, error) {
 	query := url.Values{}
@@ -142,3 +357,155 @@ func (s *BalancesService) GetStatement(ctx context.Context, profileID, balanceID
 	}
 	return result.Transactions, nil
 }
+
+// GetFundingInstructions returns the bank details and reference to use when
+// topping up a balance by bank transfer. The amount is optional; pass zero
+// for instructions that are not tied to a specific amount.
+// GET /v1/profiles/{profileId}/balances/{balanceId}/funding-instructions
+func (s *BalancesService) GetFundingInstructions(ctx context.Context, profileID, balanceID int64, amount float64) (*FundingInstructions, error) {
+	query := url.Values{}
+	if amount > 0 {
+		query.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	}
+
+	var instructions FundingInstructions
+	path := fmt.Sprintf("/v1/profiles/%d/balances/%d/funding-instructions", profileID, balanceID)
+	err := s.client.Get(ctx, path, query, &instructions)
+	if err != nil {
+		return nil, err
+	}
+	return &instructions, nil
+}
+
+// DownloadStatement downloads the statement for a balance in the given format.
+// The file is not buffered, so it can be copied straight to a file or object
+// store with io.Copy. The caller must close the returned reader.
+// GET /v1/profiles/{profileId}/balance-statements/{balanceId}/statement.{json,csv,pdf,xml}
+func (s *BalancesService) DownloadStatement(ctx context.Context, profileID, balanceID int64, currency Currency, intervalStart, intervalEnd string, format StatementFormat) (io.ReadCloser, error) {
+	if format == "" {
+		format = StatementFormatJSON
+	}
+
+	query := url.Values{}
+	query.Set("currency", string(currency))
+	query.Set("intervalStart", intervalStart)
+	query.Set("intervalEnd", intervalEnd)
+
+	path := fmt.Sprintf("/v1/profiles/%d/balance-statements/%d/statement.%s", profileID, balanceID, format)
+	return s.client.stream(ctx, path, query, format.contentType())
+}
+
+// StreamStatement downloads the JSON statement for a balance and decodes its
+// transactions one at a time, so long statements need not fit in memory.
+// The caller must close the returned stream.
+// GET /v1/profiles/{profileId}/balance-statements/{balanceId}/statement.json
+func (s *BalancesService) StreamStatement(ctx context.Context, profileID, balanceID int64, currency Currency, intervalStart, intervalEnd string) (*StatementStream, error) {
+	body, err := s.DownloadStatement(ctx, profileID, balanceID, currency, intervalStart, intervalEnd, StatementFormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &StatementStream{body: body, dec: json.NewDecoder(body)}, nil
+}
+
+// StatementStream decodes statement transactions from a response body.
+//
+//	for stream.Next() {
+//		tx := stream.Value()
+//		...
+//	}
+//	if err := stream.Err(); err != nil { ... }
+type StatementStream struct {
+	body    io.ReadCloser
+	dec     *json.Decoder
+	value   BalanceStatement
+	err     error
+	started bool
+	done    bool
+}
+
+// Next decodes the next transaction, returning false at the end of the
+// statement or on error.
+func (st *StatementStream) Next() bool {
+	if st.done {
+		return false
+	}
+	if !st.started {
+		st.started = true
+		if err := st.seekTransactions(); err != nil {
+			return st.fail(err)
+		}
+	}
+	if !st.dec.More() {
+		st.done = true
+		return false
+	}
+
+	st.value = BalanceStatement{}
+	if err := st.dec.Decode(&st.value); err != nil {
+		return st.fail(fmt.Errorf("decoding statement transaction: %w", err))
+	}
+	return true
+}
+
+// seekTransactions advances the decoder into the "transactions" array,
+// skipping the other statement fields.
+func (st *StatementStream) seekTransactions() error {
+	if tok, err := st.dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("decoding statement: expected an object")
+	}
+	for st.dec.More() {
+		key, err := st.dec.Token()
+		if err != nil {
+			return fmt.Errorf("decoding statement: %w", err)
+		}
+		if key == "transactions" {
+			if tok, err := st.dec.Token(); err != nil || tok != json.Delim('[') {
+				return fmt.Errorf("decoding statement: expected a transactions array")
+			}
+			return nil
+		}
+		var skip json.RawMessage
+		if err := st.dec.Decode(&skip); err != nil {
+			return fmt.Errorf("decoding statement: %w", err)
+		}
+	}
+	return io.EOF
+}
+
+func (st *StatementStream) fail(err error) bool {
+	st.done = true
+	if !errors.Is(err, io.EOF) {
+		st.err = err
+	}
+	return false
+}
+
+// Value returns the transaction decoded by the last call to Next.
+func (st *StatementStream) Value() BalanceStatement {
+	return st.value
+}
+
+// Err returns the error that stopped the stream, if any.
+func (st *StatementStream) Err() error {
+	return st.err
+}
+
+// All returns the remaining transactions as a range-over-func sequence.
+// A decoding error is yielded once, as the last element.
+func (st *StatementStream) All() iter.Seq2[BalanceStatement, error] {
+	return func(yield func(BalanceStatement, error) bool) {
+		for st.Next() {
+			if !yield(st.value, nil) {
+				return
+			}
+		}
+		if st.err != nil {
+			yield(BalanceStatement{}, st.err)
+		}
+	}
+}
+
+// Close closes the underlying response body.
+func (st *StatementStream) Close() error {
+	return st.body.Close()
+}
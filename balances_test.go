@@ -0,0 +1,90 @@
+package wise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBalances_StreamStatement(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/profiles/1/balance-statements/2/statement.json" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"accountHolder": {"type": "PERSONAL"},
+			"transactions": [
+				{"type": "CREDIT", "amount": {"value": 10, "currency": "EUR"}, "referenceNumber": "A"},
+				{"type": "DEBIT", "amount": {"value": -4.5, "currency": "EUR"}, "referenceNumber": "B"}
+			],
+			"endOfStatementBalance": {"value": 5.5, "currency": "EUR"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	stream, err := client.Balances.StreamStatement(context.Background(), 1, 2, EUR, "2024-01-01T00:00:00Z", "2024-12-31T23:59:59Z")
+	if err != nil {
+		t.Fatalf("StreamStatement failed: %v", err)
+	}
+	defer stream.Close()
+
+	var refs []string
+	for tx, err := range stream.All() {
+		if err != nil {
+			t.Fatalf("Stream failed: %v", err)
+		}
+		refs = append(refs, tx.ReferenceNumber)
+	}
+	if len(refs) != 2 || refs[0] != "A" || refs[1] != "B" {
+		t.Errorf("Unexpected transactions: %v", refs)
+	}
+}
+
+func TestBalances_ListAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.RawQuery; got != "types=STANDARD%2CSAVINGS" {
+			t.Errorf("Unexpected query: %s", got)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	if _, err := client.Balances.ListAll(context.Background(), 1); err != nil {
+		t.Fatalf("ListAll failed: %v", err)
+	}
+}
+
+func TestBalances_CloseNotEmpty(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		notEmpty bool
+	}{
+		{"code", `{"errors": [{"code": "balance.not.empty", "message": "Balance has funds"}]}`, true},
+		{"type", `{"type": "BALANCE_NOT_EMPTY"}`, true},
+		{"message only", `{"message": "Balance is not empty"}`, false},
+		{"other code", `{"errors": [{"code": "balance.not.found", "message": "Balance not empty or missing"}]}`, false},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(tt.body))
+		}))
+		client := NewClient("test-token", WithBaseURL(server.URL))
+		err := client.Balances.Close(context.Background(), 1, 2)
+		server.Close()
+
+		if err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+			continue
+		}
+		if got := errors.Is(err, ErrBalanceNotEmpty); got != tt.notEmpty {
+			t.Errorf("%s: errors.Is(ErrBalanceNotEmpty) = %v, want %v (%v)", tt.name, got, tt.notEmpty, err)
+		}
+	}
+}
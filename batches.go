@@ -0,0 +1,124 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+)
+
+// BatchGroupsService handles batch transfer API calls.
+// A batch group collects up to 1000 transfers that are funded with a single payment.
+type BatchGroupsService struct {
+	client *Client
+}
+
+// BatchGroupStatus represents the status of a batch group.
+type BatchGroupStatus string
+
+const (
+	BatchGroupStatusNew                   BatchGroupStatus = "NEW"
+	BatchGroupStatusCompleted             BatchGroupStatus = "COMPLETED"
+	BatchGroupStatusMarkedForCancellation BatchGroupStatus = "MARKED_FOR_CANCELLATION"
+	BatchGroupStatusProcessingCancel      BatchGroupStatus = "PROCESSING_CANCEL"
+	BatchGroupStatusCancelled             BatchGroupStatus = "CANCELLED"
+)
+
+// BatchGroup represents a group of transfers paid out together.
+type BatchGroup struct {
+	ID             string           `json:"id"`
+	Version        int              `json:"version"`
+	Name           string           `json:"name"`
+	SourceCurrency Currency         `json:"sourceCurrency"`
+	Status         BatchGroupStatus `json:"status"`
+	TransferIDs    []int64          `json:"transferIds"`
+}
+
+// CreateBatchGroupRequest represents the request to create a batch group.
+type CreateBatchGroupRequest struct {
+	Name           string   `json:"name"`
+	SourceCurrency Currency `json:"sourceCurrency"`
+}
+
+// BatchPayment represents the result of funding a batch group.
+type BatchPayment struct {
+	Type         string `json:"type"`
+	Status       string `json:"status"`
+	ErrorCode    string `json:"errorCode,omitempty"`
+	BalanceID    int64  `json:"balanceId,omitempty"`
+	BatchGroupID string `json:"batchGroupId,omitempty"`
+}
+
+// Create creates a new batch group.
+// POST /v3/profiles/{profileId}/batch-groups
+func (s *BatchGroupsService) Create(ctx context.Context, profileID int64, req *CreateBatchGroupRequest) (*BatchGroup, error) {
+	var group BatchGroup
+	path := fmt.Sprintf("/v3/profiles/%d/batch-groups", profileID)
+	err := s.client.Post(ctx, path, req, &group)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// Get retrieves a batch group by ID.
+// GET /v3/profiles/{profileId}/batch-groups/{batchGroupId}
+func (s *BatchGroupsService) Get(ctx context.Context, profileID int64, batchGroupID string) (*BatchGroup, error) {
+	var group BatchGroup
+	path := fmt.Sprintf("/v3/profiles/%d/batch-groups/%s", profileID, batchGroupID)
+	err := s.client.Get(ctx, path, nil, &group)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// AddTransfer creates a transfer inside a batch group.
+// POST /v3/profiles/{profileId}/batch-groups/{batchGroupId}/transfers
+func (s *BatchGroupsService) AddTransfer(ctx context.Context, profileID int64, batchGroupID string, req *CreateTransferRequest) (*Transfer, error) {
+	var transfer Transfer
+	path := fmt.Sprintf("/v3/profiles/%d/batch-groups/%s/transfers", profileID, batchGroupID)
+	err := s.client.Post(ctx, path, req, &transfer)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// Complete marks a batch group as complete so it can be funded.
+// No more transfers can be added after completion.
+// PATCH /v3/profiles/{profileId}/batch-groups/{batchGroupId}
+func (s *BatchGroupsService) Complete(ctx context.Context, profileID int64, batchGroupID string, version int) (*BatchGroup, error) {
+	return s.updateStatus(ctx, profileID, batchGroupID, version, BatchGroupStatusCompleted)
+}
+
+// Cancel cancels a batch group and all of its transfers.
+// PATCH /v3/profiles/{profileId}/batch-groups/{batchGroupId}
+func (s *BatchGroupsService) Cancel(ctx context.Context, profileID int64, batchGroupID string, version int) (*BatchGroup, error) {
+	return s.updateStatus(ctx, profileID, batchGroupID, version, BatchGroupStatusCancelled)
+}
+
+func (s *BatchGroupsService) updateStatus(ctx context.Context, profileID int64, batchGroupID string, version int, status BatchGroupStatus) (*BatchGroup, error) {
+	req := struct {
+		Status  BatchGroupStatus `json:"status"`
+		Version int              `json:"version"`
+	}{Status: status, Version: version}
+	var group BatchGroup
+	path := fmt.Sprintf("/v3/profiles/%d/batch-groups/%s", profileID, batchGroupID)
+	err := s.client.Request(ctx, "PATCH", path, nil, req, &group)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// Fund funds a completed batch group from the profile's balance.
+// POST /v3/profiles/{profileId}/batch-payments/{batchGroupId}/payments
+func (s *BatchGroupsService) Fund(ctx context.Context, profileID int64, batchGroupID string) (*BatchPayment, error) {
+	req := FundTransferRequest{Type: "BALANCE"}
+	var payment BatchPayment
+	path := fmt.Sprintf("/v3/profiles/%d/batch-payments/%s/payments", profileID, batchGroupID)
+	err := s.client.Post(ctx, path, req, &payment)
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
@@ -0,0 +1,136 @@
+package wise
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCachePrefixes are the endpoints cached by WithCache when no
+// prefixes are given: data that changes rarely or only by the user's own
+// actions.
+var defaultCachePrefixes = []string{
+	"/v1/rates",
+	"/v1/currencies",
+	"/v1/currency-pairs",
+	"/v1/me",
+}
+
+// defaultCachePaths are the path.Match patterns of the profile list and
+// get endpoints, cached by default without the resources nested under a
+// profile, such as statements and activities.
+var defaultCachePaths = []string{
+	"/v1/profiles",
+	"/v1/profiles/*",
+	"/v2/profiles",
+	"/v2/profiles/*",
+}
+
+// WithCache caches successful GET responses for ttl. Only paths starting
+// with one of prefixes are cached; with no prefixes, exchange rates,
+// currencies, the profile list and profiles, and the current user are. Any
+// successful request other than a GET clears the cache, so the client
+// never serves data it has just changed.
+//
+// Note that a prefix such as /v1/profiles also matches nested resources
+// such as balance statements.
+func WithCache(ttl time.Duration, prefixes ...string) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.cache = nil
+			return
+		}
+		var paths []string
+		if len(prefixes) == 0 {
+			prefixes, paths = defaultCachePrefixes, defaultCachePaths
+		}
+		c.cache = &responseCache{
+			ttl:      ttl,
+			prefixes: prefixes,
+			paths:    paths,
+			entries:  make(map[string]cacheEntry),
+		}
+	}
+}
+
+// ClearCache removes all cached responses.
+func (c *Client) ClearCache() {
+	c.cache.clear()
+}
+
+// responseCache is an in-memory TTL cache of response bodies keyed by URL.
+type responseCache struct {
+	ttl      time.Duration
+	prefixes []string
+	paths    []string // path.Match patterns
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// cacheable reports whether a request's response may be cached.
+func (rc *responseCache) cacheable(method, rawURL string) bool {
+	if rc == nil || method != http.MethodGet {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	for _, p := range rc.prefixes {
+		if strings.HasPrefix(u.Path, p) {
+			return true
+		}
+	}
+	for _, p := range rc.paths {
+		if ok, _ := path.Match(p, u.Path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *responseCache) get(key string) ([]byte, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	e, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+	return e.body, true
+}
+
+func (rc *responseCache) set(key string, body []byte) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range rc.entries {
+		if now.After(e.expires) {
+			delete(rc.entries, k)
+		}
+	}
+	rc.entries[key] = cacheEntry{body: body, expires: now.Add(rc.ttl)}
+}
+
+func (rc *responseCache) clear() {
+	if rc == nil {
+		return
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	clear(rc.entries)
+}
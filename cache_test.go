@@ -0,0 +1,56 @@
+package wise
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Cache(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method+" "+r.URL.Path]++
+		w.Write([]byte(`[{"id": 1, "type": "personal"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithCache(time.Minute))
+	ctx := context.Background()
+
+	for range 3 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		if len(profiles) != 1 || profiles[0].ID != 1 {
+			t.Fatalf("Unexpected profiles: %+v", profiles)
+		}
+	}
+	if n := calls["GET /v1/profiles"]; n != 1 {
+		t.Errorf("Expected 1 profiles request, got %d", n)
+	}
+
+	// Uncached paths always hit the server.
+	client.Transfers.List(ctx, nil)
+	client.Transfers.List(ctx, nil)
+	if n := calls["GET /v1/transfers"]; n != 2 {
+		t.Errorf("Expected 2 transfers requests, got %d", n)
+	}
+
+	// Resources nested under a profile are not cached by default.
+	for range 2 {
+		client.Get(ctx, "/v1/profiles/1/activities", nil, nil)
+	}
+	if n := calls["GET /v1/profiles/1/activities"]; n != 2 {
+		t.Errorf("Expected 2 activities requests, got %d", n)
+	}
+
+	// Writes clear the cache.
+	client.Recipients.Delete(ctx, 1)
+	client.Profiles.List(ctx)
+	if n := calls["GET /v1/profiles"]; n != 2 {
+		t.Errorf("Expected cache to be cleared after a write, got %d requests", n)
+	}
+}
@@ -0,0 +1,293 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// CardsService handles card-related API calls.
+type CardsService struct {
+	client *Client
+}
+
+// CardStatus represents the status of a card.
+type CardStatus string
+
+const (
+	CardStatusActive   CardStatus = "ACTIVE"
+	CardStatusInactive CardStatus = "INACTIVE"
+	CardStatusFrozen   CardStatus = "FROZEN"
+	CardStatusBlocked  CardStatus = "BLOCKED"
+	CardStatusExpired  CardStatus = "EXPIRED"
+)
+
+// Card represents a Wise debit card.
+type Card struct {
+	Token                    string          `json:"token"`
+	ProfileID                int64           `json:"profileId"`
+	ClientID                 string          `json:"clientId,omitempty"`
+	Status                   CardStatusValue `json:"status"`
+	CardHolderName           string          `json:"cardHolderName"`
+	ExpiryDate               Timestamp       `json:"expiryDate,omitempty"`
+	LastFourDigits           string          `json:"lastFourDigits"`
+	BankIdentificationNumber string          `json:"bankIdentificationNumber,omitempty"`
+	PhoneNumber              string          `json:"phoneNumber,omitempty"`
+	CardProgram              *CardProgram    `json:"cardProgram,omitempty"`
+	CreationTime             Timestamp       `json:"creationTime,omitempty"`
+	ModificationTime         Timestamp       `json:"modificationTime,omitempty"`
+}
+
+// CardStatusValue wraps the card status as returned by the API.
+type CardStatusValue struct {
+	Value CardStatus `json:"value"`
+}
+
+// CardProgram describes the program a card was issued under.
+type CardProgram struct {
+	Name            string   `json:"name"`
+	Scheme          string   `json:"scheme"` // VISA, MASTERCARD
+	DefaultCurrency Currency `json:"defaultCurrency"`
+	CardType        string   `json:"cardType"` // PHYSICAL, VIRTUAL_NOT_REQUIRING_PHYSICAL
+}
+
+// SpendingLimits represents the spending limits configured on a card.
+type SpendingLimits struct {
+	Spendings []SpendingLimitGroup `json:"spendings"`
+}
+
+// SpendingLimitGroup groups limits for one kind of spending (e.g. ATM_WITHDRAWAL, ECOM_PURCHASE).
+type SpendingLimitGroup struct {
+	Type   string          `json:"type"`
+	Limits []SpendingLimit `json:"limits"`
+}
+
+// SpendingLimit represents a single spending limit and its current usage.
+type SpendingLimit struct {
+	Type      string    `json:"type"` // TRANSACTION, DAILY, MONTHLY, LIFETIME
+	Usage     float64   `json:"usage,omitempty"`
+	Threshold float64   `json:"threshold"`
+	Currency  Currency  `json:"currency,omitempty"`
+	ExpiresAt Timestamp `json:"expiresAt,omitempty"`
+}
+
+// UpdateSpendingLimitsRequest represents the request to update card spending limits.
+type UpdateSpendingLimitsRequest struct {
+	Spendings []SpendingLimitGroup `json:"spendings"`
+}
+
+// ListCardsParams represents the parameters for listing cards.
+type ListCardsParams struct {
+	PageSize   int
+	PageNumber int
+}
+
+// List returns the cards for a profile.
+// GET /v3/spend/profiles/{profileId}/cards
+func (s *CardsService) List(ctx context.Context, profileID int64, params *ListCardsParams) ([]Card, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.PageSize > 0 {
+			query.Set("pageSize", strconv.Itoa(params.PageSize))
+		}
+		if params.PageNumber > 0 {
+			query.Set("pageNumber", strconv.Itoa(params.PageNumber))
+		}
+	}
+
+	var result struct {
+		TotalCount int    `json:"totalCount"`
+		Cards      []Card `json:"cards"`
+	}
+	path := fmt.Sprintf("/v3/spend/profiles/%d/cards", profileID)
+	err := s.client.Get(ctx, path, query, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Cards, nil
+}
+
+// Get retrieves a card by its token.
+// GET /v3/spend/profiles/{profileId}/cards/{cardToken}
+func (s *CardsService) Get(ctx context.Context, profileID int64, cardToken string) (*Card, error) {
+	var card Card
+	path := fmt.Sprintf("/v3/spend/profiles/%d/cards/%s", profileID, cardToken)
+	err := s.client.Get(ctx, path, nil, &card)
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// UpdateStatus changes the status of a card.
+// PUT /v3/spend/profiles/{profileId}/cards/{cardToken}/status
+func (s *CardsService) UpdateStatus(ctx context.Context, profileID int64, cardToken string, status CardStatus) (*Card, error) {
+	req := struct {
+		Status CardStatus `json:"status"`
+	}{Status: status}
+	var card Card
+	path := fmt.Sprintf("/v3/spend/profiles/%d/cards/%s/status", profileID, cardToken)
+	err := s.client.Put(ctx, path, req, &card)
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// Freeze temporarily freezes a card.
+func (s *CardsService) Freeze(ctx context.Context, profileID int64, cardToken string) (*Card, error) {
+	return s.UpdateStatus(ctx, profileID, cardToken, CardStatusFrozen)
+}
+
+// Unfreeze reactivates a frozen card.
+func (s *CardsService) Unfreeze(ctx context.Context, profileID int64, cardToken string) (*Card, error) {
+	return s.UpdateStatus(ctx, profileID, cardToken, CardStatusActive)
+}
+
+// GetSpendingLimits retrieves the spending limits of a card.
+// GET /v3/spend/profiles/{profileId}/cards/{cardToken}/spending-limits
+func (s *CardsService) GetSpendingLimits(ctx context.Context, profileID int64, cardToken string) (*SpendingLimits, error) {
+	var limits SpendingLimits
+	path := fmt.Sprintf("/v3/spend/profiles/%d/cards/%s/spending-limits", profileID, cardToken)
+	err := s.client.Get(ctx, path, nil, &limits)
+	if err != nil {
+		return nil, err
+	}
+	return &limits, nil
+}
+
+// UpdateSpendingLimits updates the spending limits of a card.
+// PATCH /v3/spend/profiles/{profileId}/cards/{cardToken}/spending-limits
+func (s *CardsService) UpdateSpendingLimits(ctx context.Context, profileID int64, cardToken string, req *UpdateSpendingLimitsRequest) (*SpendingLimits, error) {
+	var limits SpendingLimits
+	path := fmt.Sprintf("/v3/spend/profiles/%d/cards/%s/spending-limits", profileID, cardToken)
+	err := s.client.Request(ctx, "PATCH", path, nil, req, &limits)
+	if err != nil {
+		return nil, err
+	}
+	return &limits, nil
+}
+
+// CardTransaction represents a transaction made with a card.
+type CardTransaction struct {
+	ID                        string        `json:"id"`
+	CardToken                 string        `json:"cardToken"`
+	Type                      string        `json:"type"`  // POS_PURCHASE, ECOM_PURCHASE, CASH_WITHDRAWAL, REFUND, ...
+	State                     string        `json:"state"` // IN_PROGRESS, COMPLETED, DECLINED, CANCELLED
+	DeclineReason             string        `json:"declineReason,omitempty"`
+	CreationTime              Timestamp     `json:"creationTime"`
+	CardLastDigits            string        `json:"cardLastDigits,omitempty"`
+	AuthorisationMethod       string        `json:"authorisationMethod,omitempty"`
+	BalanceTransactionID      int64         `json:"balanceTransactionId,omitempty"`
+	TransactionAmount         CardAmount    `json:"transactionAmount"`
+	TransactionAmountWithFees CardAmount    `json:"transactionAmountWithFees,omitempty"`
+	Fees                      []CardFee     `json:"fees,omitempty"`
+	Debits                    []CardDebit   `json:"debits,omitempty"`
+	Credit                    *CardCredit   `json:"credit,omitempty"`
+	Merchant                  *CardMerchant `json:"merchant,omitempty"`
+}
+
+// CardAmount is a monetary amount as returned by the card APIs.
+type CardAmount struct {
+	Amount   float64  `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// CardFee represents a fee charged on a card transaction.
+type CardFee struct {
+	Amount   float64  `json:"amount"`
+	Currency Currency `json:"currency"`
+	FeeType  string   `json:"feeType"`
+}
+
+// CardDebit describes how a card transaction was debited from a balance,
+// including any currency conversion.
+type CardDebit struct {
+	BalanceID     int64      `json:"balanceId"`
+	DebitedAmount CardAmount `json:"debitedAmount"`
+	ForAmount     CardAmount `json:"forAmount"`
+	Rate          float64    `json:"rate,omitempty"`
+	Fee           CardAmount `json:"fee,omitempty"`
+	CreationTime  Timestamp  `json:"creationTime,omitempty"`
+}
+
+// CardCredit describes a credit (e.g. refund) to a balance from a card transaction.
+type CardCredit struct {
+	BalanceID      int64      `json:"balanceId"`
+	CreditedAmount CardAmount `json:"creditedAmount"`
+	CreationTime   Timestamp  `json:"creationTime,omitempty"`
+}
+
+// CardMerchant represents the merchant of a card transaction.
+type CardMerchant struct {
+	Name     string                `json:"name"`
+	Location *CardMerchantLocation `json:"location,omitempty"`
+	Category *CardMerchantCategory `json:"category,omitempty"`
+}
+
+// CardMerchantLocation is the location of a merchant.
+type CardMerchantLocation struct {
+	Country string `json:"country,omitempty"`
+	City    string `json:"city,omitempty"`
+	ZipCode string `json:"zipCode,omitempty"`
+	Region  string `json:"region,omitempty"`
+	State   string `json:"state,omitempty"`
+}
+
+// CardMerchantCategory is the merchant category (MCC) of a merchant.
+type CardMerchantCategory struct {
+	Name        string `json:"name"`
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+// ListCardTransactionsParams represents the parameters for listing card transactions.
+type ListCardTransactionsParams struct {
+	FromCreationTime string // ISO 8601 format
+	ToCreationTime   string // ISO 8601 format
+	PageSize         int
+	PageNumber       int
+}
+
+// ListTransactions returns the transactions made with a card.
+// GET /v3/spend/profiles/{profileId}/cards/{cardToken}/transactions
+func (s *CardsService) ListTransactions(ctx context.Context, profileID int64, cardToken string, params *ListCardTransactionsParams) ([]CardTransaction, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.FromCreationTime != "" {
+			query.Set("fromCreationTime", params.FromCreationTime)
+		}
+		if params.ToCreationTime != "" {
+			query.Set("toCreationTime", params.ToCreationTime)
+		}
+		if params.PageSize > 0 {
+			query.Set("pageSize", strconv.Itoa(params.PageSize))
+		}
+		if params.PageNumber > 0 {
+			query.Set("pageNumber", strconv.Itoa(params.PageNumber))
+		}
+	}
+
+	var result struct {
+		Transactions []CardTransaction `json:"transactions"`
+	}
+	path := fmt.Sprintf("/v3/spend/profiles/%d/cards/%s/transactions", profileID, cardToken)
+	err := s.client.Get(ctx, path, query, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.Transactions, nil
+}
+
+// GetTransaction retrieves a single card transaction.
+// GET /v4/spend/profiles/{profileId}/cards/{cardToken}/transactions/{transactionId}
+func (s *CardsService) GetTransaction(ctx context.Context, profileID int64, cardToken, transactionID string) (*CardTransaction, error) {
+	var tx CardTransaction
+	path := fmt.Sprintf("/v4/spend/profiles/%d/cards/%s/transactions/%s", profileID, cardToken, transactionID)
+	err := s.client.Get(ctx, path, nil, &tx)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
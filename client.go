@@ -5,12 +5,19 @@ package wise
 import (
 	"bytes"
 	"context"
+	"crypto/rsa"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -19,22 +26,59 @@ const (
 	// SandboxBaseURL is the base URL for the Wise sandbox API.
 	SandboxBaseURL = "https://api.sandbox.transferwise.tech"
 
+	// DefaultUserAgent identifies this library in the User-Agent header.
+	DefaultUserAgent = "plat-wise-go"
+
 	defaultTimeout = 30 * time.Second
 )
 
 // Client is the Wise API client.
 type Client struct {
-	baseURL    string
-	apiToken   string
-	httpClient *http.Client
+	baseURL     string
+	pathBaseURL map[string]string // Path prefix to base URL, see WithPathBaseURL
+	apiToken    string
+	tokenSource TokenSource
+	httpClient  *http.Client
+	scaKey      *rsa.PrivateKey
+	retry       *retryPolicy
+	limiter     *rate.Limiter
+	rateLimit   RateLimitStatus
+	rateLimitMu sync.Mutex
+	cache       *responseCache
+	flight      *singleflight.Group
+	strict      *strictDecoding
+	dump        *debugDump
+	metrics     Recorder
+	userAgent   string
+	headers     http.Header
+
+	idempotencyKeys   bool
+	adaptiveRateLimit bool
+
+	optionErr error // Invalid option, returned by every request
 
 	// Services
-	Profiles      *ProfilesService
-	Quotes        *QuotesService
-	Recipients    *RecipientsService
-	Transfers     *TransfersService
-	ExchangeRates *ExchangeRatesService
-	Balances      *BalancesService
+	Profiles        *ProfilesService
+	Quotes          *QuotesService
+	Recipients      *RecipientsService
+	Transfers       *TransfersService
+	ExchangeRates   *ExchangeRatesService
+	Balances        *BalancesService
+	Webhooks        *WebhooksService
+	Cards           *CardsService
+	BatchGroups     *BatchGroupsService
+	Simulation      *SimulationService
+	Comparisons     *ComparisonsService
+	Pricing         *PricingService
+	Assets          *AssetsService
+	Currencies      *CurrenciesService
+	Validators      *ValidatorsService
+	Users           *UsersService
+	AutoConversions *AutoConversionsService
+	PaymentRequests *PaymentRequestsService
+	Contacts        *ContactsService
+	Activities      *ActivitiesService
+	Partner         *PartnerService
 }
 
 // ClientOption is a function that configures the Client.
@@ -47,10 +91,12 @@ func WithBaseURL(baseURL string) ClientOption {
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client.
+// WithHTTPClient sets a custom HTTP client. The client is copied, so later
+// options such as WithTimeout do not change it.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
-		c.httpClient = httpClient
+		hc := *httpClient
+		c.httpClient = &hc
 	}
 }
 
@@ -68,11 +114,139 @@ func WithTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithTransport sets the HTTP transport, keeping the client's timeout.
+// Use it to add middleware such as tracing, or a custom dialer.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// WithTLSConfig sets the TLS configuration, e.g. client certificates for
+// mTLS egress or a corporate root CA. Other transport settings, including
+// proxies from the environment, are kept. A custom transport set with
+// WithTransport must be an *http.Transport, otherwise every request fails.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		if transport := c.cloneTransport("WithTLSConfig"); transport != nil {
+			transport.TLSClientConfig = config
+			c.httpClient.Transport = transport
+		}
+	}
+}
+
+// WithProxy sends requests through an HTTP proxy instead of the one from the
+// HTTP_PROXY and HTTPS_PROXY environment variables. Like WithTLSConfig, it
+// needs an *http.Transport.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(c *Client) {
+		if transport := c.cloneTransport("WithProxy"); transport != nil {
+			transport.Proxy = http.ProxyURL(proxyURL)
+			c.httpClient.Transport = transport
+		}
+	}
+}
+
+// cloneTransport returns a copy of the client's transport, or of the
+// default transport if none is set, for option to modify. A custom
+// RoundTripper cannot be modified: it is kept, and requests fail with an
+// error instead of bypassing it.
+func (c *Client) cloneTransport(option string) *http.Transport {
+	switch t := c.httpClient.Transport.(type) {
+	case nil:
+		return http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		return t.Clone()
+	default:
+		c.optionErr = fmt.Errorf("wise: %s needs an *http.Transport, not %T: configure the custom transport instead", option, t)
+		return nil
+	}
+}
+
+// WithUserAgent identifies the application in the User-Agent header, e.g.
+// "acme-payouts/1.2". The library's DefaultUserAgent is appended so that
+// Wise support can attribute traffic to both the application and this client.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent + " " + DefaultUserAgent
+	}
+}
+
+// WithHeader adds a header sent with every request, e.g. a partner or
+// application identifier, so that apps sharing one token can be told apart.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+type callTimeoutKey struct{}
+
+// WithCallTimeout returns a context that overrides the client's HTTP timeout
+// (see WithTimeout) for requests made with it, e.g. to allow long statement
+// downloads. A timeout of zero disables the HTTP timeout, leaving only the
+// context's own deadline.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, timeout)
+}
+
+type callBaseURLKey struct{}
+
+// WithCallBaseURL returns a context that sends requests made with it to
+// baseURL instead of the client's base URL, e.g. to reach an endpoint served
+// from another Wise host without creating a second Client.
+func WithCallBaseURL(ctx context.Context, baseURL string) context.Context {
+	return context.WithValue(ctx, callBaseURLKey{}, baseURL)
+}
+
+// WithPathBaseURL sends requests whose path starts with pathPrefix to
+// baseURL, e.g. WithPathBaseURL("/v3/profiles", "https://api.example.com")
+// to route a whole service elsewhere. The longest matching prefix wins.
+// WithCallBaseURL takes precedence.
+func WithPathBaseURL(pathPrefix, baseURL string) ClientOption {
+	return func(c *Client) {
+		if c.pathBaseURL == nil {
+			c.pathBaseURL = make(map[string]string)
+		}
+		c.pathBaseURL[pathPrefix] = baseURL
+	}
+}
+
+// baseURLFor returns the base URL for a request to path.
+func (c *Client) baseURLFor(ctx context.Context, path string) string {
+	if baseURL, ok := ctx.Value(callBaseURLKey{}).(string); ok && baseURL != "" {
+		return baseURL
+	}
+	baseURL, longest := c.baseURL, -1
+	for prefix, u := range c.pathBaseURL {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			baseURL, longest = u, len(prefix)
+		}
+	}
+	return baseURL
+}
+
+// httpClientFor returns the HTTP client to use for a request, applying any
+// per-call timeout from ctx.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	timeout, ok := ctx.Value(callTimeoutKey{}).(time.Duration)
+	if !ok || timeout == c.httpClient.Timeout {
+		return c.httpClient
+	}
+	hc := *c.httpClient
+	hc.Timeout = timeout
+	return &hc
+}
+
 // NewClient creates a new Wise API client.
 func NewClient(apiToken string, opts ...ClientOption) *Client {
 	c := &Client{
-		baseURL:  ProductionBaseURL,
-		apiToken: apiToken,
+		baseURL:   ProductionBaseURL,
+		apiToken:  apiToken,
+		userAgent: DefaultUserAgent,
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
@@ -89,62 +263,133 @@ func NewClient(apiToken string, opts ...ClientOption) *Client {
 	c.Transfers = &TransfersService{client: c}
 	c.ExchangeRates = &ExchangeRatesService{client: c}
 	c.Balances = &BalancesService{client: c}
+	c.Webhooks = &WebhooksService{client: c}
+	c.Cards = &CardsService{client: c}
+	c.BatchGroups = &BatchGroupsService{client: c}
+	c.Simulation = &SimulationService{client: c}
+	c.Comparisons = &ComparisonsService{client: c}
+	c.Pricing = &PricingService{client: c}
+	c.Assets = &AssetsService{client: c}
+	c.Currencies = &CurrenciesService{client: c}
+	c.Validators = &ValidatorsService{client: c}
+	c.Users = &UsersService{client: c}
+	c.AutoConversions = &AutoConversionsService{client: c}
+	c.PaymentRequests = &PaymentRequestsService{client: c}
+	c.Contacts = &ContactsService{client: c}
+	c.Activities = &ActivitiesService{client: c}
+	c.Partner = &PartnerService{client: c}
 
 	return c
 }
 
 // Request performs an HTTP request to the Wise API.
 func (c *Client) Request(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
-	u, err := url.Parse(c.baseURL + path)
+	return c.request(ctx, method, path, query, body, result, nil)
+}
+
+// Do sends a request to the Wise API and returns the raw response, for
+// endpoints that this package does not model yet. The body, if any, is
+// encoded as JSON. Authentication, retries, rate limiting and SCA signing
+// apply as for other calls, but the response is not checked or decoded:
+// error statuses are returned as a response, not an APIError. The caller
+// must close the response body.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	rawURL, err := c.buildURL(ctx, path, query)
 	if err != nil {
-		return fmt.Errorf("parsing URL: %w", err)
+		return nil, err
 	}
 
-	if query != nil {
-		u.RawQuery = query.Encode()
+	var jsonBody []byte
+	if body != nil {
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling request body: %w", err)
+		}
 	}
 
-	var bodyReader io.Reader
+	resp, err := c.do(ctx, method, rawURL, jsonBody, nil)
+	if err != nil {
+		return nil, err
+	}
+	if method != http.MethodGet {
+		c.cache.clear()
+	}
+	return resp, nil
+}
+
+// request is Request with additional per-call headers.
+func (c *Client) request(ctx context.Context, method, path string, query url.Values, body, result interface{}, header http.Header) error {
+	rawURL, err := c.buildURL(ctx, path, query)
+	if err != nil {
+		return err
+	}
+
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshaling request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), bodyReader)
+	return c.exchange(ctx, method, rawURL, jsonBody, header, result)
+}
+
+// exchange sends an encoded request body and decodes the JSON response into result.
+func (c *Client) exchange(ctx context.Context, method, rawURL string, body []byte, header http.Header, result interface{}) error {
+	cacheable := c.cache.cacheable(method, rawURL)
+	if cacheable {
+		if cached, ok := c.cache.get(rawURL); ok {
+			return c.decode(method, rawURL, cached, result)
+		}
+	}
+
+	var respBody []byte
+	var err error
+	if c.flight != nil && method == http.MethodGet && header == nil {
+		var v interface{}
+		v, err, _ = c.flight.Do(rawURL, func() (interface{}, error) {
+			return c.fetch(ctx, method, rawURL, body, header)
+		})
+		respBody, _ = v.([]byte)
+	} else {
+		respBody, err = c.fetch(ctx, method, rawURL, body, header)
+	}
 	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
+		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.apiToken)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	if cacheable {
+		c.cache.set(rawURL, respBody)
+	} else if method != http.MethodGet {
+		c.cache.clear()
+	}
 
-	resp, err := c.httpClient.Do(req)
+	return c.decode(method, rawURL, respBody, result)
+}
+
+// fetch sends a request and returns the response body, or an APIError if
+// the response has an error status.
+func (c *Client) fetch(ctx context.Context, method, rawURL string, body []byte, header http.Header) ([]byte, error) {
+	resp, err := c.do(ctx, method, rawURL, body, header)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("reading response body: %w", err)
+		return nil, fmt.Errorf("reading response body: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err != nil {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    string(respBody),
-			}
-		}
-		apiErr.StatusCode = resp.StatusCode
-		return &apiErr
+		return nil, newAPIError(resp, respBody)
 	}
+	return respBody, nil
+}
 
+// decodeResponse decodes a JSON response body into result, if both are set.
+func decodeResponse(respBody []byte, result interface{}) error {
 	if result != nil && len(respBody) > 0 {
 		if err := json.Unmarshal(respBody, result); err != nil {
 			return fmt.Errorf("unmarshaling response: %w", err)
@@ -154,6 +399,158 @@ func (c *Client) Request(ctx context.Context, method, path string, query url.Val
 	return nil
 }
 
+// stream performs a GET request and returns the response body unread, for
+// downloads that should not be buffered or decoded. The caller must close it.
+func (c *Client) stream(ctx context.Context, path string, query url.Values, accept string) (io.ReadCloser, error) {
+	rawURL, err := c.buildURL(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	header := http.Header{}
+	header.Set("Accept", accept)
+	resp, err := c.do(ctx, http.MethodGet, rawURL, nil, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading response body: %w", err)
+		}
+		return nil, newAPIError(resp, respBody)
+	}
+
+	return resp.Body, nil
+}
+
+// buildURL joins the base URL for the request, path and query.
+func (c *Client) buildURL(ctx context.Context, path string, query url.Values) (string, error) {
+	u, err := url.Parse(c.baseURLFor(ctx, path) + path)
+	if err != nil {
+		return "", fmt.Errorf("parsing URL: %w", err)
+	}
+
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+	return u.String(), nil
+}
+
+// do sends a request and returns the unread response, retrying transient
+// failures when a retry policy is configured (see WithRetry).
+func (c *Client) do(ctx context.Context, method, rawURL string, body []byte, header http.Header) (*http.Response, error) {
+	if c.optionErr != nil {
+		return nil, c.optionErr
+	}
+	header = c.withIdempotencyKey(method, header)
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.waitForBudget(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := c.doOnce(ctx, method, rawURL, body, header)
+		wait, ok := c.retry.shouldRetry(ctx, method, header, resp, err, attempt)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doOnce sends a request and returns the unread response. If the API asks for
+// Strong Customer Authentication and a signing key is configured, the
+// one-time token is signed and the request is sent again.
+func (c *Client) doOnce(ctx context.Context, method, rawURL string, body []byte, header http.Header) (*http.Response, error) {
+	resp, err := c.send(ctx, method, rawURL, body, header)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.scaKey != nil && resp.StatusCode == http.StatusForbidden {
+		if ott := resp.Header.Get("x-2fa-approval"); ott != "" {
+			resp.Body.Close()
+			signature, err := signSCAToken(c.scaKey, ott)
+			if err != nil {
+				return nil, err
+			}
+			header = header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			header.Set("x-2fa-approval", ott)
+			header.Set("X-Signature", signature)
+			return c.send(ctx, method, rawURL, body, header)
+		}
+	}
+
+	return resp, nil
+}
+
+// send performs a single HTTP round trip with authentication and default headers.
+func (c *Client) send(ctx context.Context, method, rawURL string, body []byte, header http.Header) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	token := c.apiToken
+	if c.tokenSource != nil {
+		t, err := c.tokenSource.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getting token: %w", err)
+		}
+		token = t.AccessToken
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	for k, v := range c.headers {
+		req.Header[k] = v
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	start := time.Now()
+	resp, err := c.httpClientFor(ctx).Do(req)
+	if c.metrics != nil {
+		m := RequestMetrics{Method: method, Endpoint: endpointName(rawURL), Duration: time.Since(start), Err: err}
+		if resp != nil {
+			m.StatusCode = resp.StatusCode
+		}
+		c.metrics.RecordRequest(m)
+	}
+	if c.dump != nil {
+		c.dump.record(req, body, resp, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	c.updateRateLimit(resp.Header)
+	recordResponseMeta(ctx, resp)
+	return resp, nil
+}
+
 // Get performs a GET request.
 func (c *Client) Get(ctx context.Context, path string, query url.Values, result interface{}) error {
 	return c.Request(ctx, http.MethodGet, path, query, nil, result)
@@ -0,0 +1,219 @@
+package wise
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_CallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithTimeout(10*time.Millisecond))
+	if _, err := client.Profiles.List(context.Background()); err == nil {
+		t.Fatal("Expected the client timeout to be exceeded")
+	}
+
+	ctx := WithCallTimeout(context.Background(), time.Second)
+	if _, err := client.Profiles.List(ctx); err != nil {
+		t.Errorf("Expected the per-call timeout to apply, got %v", err)
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL),
+		WithUserAgent("acme-payouts/1.2"), WithHeader("X-External-Correlation-Id", "acme"))
+	client.Profiles.List(context.Background())
+
+	if ua := header.Get("User-Agent"); ua != "acme-payouts/1.2 "+DefaultUserAgent {
+		t.Errorf("Unexpected User-Agent: %q", ua)
+	}
+	if id := header.Get("X-External-Correlation-Id"); id != "acme" {
+		t.Errorf("Missing identification header, got %q", id)
+	}
+}
+
+func TestClient_APIErrorDetails(t *testing.T) {
+	body := `{"message":"slow down"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	_, err := client.Profiles.List(context.Background())
+	err = fmt.Errorf("listing profiles: %w", err)
+
+	if !IsRateLimited(err) || IsNotFound(err) {
+		t.Fatalf("Expected a wrapped rate limit error, got %v", err)
+	}
+	if wait, ok := RetryAfter(err); !ok || wait != 7*time.Second {
+		t.Errorf("Expected Retry-After of 7s, got %v", wait)
+	}
+	apiErr, _ := AsAPIError(err)
+	if apiErr.RequestID != "req-123" || string(apiErr.Body) != body {
+		t.Errorf("Unexpected error details: %+v", apiErr)
+	}
+}
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Missing Authorization header")
+		}
+		if r.URL.Path != "/v1/new-endpoint" || r.URL.Query().Get("profile") != "1" {
+			t.Errorf("Unexpected URL: %s", r.URL)
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusTeapot)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	resp, err := client.Do(context.Background(), http.MethodPost, "/v1/new-endpoint",
+		url.Values{"profile": {"1"}}, map[string]string{"name": "value"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusTeapot || string(body) != `{"name":"value"}` {
+		t.Errorf("Unexpected response: %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestClient_RequestCoalescing(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(`[{"id": 1}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRequestCoalescing())
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profiles, err := client.Profiles.List(context.Background())
+			if err != nil || len(profiles) != 1 {
+				t.Errorf("List = %v, %v", profiles, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected concurrent GETs to share one request, got %d", n)
+	}
+}
+
+type countingTransport struct {
+	calls int
+	next  http.RoundTripper
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.calls++
+	return t.next.RoundTrip(r)
+}
+
+func TestClient_TransportOptions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	// The test server's certificate is only trusted through the TLS config.
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	client := NewClient("test-token", WithBaseURL(server.URL), WithTimeout(5*time.Second),
+		WithTLSConfig(&tls.Config{RootCAs: roots}))
+	if _, err := client.Profiles.List(context.Background()); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected the timeout to be kept, got %v", client.httpClient.Timeout)
+	}
+
+	transport := &countingTransport{next: server.Client().Transport}
+	client = NewClient("test-token", WithBaseURL(server.URL), WithTransport(transport))
+	if _, err := client.Profiles.List(context.Background()); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if transport.calls != 1 {
+		t.Errorf("Expected the custom transport to be used, got %d calls", transport.calls)
+	}
+
+	// A custom transport cannot take a TLS config, so it is an error
+	// rather than silently bypassed.
+	client = NewClient("test-token", WithBaseURL(server.URL), WithTransport(transport),
+		WithTLSConfig(&tls.Config{RootCAs: roots}))
+	if _, err := client.Profiles.List(context.Background()); err == nil || !strings.Contains(err.Error(), "WithTLSConfig") {
+		t.Errorf("Expected an option error, got %v", err)
+	}
+	if transport.calls != 1 {
+		t.Errorf("Expected no request to be sent, got %d calls", transport.calls)
+	}
+
+	// Options do not change a shared HTTP client.
+	shared := &http.Client{}
+	NewClient("test-token", WithHTTPClient(shared), WithTimeout(time.Second), WithProxy(&url.URL{Scheme: "http", Host: "proxy:8080"}))
+	if shared.Timeout != 0 || shared.Transport != nil {
+		t.Errorf("Expected the shared client to be unchanged, got %+v", shared)
+	}
+}
+
+func TestClient_BaseURLOverrides(t *testing.T) {
+	newServer := func(name string, hits *[]string) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*hits = append(*hits, name+" "+r.URL.Path)
+			w.Write([]byte(`[]`))
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	var hits []string
+	core := newServer("core", &hits)
+	other := newServer("other", &hits)
+	call := newServer("call", &hits)
+
+	client := NewClient("test-token", WithBaseURL(core.URL), WithPathBaseURL("/v2/profiles", other.URL))
+	ctx := context.Background()
+	client.Profiles.List(ctx)
+	client.Get(ctx, "/v2/profiles/1/contacts", nil, nil)
+	client.Profiles.List(WithCallBaseURL(ctx, call.URL))
+
+	want := []string{"core /v1/profiles", "other /v2/profiles/1/contacts", "call /v1/profiles"}
+	if fmt.Sprint(hits) != fmt.Sprint(want) {
+		t.Errorf("Expected requests %v, got %v", want, hits)
+	}
+}
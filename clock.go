@@ -0,0 +1,22 @@
+package wise
+
+import "time"
+
+// Clock tells the current time. Token expiry logic accepts a Clock (see
+// OAuthConfig.Clock) so that tests can simulate expiry without waiting;
+// the quote expiry helpers such as Quote.TimeUntilExpiryAt take the time
+// itself, e.g. from Clock.Now.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now implements Clock.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock backed by time.Now.
+var SystemClock Clock = ClockFunc(time.Now)
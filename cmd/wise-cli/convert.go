@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/commands"
+	"github.com/joeblew999/plat-wise/output"
+)
+
+// runConvert converts between two balances of the first profile. The
+// quoted rate and fee are shown for confirmation unless yes is set.
+func runConvert(ctx context.Context, client *wise.Client, out output.Format, from, to string, amount float64, yes bool) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if amount <= 0 {
+		fail(out, errors.New("amount must be positive"))
+		return
+	}
+
+	quoted := commands.QuoteConversion(ctx, client, from, to, amount)
+	if quoted.Error != "" {
+		fail(out, errors.New(quoted.Error))
+		return
+	}
+	if !yes {
+		// The prompt goes to stderr so structured output stays parseable.
+		fmt.Fprintf(os.Stderr, "Convert %.2f %s to %.2f %s at %.6f (fee %.2f %s)? [y/N] ",
+			quoted.SourceAmount, from, quoted.TargetAmount, to, quoted.Rate, quoted.Fee, from)
+		if !confirm() {
+			fmt.Fprintln(os.Stderr, "Cancelled")
+			return
+		}
+	}
+
+	result := commands.ExecuteConversion(ctx, client, quoted)
+	if out != output.Text {
+		emit(out, result, nil)
+		if result.Error != "" {
+			fail(out, errors.New(result.Error))
+		}
+		return
+	}
+	if result.Error != "" {
+		fmt.Printf("Error: %s\n", result.Error)
+		os.Exit(1)
+	}
+	fmt.Printf("Converted %.2f %s to %.2f %s\n", result.SourceAmount, from, result.TargetAmount, to)
+	fmt.Printf("  Rate:  %.6f\n", result.Rate)
+	fmt.Printf("  Fee:   %.2f %s\n", result.Fee, from)
+	fmt.Printf("  Quote: %s\n", result.QuoteID)
+}
+
+// confirm reads a yes/no answer from stdin, defaulting to no.
+func confirm() bool {
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
@@ -2,49 +2,82 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"text/tabwriter"
 
 	wise "github.com/joeblew999/plat-wise"
 	"github.com/joeblew999/plat-wise/commands"
+	"github.com/joeblew999/plat-wise/output"
 )
 
 var cmdHelp = map[string]struct {
-	desc   string
-	usage  string
-	flags  []string
+	desc  string
+	usage string
+	flags []string
 }{
 	"rates": {
-		desc:  "Get exchange rates for common currency pairs",
-		usage: "wise-cli -cmd rates",
-		flags: []string{},
+		desc:  "Get exchange rates for common or given currency pairs",
+		usage: "wise-cli -cmd rates [-pairs EUR/USD,GBP/INR | -base SGD]\n  wise-cli -cmd rates watch [-pairs EUR-USD,GBP-USD] [-interval 30s] [-threshold 0.5]",
+		flags: []string{"pairs", "base", "interval", "threshold"},
 	},
 	"profiles": {
 		desc:  "List all Wise profiles for the authenticated user",
 		usage: "wise-cli -cmd profiles",
 		flags: []string{},
 	},
+	"whoami": {
+		desc:  "Show the authenticated Wise user",
+		usage: "wise-cli -cmd whoami",
+		flags: []string{},
+	},
+	"currencies": {
+		desc:  "List currencies supported by Wise",
+		usage: "wise-cli -cmd currencies",
+		flags: []string{},
+	},
 	"balances": {
 		desc:  "Show account balances across all profiles and currencies",
 		usage: "wise-cli -cmd balances",
 		flags: []string{},
 	},
 	"statements": {
-		desc:  "Get transaction history for the last N days",
-		usage: "wise-cli -cmd statements [-days 30]",
-		flags: []string{"days"},
+		desc:  "Get transaction history for the last N days, or export it to a file",
+		usage: "wise-cli -cmd statements [-days 30]\n  wise-cli -cmd statements export -format csv|ofx|qif|pdf [-currency EUR] [-start 2023-01-01] [-end 2024-12-31] [-out file]",
+		flags: []string{"days", "format", "currency", "start", "end", "out"},
 	},
 	"quote": {
 		desc:  "Get a quote for currency conversion",
-		usage: "wise-cli -cmd quote -from USD -to EUR -amount 100",
-		flags: []string{"from", "to", "amount"},
+		usage: "wise-cli -cmd quote -from USD -to EUR -amount 100 [-payin BANK_TRANSFER]",
+		flags: []string{"from", "to", "amount", "payin"},
 	},
 	"rate-history": {
 		desc:  "Get historical exchange rates over a period",
 		usage: "wise-cli -cmd rate-history -from EUR -to USD [-days 7] [-group day]",
 		flags: []string{"from", "to", "days", "group"},
 	},
+	"convert": {
+		desc:  "Convert between two balances of your profile",
+		usage: "wise-cli -cmd convert -from EUR -to USD -amount 500 [-yes]",
+		flags: []string{"from", "to", "amount", "yes"},
+	},
+	"transfers": {
+		desc:  "Show a transfer's status, issues, delivery estimate and tracking, or cancel it",
+		usage: "wise-cli -cmd transfers show <id>\n  wise-cli -cmd transfers cancel <id> [-yes]",
+		flags: []string{"yes"},
+	},
+	"recipients": {
+		desc:  "List, show, create or delete recipients",
+		usage: "wise-cli -cmd recipients [list [-currency GBP] | show <id> | create <details.json|yaml> | delete <id>]",
+		flags: []string{"currency"},
+	},
+	"logout": {
+		desc:  "Revoke and delete the saved OAuth token",
+		usage: "wise-cli -cmd logout",
+		flags: []string{},
+	},
 	"help": {
 		desc:  "Show help for a specific command",
 		usage: "wise-cli -cmd help [command]",
@@ -58,7 +91,14 @@ func printUsage() {
 	fmt.Println("Usage: wise-cli -cmd <command> [flags]")
 	fmt.Println()
 	fmt.Println("Environment:")
-	fmt.Println("  WISE_API_TOKEN    Required. Your Wise API token")
+	fmt.Println("  WISE_CONFIG       Config file with named accounts (default ~/.config/wise/config.toml)")
+	fmt.Println("  WISE_API_TOKEN    Your Wise API token (or OAuth, below)")
+	fmt.Println("  WISE_CLIENT_ID    OAuth client ID; uses the token saved by wise-server")
+	fmt.Println("  WISE_CLIENT_SECRET OAuth client secret")
+	fmt.Println("  WISE_TOKEN_STORE  Saved token location: file path or \"keyring\"")
+	fmt.Println("  WISE_TOKEN_KEY    Passphrase the token file is encrypted with")
+	fmt.Println("  WISE_DEBUG_DUMP   Directory to write redacted request/response traces to")
+	fmt.Println("  WISE_SCA_KEY_FILE Optional. RSA private key for SCA-protected endpoints")
 	fmt.Println()
 	fmt.Println("Commands:")
 	for name, help := range cmdHelp {
@@ -66,7 +106,9 @@ func printUsage() {
 	}
 	fmt.Println()
 	fmt.Println("Global Flags:")
+	fmt.Println("  -account    Account from the config file (default: its default account)")
 	fmt.Println("  -sandbox    Use sandbox environment")
+	fmt.Println("  -output     Output format: text, json, csv or table (default text)")
 	fmt.Println()
 	fmt.Println("Use 'wise-cli -cmd help <command>' for more information about a command.")
 }
@@ -89,11 +131,22 @@ func printCmdHelp(cmdName string) {
 		fmt.Println()
 		fmt.Println("Flags:")
 		flagDescs := map[string]string{
-			"from":   "Source currency code (e.g., USD, EUR, GBP)",
-			"to":     "Target currency code (e.g., USD, EUR, GBP)",
-			"amount": "Amount to convert in source currency",
-			"days":   "Number of days (default varies by command)",
-			"group":  "Grouping interval: day, hour, minute (default: day)",
+			"from":      "Source currency code (e.g., USD, EUR, GBP)",
+			"to":        "Target currency code (e.g., USD, EUR, GBP)",
+			"amount":    "Amount to convert in source currency",
+			"days":      "Number of days (default varies by command)",
+			"group":     "Grouping interval: day, hour, minute (default: day)",
+			"pairs":     "Comma-separated currency pairs (e.g., EUR/USD,GBP-INR)",
+			"base":      "Base currency to get rates against USD, EUR, GBP and JPY",
+			"currency":  "Currency of the recipients to list or the balance to export",
+			"yes":       "Don't ask for confirmation",
+			"format":    "Export file format: csv, ofx, qif or pdf",
+			"payin":     "Only payment options paid in this way, e.g. BALANCE, BANK_TRANSFER, DEBIT",
+			"interval":  "How often to poll rates when watching (default 1m)",
+			"threshold": "Highlight moves of at least this many percent (default 0.5)",
+			"start":     "First day of the export, YYYY-MM-DD (default: -days before -end)",
+			"end":       "Last day of the export, YYYY-MM-DD (default: today)",
+			"out":       "File to write to (default: stdout)",
 		}
 		for _, f := range help.flags {
 			fmt.Printf("  -%-10s  %s\n", f, flagDescs[f])
@@ -108,14 +161,27 @@ func main() {
 	amount := flag.Float64("amount", 100, "Amount for quote")
 	days := flag.Int("days", 7, "Days of history")
 	group := flag.String("group", "day", "History grouping: day, hour, minute")
+	pairs := flag.String("pairs", "", "Currency pairs for rates, e.g. EUR/USD,GBP/INR")
+	base := flag.String("base", "", "Base currency for rates")
+	currency := flag.String("currency", "", "Currency of recipients to list or balance to export")
+	yes := flag.Bool("yes", false, "Don't ask for confirmation")
+	format := flag.String("format", "csv", "Statement export format: csv, ofx, qif, pdf")
+	start := flag.String("start", "", "Statement export start date, YYYY-MM-DD")
+	end := flag.String("end", "", "Statement export end date, YYYY-MM-DD")
+	outFile := flag.String("out", "", "File to write the statement export to")
+	interval := flag.Duration("interval", commands.DefaultWatchInterval, "Rate watch polling interval")
+	payIn := flag.String("payin", "", "Quote pay-in method filter, e.g. BALANCE")
+	threshold := flag.Float64("threshold", 0.5, "Rate watch highlight threshold in percent")
+	account := flag.String("account", "", "Account from the config file")
 	sandbox := flag.Bool("sandbox", false, "Use sandbox environment")
+	outputFlag := flag.String("output", "text", "Output format: text, json, csv or table")
 
 	flag.Usage = printUsage
 	flag.Parse()
+	args := parseArgs()
 
 	// Handle help command
 	if *cmd == "help" {
-		args := flag.Args()
 		if len(args) > 0 {
 			printCmdHelp(args[0])
 		} else {
@@ -124,34 +190,88 @@ func main() {
 		return
 	}
 
-	token := os.Getenv("WISE_API_TOKEN")
-	if token == "" {
-		fmt.Println("Error: WISE_API_TOKEN environment variable required")
+	out, err := output.ParseFormat(*outputFlag)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	acct, err := commands.LoadAccount(*account)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !acct.HasCredentials() {
+		fmt.Println("Error: WISE_API_TOKEN or WISE_CLIENT_ID/WISE_CLIENT_SECRET environment variables, or an account in the config file, required")
 		fmt.Println()
 		printUsage()
 		os.Exit(1)
 	}
 
-	var opts []wise.ClientOption
-	if *sandbox {
+	opts, err := commands.DefaultClientOptions("wise-cli", 0)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if dir := os.Getenv("WISE_DEBUG_DUMP"); dir != "" {
+		opts = append(opts, wise.WithDebugDumpDir(dir))
+	}
+	if *sandbox || acct.Sandbox {
 		opts = append(opts, wise.WithSandbox())
 	}
-	client := wise.NewClient(token, opts...)
 	ctx := context.Background()
+	var client *wise.Client
+	if acct.Token != "" {
+		client = wise.NewClient(acct.Token, opts...)
+	} else {
+		mgr := tokenManager(ctx, acct, *sandbox || acct.Sandbox)
+		if *cmd == "logout" {
+			logout(ctx, mgr)
+			return
+		}
+		client = oauthClient(ctx, mgr, opts)
+	}
 
 	switch *cmd {
 	case "rates":
-		printRates(ctx, client)
+		if len(args) > 0 && args[0] == "watch" {
+			runRatesWatch(ctx, client, out, ratePairs(acct, *pairs, *base), *interval, *threshold)
+			break
+		}
+		printRates(ctx, client, out, acct, *pairs, *base)
 	case "profiles":
-		printProfiles(ctx, client)
+		printProfiles(ctx, client, out)
+	case "whoami":
+		printCurrentUser(ctx, client, out)
+	case "currencies":
+		printCurrencies(ctx, client, out)
 	case "balances":
-		printBalances(ctx, client)
+		printBalances(ctx, client, out)
 	case "statements":
-		printStatements(ctx, client, *days)
+		if len(args) > 0 && args[0] == "export" {
+			runStatementsExport(ctx, client, exportFlags{
+				format: *format, currency: *currency, out: *outFile,
+				start: *start, end: *end, days: *days,
+			})
+			break
+		}
+		printStatements(ctx, client, out, *days)
 	case "quote":
-		printQuote(ctx, client, *from, *to, *amount)
+		printQuote(ctx, client, out, *from, *to, *amount, *payIn)
 	case "rate-history":
-		printHistory(ctx, client, *from, *to, *days, *group)
+		printHistory(ctx, client, out, *from, *to, *days, *group)
+	case "convert":
+		if !isFlagSet("amount") {
+			fmt.Println("Error: convert requires -amount")
+			os.Exit(1)
+		}
+		runConvert(ctx, client, out, *from, *to, *amount, *yes)
+	case "transfers":
+		runTransfers(ctx, client, out, args, *yes)
+	case "recipients":
+		runRecipients(ctx, client, out, args, *currency)
+	case "logout":
+		fmt.Println("Nothing to log out: using an API token")
 	default:
 		fmt.Printf("Unknown command: %s\n", *cmd)
 		fmt.Println()
@@ -160,23 +280,89 @@ func main() {
 	}
 }
 
-func printRates(ctx context.Context, client *wise.Client) {
-	results := commands.GetRates(ctx, client)
+// tokenManager returns a token manager for the OAuth token saved by a
+// previous login.
+func tokenManager(ctx context.Context, acct commands.Account, sandbox bool) *wise.TokenManager {
+	store, err := acct.OpenTokenStore()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	oauth := wise.NewOAuthClient(wise.OAuthConfig{
+		ClientID:     acct.ClientID,
+		ClientSecret: acct.ClientSecret,
+		Sandbox:      sandbox,
+	})
+	mgr, err := wise.NewTokenManagerFromStore(ctx, oauth, store)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	return mgr
+}
+
+// oauthClient builds a client from the saved OAuth token, refreshing and
+// re-saving it when it has expired.
+func oauthClient(ctx context.Context, mgr *wise.TokenManager, opts []wise.ClientOption) *wise.Client {
+	if _, err := mgr.GetToken(ctx); err != nil {
+		fmt.Printf("Error: %v (log in with wise-server first)\n", err)
+		os.Exit(1)
+	}
+	return wise.NewClientWithTokenSource(mgr, opts...)
+}
+
+// logout revokes the saved OAuth token with Wise and deletes it.
+func logout(ctx context.Context, mgr *wise.TokenManager) {
+	if err := mgr.Revoke(ctx); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Logged out")
+}
+
+// ratePairs returns the pairs given by -pairs, or else those of -base or
+// the account. Nil means commands.DefaultPairs.
+func ratePairs(acct commands.Account, pairList, base string) []commands.CurrencyPair {
+	pairs, err := commands.ParseCurrencyPairs(pairList)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(pairs) == 0 {
+		pairs = acct.RatePairs(base)
+	}
+	return pairs
+}
+
+func printRates(ctx context.Context, client *wise.Client, out output.Format, acct commands.Account, pairList, base string) {
+	results, err := commands.GetRates(ctx, client, ratePairs(acct, pairList, base))
+	if out != output.Text {
+		emit(out, results, nil)
+		failIf(out, err)
+		return
+	}
 	fmt.Println("Exchange Rates:")
 	fmt.Println("---------------")
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != "" {
 			fmt.Printf("%s/%s: error - %v\n", r.From, r.To, r.Error)
 		} else {
 			fmt.Printf("%s/%s: %.6f\n", r.From, r.To, r.Rate)
 		}
 	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
 }
 
-func printProfiles(ctx context.Context, client *wise.Client) {
+func printProfiles(ctx context.Context, client *wise.Client, out output.Format) {
 	profiles, err := commands.GetProfiles(ctx, client)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
+		fail(out, err)
+		return
+	}
+	if out != output.Text {
+		emit(out, profiles, nil)
 		return
 	}
 
@@ -191,8 +377,44 @@ func printProfiles(ctx context.Context, client *wise.Client) {
 	}
 }
 
-func printBalances(ctx context.Context, client *wise.Client) {
+func printCurrentUser(ctx context.Context, client *wise.Client, out output.Format) {
+	user, err := commands.GetCurrentUser(ctx, client)
+	if err != nil {
+		fail(out, err)
+		return
+	}
+	if out != output.Text {
+		emit(out, user, nil)
+		return
+	}
+	fmt.Printf("User %d: %s <%s>\n", user.ID, user.Name, user.Email)
+}
+
+func printCurrencies(ctx context.Context, client *wise.Client, out output.Format) {
+	currencies, err := commands.GetCurrencies(ctx, client)
+	if err != nil {
+		fail(out, err)
+		return
+	}
+	if out != output.Text {
+		emit(out, currencies, nil)
+		return
+	}
+
+	fmt.Println("Currencies:")
+	fmt.Println("-----------")
+	for _, c := range currencies {
+		fmt.Printf("%s  %-4s %s (%d decimals)\n", c.Code, c.Symbol, c.Name, c.Decimals)
+	}
+}
+
+func printBalances(ctx context.Context, client *wise.Client, out output.Format) {
 	results, err := commands.GetBalances(ctx, client)
+	if out != output.Text {
+		emit(out, results, balanceRows(results))
+		failIf(out, err)
+		return
+	}
 	if err != nil {
 		fmt.Printf("Error getting profiles: %v\n", err)
 		return
@@ -206,7 +428,7 @@ func printBalances(ctx context.Context, client *wise.Client) {
 	fmt.Println("Balances:")
 	fmt.Println("---------")
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != "" {
 			fmt.Printf("Profile %d: error - %v\n", r.ProfileID, r.Error)
 			continue
 		}
@@ -217,11 +439,16 @@ func printBalances(ctx context.Context, client *wise.Client) {
 	}
 }
 
-func printStatements(ctx context.Context, client *wise.Client, days int) {
+func printStatements(ctx context.Context, client *wise.Client, out output.Format, days int) {
 	if days <= 0 {
 		days = 30
 	}
 	results, err := commands.GetStatements(ctx, client, days)
+	if out != output.Text {
+		emit(out, results, statementRows(results))
+		failIf(out, err)
+		return
+	}
 	if err != nil {
 		fmt.Printf("Error getting profiles: %v\n", err)
 		return
@@ -231,7 +458,7 @@ func printStatements(ctx context.Context, client *wise.Client, days int) {
 	fmt.Println("--------------------------")
 
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != "" {
 			fmt.Printf("%s: error - %v\n", r.Currency, r.Error)
 			continue
 		}
@@ -246,9 +473,16 @@ func printStatements(ctx context.Context, client *wise.Client, days int) {
 	}
 }
 
-func printQuote(ctx context.Context, client *wise.Client, from, to string, amount float64) {
-	result := commands.GetQuote(ctx, client, from, to, amount)
-	if result.Error != nil {
+func printQuote(ctx context.Context, client *wise.Client, out output.Format, from, to string, amount float64, payIn string) {
+	result := commands.GetQuoteForPayIn(ctx, client, from, to, amount, payIn)
+	if out != output.Text {
+		emit(out, result, result.Options)
+		if result.Error != "" {
+			fail(out, errors.New(result.Error))
+		}
+		return
+	}
+	if result.Error != "" {
 		fmt.Printf("Error: %v\n", result.Error)
 		return
 	}
@@ -257,13 +491,46 @@ func printQuote(ctx context.Context, client *wise.Client, from, to string, amoun
 	fmt.Println("------")
 	fmt.Printf("  %s %.2f → %s %.2f\n", result.From, result.SourceAmount, result.To, result.TargetAmount)
 	fmt.Printf("  Rate: %.6f\n", result.Rate)
+	if result.PayIn != "" {
+		fmt.Printf("  Fee: %.2f %s (%.2f%%, %s → %s)\n", result.Fee.Value, result.Fee.Currency, result.FeePercentage, result.PayIn, result.PayOut)
+	}
+	if result.EstimatedDelivery != "" {
+		fmt.Printf("  Estimated delivery: %s\n", result.EstimatedDelivery)
+	}
 	fmt.Printf("  Quote ID: %s\n", result.QuoteID)
 	fmt.Printf("  Expires: %s\n", result.Expires)
+
+	if len(result.Options) > 0 {
+		fmt.Println()
+		fmt.Println("Payment options (cheapest first):")
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  PAY IN\tPAY OUT\tFEE\tYOU PAY\tTHEY GET\tEFFECTIVE RATE\tDELIVERY")
+		for _, o := range result.Options {
+			delivery := o.DeliveryText
+			if delivery == "" {
+				delivery = o.Delivery
+			}
+			if o.Fastest {
+				delivery += " (fastest)"
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%.2f %s (%.2f%%)\t%.2f %s\t%.2f %s\t%.6f\t%s\n",
+				o.PayIn, o.PayOut, o.Fee, result.From, o.FeePercent,
+				o.SourceAmount, result.From, o.TargetAmount, result.To, o.EffectiveRate, delivery)
+		}
+		tw.Flush()
+	}
 }
 
-func printHistory(ctx context.Context, client *wise.Client, from, to string, days int, group string) {
+func printHistory(ctx context.Context, client *wise.Client, out output.Format, from, to string, days int, group string) {
 	result := commands.GetRateHistory(ctx, client, from, to, days, group)
-	if result.Error != nil {
+	if out != output.Text {
+		emit(out, result, result.DataPoints)
+		if result.Error != "" {
+			fail(out, errors.New(result.Error))
+		}
+		return
+	}
+	if result.Error != "" {
 		fmt.Printf("Error: %v\n", result.Error)
 		return
 	}
@@ -273,8 +540,9 @@ func printHistory(ctx context.Context, client *wise.Client, from, to string, day
 	fmt.Printf("  Data points: %d\n", len(result.DataPoints))
 	fmt.Printf("  First: %.6f\n", result.First)
 	fmt.Printf("  Last:  %.6f\n", result.Last)
-	fmt.Printf("  Min:   %.6f\n", result.Min)
-	fmt.Printf("  Max:   %.6f\n", result.Max)
+	fmt.Printf("  Min:   %.6f (%s)\n", result.Min, result.MinTime)
+	fmt.Printf("  Max:   %.6f (%s)\n", result.Max, result.MaxTime)
+	fmt.Printf("  Change: %+.2f%%, std dev %.6f, trend %s\n", result.Change, result.StdDev, result.Trend)
 
 	if len(result.DataPoints) > 0 {
 		fmt.Println("\nRecent rates:")
@@ -288,3 +556,25 @@ func printHistory(ctx context.Context, client *wise.Client, from, to string, day
 		}
 	}
 }
+
+// parseArgs returns the positional arguments, parsing flags that follow
+// them, so subcommands take flags too: "recipients list -currency GBP".
+func parseArgs() []string {
+	var args []string
+	for rest := flag.Args(); len(rest) > 0; rest = flag.Args() {
+		args = append(args, rest[0])
+		flag.CommandLine.Parse(rest[1:])
+	}
+	return args
+}
+
+// isFlagSet reports whether the named flag was given on the command line.
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
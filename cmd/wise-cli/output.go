@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/joeblew999/plat-wise/commands"
+	"github.com/joeblew999/plat-wise/output"
+)
+
+// emit writes v to stdout in a structured format. For CSV and table output,
+// rows are written instead of v if given, as nested lists don't fit in a
+// row; JSON always gets v.
+func emit(out output.Format, v, rows any) {
+	if rows != nil && out != output.JSON {
+		v = rows
+	}
+	if err := output.Write(os.Stdout, out, v); err != nil {
+		fail(out, err)
+	}
+}
+
+// fail reports err. With structured output it goes to stderr, keeping
+// stdout parseable, and the CLI exits with status 1.
+func fail(out output.Format, err error) {
+	if out == output.Text {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
+
+// failIf calls fail if err is not nil.
+func failIf(out output.Format, err error) {
+	if err != nil {
+		fail(out, err)
+	}
+}
+
+// balanceRow is one balance of BalanceResult, for CSV and table output.
+type balanceRow struct {
+	ProfileID   int64   `csv:"profile_id"`
+	ProfileType string  `csv:"profile_type"`
+	Currency    string  `csv:"currency"`
+	Amount      float64 `csv:"amount"`
+	Error       string  `csv:"error"`
+}
+
+func balanceRows(results []commands.BalanceResult) []balanceRow {
+	rows := []balanceRow{}
+	for _, r := range results {
+		if r.Error != "" {
+			rows = append(rows, balanceRow{ProfileID: r.ProfileID, ProfileType: r.ProfileType, Error: r.Error})
+			continue
+		}
+		for _, b := range r.Balances {
+			rows = append(rows, balanceRow{ProfileID: r.ProfileID, ProfileType: r.ProfileType, Currency: b.Currency, Amount: b.Amount})
+		}
+	}
+	return rows
+}
+
+// statementRow is one transaction of StatementResult, for CSV and table
+// output.
+type statementRow struct {
+	BalanceID int64 `csv:"balance_id"`
+	commands.Transaction
+	Error string `csv:"error"`
+}
+
+func statementRows(results []commands.StatementResult) []statementRow {
+	rows := []statementRow{}
+	for _, r := range results {
+		if r.Error != "" {
+			rows = append(rows, statementRow{BalanceID: r.BalanceID, Transaction: commands.Transaction{Currency: r.Currency}, Error: r.Error})
+			continue
+		}
+		for _, t := range r.Transactions {
+			rows = append(rows, statementRow{BalanceID: r.BalanceID, Transaction: t})
+		}
+	}
+	return rows
+}
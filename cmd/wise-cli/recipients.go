@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/commands"
+	"github.com/joeblew999/plat-wise/output"
+	"gopkg.in/yaml.v3"
+)
+
+// runRecipients runs a recipients subcommand: list, show <id>,
+// create <file> or delete <id>.
+func runRecipients(ctx context.Context, client *wise.Client, out output.Format, args []string, currency string) {
+	sub := "list"
+	if len(args) > 0 {
+		sub = args[0]
+	}
+	// Flags after the subcommand are parsed by parseArgs; anything else
+	// left over is a mistake, such as "list GBP" for "list -currency GBP".
+	if want := map[string]int{"list": 1, "show": 2, "create": 2, "delete": 2}[sub]; want > 0 && len(args) > want {
+		fmt.Printf("Error: unexpected arguments: %s\n", strings.Join(args[want:], " "))
+		fmt.Println()
+		printCmdHelp("recipients")
+		os.Exit(1)
+	}
+	switch sub {
+	case "list":
+		recipients, err := commands.GetRecipients(ctx, client, 0, strings.ToUpper(currency))
+		if err != nil {
+			fail(out, err)
+			return
+		}
+		if out != output.Text {
+			emit(out, recipients, nil)
+			return
+		}
+		fmt.Println("Recipients:")
+		fmt.Println("-----------")
+		for _, r := range recipients {
+			printRecipientLine(r)
+		}
+		if len(recipients) == 0 {
+			fmt.Println("No recipients")
+		}
+	case "show":
+		id := recipientID(args)
+		r, err := commands.GetRecipient(ctx, client, id)
+		if err != nil {
+			fail(out, err)
+			return
+		}
+		if out != output.Text {
+			emit(out, r, nil)
+			return
+		}
+		printRecipient(r)
+	case "create":
+		if len(args) < 2 {
+			fmt.Println("Usage: wise-cli -cmd recipients create <details.json|details.yaml>")
+			os.Exit(1)
+		}
+		req, err := readRecipientRequest(args[1])
+		if err != nil {
+			fail(out, err)
+			return
+		}
+		r, err := commands.CreateRecipient(ctx, client, req)
+		if err != nil {
+			fail(out, err)
+			return
+		}
+		if out != output.Text {
+			emit(out, r, nil)
+			return
+		}
+		fmt.Println("Created recipient:")
+		printRecipient(r)
+	case "delete":
+		id := recipientID(args)
+		if err := commands.DeleteRecipient(ctx, client, id); err != nil {
+			fail(out, err)
+			return
+		}
+		if out != output.Text {
+			emit(out, deletedRecipient{ID: id, Deleted: true}, nil)
+			return
+		}
+		fmt.Printf("Deleted recipient %d\n", id)
+	default:
+		fmt.Printf("Unknown recipients command: %s\n", sub)
+		fmt.Println()
+		printCmdHelp("recipients")
+		os.Exit(1)
+	}
+}
+
+// deletedRecipient is the structured output of recipients delete.
+type deletedRecipient struct {
+	ID      int64 `json:"id" csv:"id"`
+	Deleted bool  `json:"deleted" csv:"deleted"`
+}
+
+// recipientID parses the recipient ID argument of show and delete.
+func recipientID(args []string) int64 {
+	if len(args) < 2 {
+		fmt.Printf("Usage: wise-cli -cmd recipients %s <id>\n", args[0])
+		os.Exit(1)
+	}
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		fmt.Printf("Error: invalid recipient ID %q\n", args[1])
+		os.Exit(1)
+	}
+	return id
+}
+
+// readRecipientRequest reads a recipient to create from a JSON or, by
+// extension, YAML file with the fields of wise.CreateRecipientRequest:
+//
+//	accountHolderName: Jane Doe
+//	currency: GBP
+//	type: sort_code
+//	details:
+//	  sortCode: "231470"
+//	  accountNumber: "28821822"
+func readRecipientRequest(path string) (wise.CreateRecipientRequest, error) {
+	var req wise.CreateRecipientRequest
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return req, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		// Go through JSON so the json tags of the request apply.
+		var v map[string]interface{}
+		if err := yaml.Unmarshal(data, &v); err != nil {
+			return req, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return req, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return req, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	req.Currency = wise.Currency(strings.ToUpper(string(req.Currency)))
+	return req, nil
+}
+
+func printRecipientLine(r commands.RecipientResult) {
+	name := r.Name
+	if r.Nickname != "" {
+		name += " (" + r.Nickname + ")"
+	}
+	fmt.Printf("%-10d %-30s %s  %s\n", r.ID, name, r.Currency, r.Account)
+}
+
+func printRecipient(r commands.RecipientResult) {
+	fmt.Printf("ID:       %d\n", r.ID)
+	fmt.Printf("Name:     %s\n", r.Name)
+	if r.Nickname != "" {
+		fmt.Printf("Nickname: %s\n", r.Nickname)
+	}
+	fmt.Printf("Currency: %s\n", r.Currency)
+	fmt.Printf("Type:     %s\n", r.Type)
+	if r.Country != "" {
+		fmt.Printf("Country:  %s\n", r.Country)
+	}
+	fmt.Printf("Account:  %s\n", r.Account)
+	fmt.Printf("Active:   %t\n", r.Active)
+	if len(r.Details) > 0 {
+		details, _ := json.MarshalIndent(r.Details, "  ", "  ")
+		fmt.Printf("Details:\n  %s\n", details)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/commands"
+)
+
+// exportFlags are the flags of statements export.
+type exportFlags struct {
+	format, currency, out string
+	start, end            string // YYYY-MM-DD
+	days                  int
+}
+
+// runStatementsExport writes a statement file to f.out, or stdout. The
+// period is f.start to f.end, defaulting to the f.days up to today.
+func runStatementsExport(ctx context.Context, client *wise.Client, f exportFlags) {
+	end := time.Now()
+	if f.end != "" {
+		// The whole end day is included.
+		end = parseDate("end", f.end).AddDate(0, 0, 1).Add(-time.Second)
+	}
+	start := end.AddDate(0, 0, -max(f.days, 1))
+	if f.start != "" {
+		start = parseDate("start", f.start)
+	}
+	if !start.Before(end) {
+		fmt.Println("Error: start must be before end")
+		os.Exit(1)
+	}
+
+	opts := commands.ExportOptions{
+		Format:   strings.ToLower(f.format),
+		Currency: strings.ToUpper(f.currency),
+		Start:    start,
+		End:      end,
+	}
+	var w io.Writer = os.Stdout
+	var file *os.File
+	if f.out != "" {
+		var err error
+		if file, err = os.Create(f.out); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		w = file
+	}
+
+	err := commands.ExportStatements(ctx, client, w, opts)
+	if file != nil {
+		if cerr := file.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(f.out) // Don't leave a partial file behind
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if file != nil {
+		fmt.Printf("Exported %s statement from %s to %s to %s\n",
+			opts.Format, start.Format("2006-01-02"), end.Format("2006-01-02"), f.out)
+	}
+}
+
+func parseDate(name, value string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		fmt.Printf("Error: invalid -%s date %q, want YYYY-MM-DD\n", name, value)
+		os.Exit(1)
+	}
+	return t
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/commands"
+	"github.com/joeblew999/plat-wise/output"
+)
+
+// runTransfers runs a transfers subcommand: show <id> or cancel <id>.
+func runTransfers(ctx context.Context, client *wise.Client, out output.Format, args []string, yes bool) {
+	if len(args) < 2 || (args[0] != "show" && args[0] != "cancel") {
+		printCmdHelp("transfers")
+		os.Exit(1)
+	}
+	id, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		fmt.Printf("Error: invalid transfer ID %q\n", args[1])
+		os.Exit(1)
+	}
+
+	status, err := commands.GetTransfer(ctx, client, id)
+	if err != nil {
+		fail(out, err)
+		return
+	}
+	if args[0] == "show" {
+		if out != output.Text {
+			emit(out, status, nil)
+			return
+		}
+		printTransferStatus(status)
+		return
+	}
+
+	// Check before asking, so a transfer already on its way is reported
+	// straight away.
+	if !status.Cancellable {
+		fail(out, fmt.Errorf("transfer %d is %s and can no longer be cancelled", id, status.Status))
+		return
+	}
+	if !yes {
+		fmt.Fprintf(os.Stderr, "Cancel transfer %d of %.2f %s to %s? [y/N] ",
+			id, status.SourceAmount, status.SourceCurrency, status.RecipientName)
+		if !confirm() {
+			fmt.Fprintln(os.Stderr, "Not cancelled")
+			return
+		}
+	}
+	result, err := commands.CancelTransfer(ctx, client, id)
+	if err != nil {
+		if errors.Is(err, wise.ErrTransferAlreadyPaidOut) {
+			err = fmt.Errorf("transfer %d was paid out in the meantime", id)
+		}
+		fail(out, err)
+		return
+	}
+	if out != output.Text {
+		emit(out, result, nil)
+		return
+	}
+	fmt.Printf("Cancelled transfer %d (status %s)\n", result.ID, result.Status)
+}
+
+func printTransferStatus(s commands.TransferStatusResult) {
+	fmt.Printf("Transfer %d\n", s.ID)
+	fmt.Println("--------------")
+	fmt.Printf("Status:      %s\n", s.Status)
+	fmt.Printf("Created:     %s\n", s.Created)
+	fmt.Printf("Amount:      %.2f %s -> %.2f %s (rate %.6f)\n",
+		s.SourceAmount, s.SourceCurrency, s.TargetAmount, s.TargetCurrency, s.Rate)
+	recipient := s.RecipientName
+	if recipient == "" {
+		recipient = "unknown"
+	}
+	fmt.Printf("Recipient:   %s (%d)\n", recipient, s.RecipientID)
+	if s.Reference != "" {
+		fmt.Printf("Reference:   %s\n", s.Reference)
+	}
+	if s.EstimatedDelivery != "" {
+		fmt.Printf("Delivery:    %s (estimated)\n", s.EstimatedDelivery)
+	}
+	fmt.Printf("Cancellable: %t\n", s.Cancellable)
+
+	if len(s.Issues) > 0 || s.HasActiveIssues {
+		fmt.Println()
+		fmt.Println("Issues:")
+		for _, issue := range s.Issues {
+			fmt.Printf("  %s (%s) %s\n", issue.Type, issue.Status, issue.Message)
+		}
+		if len(s.Issues) == 0 {
+			fmt.Println("  Wise reports active issues; details are not available")
+		}
+	}
+
+	if t := s.Tracking; t != nil {
+		fmt.Println()
+		fmt.Printf("Tracking:    %s", t.Status)
+		if t.PayoutMethod != "" {
+			fmt.Printf(" via %s", t.PayoutMethod)
+		}
+		fmt.Println()
+		if t.UETR != "" {
+			fmt.Printf("  UETR:           %s\n", t.UETR)
+		}
+		if t.BankReference != "" {
+			fmt.Printf("  Bank reference: %s\n", t.BankReference)
+		}
+		for _, e := range t.Events {
+			fmt.Printf("  %s  %s %s\n", e.OccurredAt.Format("2006-01-02 15:04"), e.Status, e.Description)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/commands"
+	"github.com/joeblew999/plat-wise/output"
+)
+
+// ANSI escapes for highlighting rate moves on a terminal.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// runRatesWatch prints rate updates until interrupted. Moves of at least
+// threshold percent since the last update are highlighted.
+func runRatesWatch(ctx context.Context, client *wise.Client, out output.Format, pairs []commands.CurrencyPair, interval time.Duration, threshold float64) {
+	if len(pairs) == 0 {
+		pairs = commands.DefaultPairs()
+	}
+	if interval <= 0 {
+		interval = commands.DefaultWatchInterval
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := commands.WatchOptions{Pairs: pairs, Interval: interval}
+	var report func(commands.RateUpdate)
+	if out != output.Text {
+		stream := output.NewStream(os.Stdout, out)
+		report = func(u commands.RateUpdate) {
+			if err := stream.Write(u); err != nil {
+				fail(out, err)
+			}
+		}
+	} else {
+		color := isTerminal(os.Stdout)
+		fmt.Printf("Watching %d pairs every %s (Ctrl-C to stop)\n", len(pairs), opts.Interval)
+		report = func(u commands.RateUpdate) {
+			printRateUpdate(u, threshold, color)
+		}
+	}
+
+	err := commands.WatchRates(ctx, client, opts, report)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fail(out, err)
+	}
+}
+
+func printRateUpdate(u commands.RateUpdate, threshold float64, color bool) {
+	clock := u.Polled
+	if t, err := time.Parse(time.RFC3339, u.Polled); err == nil {
+		clock = t.Local().Format("15:04:05")
+	}
+	if u.Error != "" {
+		fmt.Printf("%s  %s/%s: error - %s\n", clock, u.From, u.To, u.Error)
+		return
+	}
+	if u.Previous == 0 {
+		fmt.Printf("%s  %s/%s: %.6f\n", clock, u.From, u.To, u.Rate)
+		return
+	}
+
+	line := fmt.Sprintf("%s/%s: %.6f  %+.4f%%", u.From, u.To, u.Rate, u.Change*100)
+	if math.Abs(u.Change*100) >= threshold {
+		switch {
+		case color && u.Change > 0:
+			line = ansiBold + ansiGreen + line + ansiReset
+		case color:
+			line = ansiBold + ansiRed + line + ansiReset
+		default:
+			line += "  !"
+		}
+	}
+	fmt.Printf("%s  %s\n", clock, line)
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
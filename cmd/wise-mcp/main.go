@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	wise "github.com/joeblew999/plat-wise"
 	"github.com/joeblew999/plat-wise/commands"
@@ -24,7 +25,11 @@ func main() {
 		os.Exit(1)
 	}
 
-	var opts []wise.ClientOption
+	opts, err := commands.DefaultClientOptions("wise-mcp", 30*time.Second)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	if os.Getenv("WISE_SANDBOX") == "true" {
 		opts = append(opts, wise.WithSandbox())
 	}
@@ -113,6 +118,7 @@ func registerTools(s *server.MCPServer) {
 			mcp.WithString("to", mcp.Description("Target currency code (e.g., USD, EUR)"), mcp.Required()),
 			mcp.WithNumber("days", mcp.Description("Number of days of history (default 7)")),
 			mcp.WithString("group", mcp.Description("Grouping interval: day, hour, minute (default day)")),
+			mcp.WithNumber("window", mcp.Description("Optional. Number of points to compute moving averages (SMA, EMA) over")),
 		),
 		handleHistory,
 	)
@@ -124,7 +130,7 @@ func handleRates(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolRes
 	to := getStringArg(args, "to")
 
 	result := commands.GetRate(ctx, client, from, to)
-	if result.Error != nil {
+	if result.Error != "" {
 		return mcp.NewToolResultError(fmt.Sprintf("Error: %v", result.Error)), nil
 	}
 	return mcp.NewToolResultText(fmt.Sprintf("%s/%s: %.6f", result.From, result.To, result.Rate)), nil
@@ -159,7 +165,7 @@ func handleBalances(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallTool
 
 	var lines []string
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != "" {
 			lines = append(lines, fmt.Sprintf("Profile %d: error - %v", r.ProfileID, r.Error))
 			continue
 		}
@@ -184,7 +190,7 @@ func handleStatements(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallTo
 	lines = append(lines, fmt.Sprintf("Statements (last %d days):", days))
 
 	for _, r := range results {
-		if r.Error != nil {
+		if r.Error != "" {
 			lines = append(lines, fmt.Sprintf("%s: error - %v", r.Currency, r.Error))
 			continue
 		}
@@ -211,21 +217,11 @@ func handleQuote(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolRes
 	}
 
 	result := commands.GetQuote(ctx, client, from, to, amount)
-	if result.Error != nil {
+	if result.Error != "" {
 		return mcp.NewToolResultError(fmt.Sprintf("Error: %v", result.Error)), nil
 	}
 
-	output := map[string]interface{}{
-		"from":         result.From,
-		"to":           result.To,
-		"sourceAmount": result.SourceAmount,
-		"targetAmount": result.TargetAmount,
-		"rate":         result.Rate,
-		"quoteId":      result.QuoteID,
-		"expires":      result.Expires,
-	}
-
-	jsonBytes, _ := json.MarshalIndent(output, "", "  ")
+	jsonBytes, _ := json.MarshalIndent(result, "", "  ")
 	return mcp.NewToolResultText(string(jsonBytes)), nil
 }
 
@@ -240,9 +236,10 @@ func handleHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolR
 	}
 
 	result := commands.GetRateHistory(ctx, client, from, to, days, group)
-	if result.Error != nil {
+	if result.Error != "" {
 		return mcp.NewToolResultError(fmt.Sprintf("Error: %v", result.Error)), nil
 	}
+	result.MovingAverages(int(getFloatArg(args, "window", 0)))
 
 	output := map[string]interface{}{
 		"from":       result.From,
@@ -251,7 +248,12 @@ func handleHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolR
 		"first":      result.First,
 		"last":       result.Last,
 		"min":        result.Min,
+		"minTime":    result.MinTime,
 		"max":        result.Max,
+		"maxTime":    result.MaxTime,
+		"change":     result.Change,
+		"stdDev":     result.StdDev,
+		"trend":      result.Trend,
 		"history":    result.DataPoints,
 	}
 
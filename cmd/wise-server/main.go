@@ -6,9 +6,12 @@ import (
 	"encoding/hex"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	wise "github.com/joeblew999/plat-wise"
 	"github.com/joeblew999/plat-wise/commands"
@@ -22,8 +25,10 @@ var (
 	client      *wise.Client
 	oauthClient *wise.OAuthClient
 	tokenMgr    *wise.TokenManager
+	tokenClient *wise.Client // API client authenticated by tokenMgr
 	mu          sync.RWMutex
-	authMode    string // "token" or "oauth"
+	authMode    string   // "token" or "oauth"
+	oauthStates sync.Map // OAuth states issued to login pages
 )
 
 func main() {
@@ -31,6 +36,17 @@ func main() {
 	sandbox := flag.Bool("sandbox", false, "Use sandbox environment")
 	flag.Parse()
 
+	opts, err := commands.DefaultClientOptions("wise-server", 30*time.Second)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	// Share identical requests made concurrently by dashboard sessions.
+	opts = append(opts, wise.WithRequestCoalescing())
+	if *sandbox {
+		opts = append(opts, wise.WithSandbox())
+	}
+
 	// Check for OAuth credentials first
 	clientID := os.Getenv("WISE_CLIENT_ID")
 	clientSecret := os.Getenv("WISE_CLIENT_SECRET")
@@ -41,13 +57,40 @@ func main() {
 		if redirectURL == "" {
 			redirectURL = fmt.Sprintf("http://localhost:%s/oauth/callback", *port)
 		}
-		oauthClient = wise.NewOAuthClient(wise.OAuthConfig{
+		config := wise.OAuthConfig{
 			ClientID:     clientID,
 			ClientSecret: clientSecret,
 			RedirectURL:  redirectURL,
 			Sandbox:      *sandbox,
-		})
+			Scopes:       strings.Fields(os.Getenv("WISE_OAUTH_SCOPES")),
+		}
+		if err := config.Validate(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		oauthClient = wise.NewOAuthClient(config)
 		fmt.Println("OAuth mode enabled")
+
+		// Restore the token saved by a previous login, if any.
+		store, err := commands.TokenStoreFromEnv()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		tokenMgr, err = wise.NewTokenManagerFromStore(context.Background(), oauthClient, store)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		tokenMgr.SetRefreshErrorCallback(func(err error) {
+			fmt.Printf("Warning: token refresh failed: %v\n", err)
+		})
+		tokenMgr.StartAutoRefresh(context.Background())
+		tokenClient = wise.NewClientWithTokenSource(tokenMgr, opts...)
+		if _, err := tokenMgr.GetToken(context.Background()); err == nil {
+			setClient(tokenClient)
+			fmt.Println("Restored saved OAuth token")
+		}
 	} else {
 		// Fall back to API token
 		authMode = "token"
@@ -56,11 +99,6 @@ func main() {
 			fmt.Println("Error: WISE_API_TOKEN or WISE_CLIENT_ID/WISE_CLIENT_SECRET required")
 			os.Exit(1)
 		}
-
-		var opts []wise.ClientOption
-		if *sandbox {
-			opts = append(opts, wise.WithSandbox())
-		}
 		client = wise.NewClient(token, opts...)
 		fmt.Println("API token mode enabled")
 	}
@@ -68,6 +106,16 @@ func main() {
 	startServer(*port, *sandbox)
 }
 
+// defaultCurrencies is used for dropdowns until the currency list is loaded.
+var defaultCurrencies = func() []string {
+	known := commands.KnownCurrencies()
+	codes := make([]string, 0, len(known))
+	for _, cur := range known {
+		codes = append(codes, cur.Code)
+	}
+	return codes
+}()
+
 type AppData struct {
 	Rates       []commands.RateResult
 	Balances    []commands.BalanceResult
@@ -75,6 +123,8 @@ type AppData struct {
 	Statements  []commands.StatementResult
 	RateHistory *commands.HistoryResult
 	Quote       *commands.QuoteResult
+	Currencies  []string
+	User        *commands.UserResult
 	LoggedIn    bool
 	AuthURL     string
 	OAuthState  string
@@ -134,17 +184,55 @@ func startServer(port string, sandbox bool) {
 				)
 			})
 		})
+
+		// Exchanges the authorization code and saves the token for restarts
+		v.HandleFunc("/oauth/complete", func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := oauthStates.LoadAndDelete(r.URL.Query().Get("state")); !ok {
+				http.Error(w, "invalid OAuth state", http.StatusBadRequest)
+				return
+			}
+			token, err := oauthClient.ExchangeCode(r.Context(), r.URL.Query().Get("code"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			if err := tokenMgr.SetToken(r.Context(), token); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+			if _, err := tokenMgr.GetToken(r.Context()); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			// Don't serve responses cached for a previous login.
+			tokenClient.ClearCache()
+			setClient(tokenClient)
+			w.WriteHeader(http.StatusNoContent)
+		})
 	}
 
 	v.Page("/", func(c *via.Context) {
 		ctx := context.Background()
 		data := &AppData{
-			AuthMode: authMode,
+			AuthMode:   authMode,
+			Currencies: defaultCurrencies,
+		}
+
+		if cl := getClient(); cl != nil {
+			if user, err := commands.GetCurrentUser(ctx, cl); err == nil {
+				data.User = &user
+			}
+			if list, err := commands.GetCurrencies(ctx, cl); err == nil && len(list) > 0 {
+				data.Currencies = make([]string, 0, len(list))
+				for _, cur := range list {
+					data.Currencies = append(data.Currencies, cur.Code)
+				}
+			}
 		}
 
 		// Initialize state for OAuth
 		if authMode == "oauth" {
 			data.OAuthState = generateState()
+			oauthStates.Store(data.OAuthState, struct{}{})
 			data.AuthURL = oauthClient.AuthURL(data.OAuthState)
 			data.LoggedIn = getClient() != nil
 		} else {
@@ -160,7 +248,7 @@ func startServer(port string, sandbox bool) {
 			if cl == nil {
 				return
 			}
-			data.Rates = commands.GetRates(ctx, cl)
+			data.Rates, _ = commands.GetRates(ctx, cl, nil)
 			c.Sync()
 		})
 
@@ -229,8 +317,22 @@ func startServer(port string, sandbox bool) {
 			c.Sync()
 		})
 
+		logout := c.Action(func() {
+			if tokenMgr == nil {
+				return
+			}
+			// Revokes the token with Wise as well as deleting the saved copy
+			if err := tokenMgr.Revoke(ctx); err != nil {
+				fmt.Printf("Warning: logout: %v\n", err)
+			}
+			setClient(nil)
+			data.LoggedIn = false
+			data.User = nil
+			c.Sync()
+		})
+
 		c.View(func() H {
-			currencies := []string{"USD", "EUR", "GBP", "JPY", "CHF", "AUD", "CAD"}
+			currencies := data.Currencies
 			fromOpts := append([]H{fromCurrency.Bind()}, renderCurrencyOptions(currencies)...)
 			toOpts := append([]H{toCurrency.Bind()}, renderCurrencyOptions(currencies)...)
 
@@ -258,6 +360,7 @@ func startServer(port string, sandbox bool) {
 					H1(Text("Wise Account Dashboard")),
 					P(Text("Manage your Wise account with live data")),
 					renderAuthStatus(data),
+					renderLogout(data, logout.OnClick()),
 				),
 
 				Section(
@@ -339,13 +442,29 @@ func startServer(port string, sandbox bool) {
 }
 
 func renderAuthStatus(data *AppData) H {
+	var method string
+	switch {
+	case data.AuthMode == "token":
+		method = "API token"
+	case data.LoggedIn:
+		method = "OAuth"
+	default:
+		return nil
+	}
+	if data.User != nil {
+		return P(Small(Textf("Hello, %s (%s) - connected via %s", data.User.Name, data.User.Email, method)))
+	}
 	if data.AuthMode == "token" {
 		return P(Small(Text("Authenticated via API token")))
 	}
-	if data.LoggedIn {
-		return P(Small(Text("Connected via OAuth")))
+	return P(Small(Text("Connected via OAuth")))
+}
+
+func renderLogout(data *AppData, onClick H) H {
+	if data.AuthMode != "oauth" {
+		return nil
 	}
-	return nil
+	return Button(Class("secondary"), Text("Log out"), onClick)
 }
 
 func renderCurrencyOptions(currencies []string) []H {
@@ -363,8 +482,8 @@ func renderBalances(balances []commands.BalanceResult) H {
 
 	var rows []H
 	for _, b := range balances {
-		if b.Error != nil {
-			rows = append(rows, Tr(Td(Textf("Profile %d", b.ProfileID)), Td(Text("Error")), Td(Text(b.Error.Error()))))
+		if b.Error != "" {
+			rows = append(rows, Tr(Td(Textf("Profile %d", b.ProfileID)), Td(Text("Error")), Td(Text(b.Error))))
 			continue
 		}
 		for _, bal := range b.Balances {
@@ -393,7 +512,7 @@ func renderRates(rates []commands.RateResult) H {
 
 	var rows []H
 	for _, r := range rates {
-		if r.Error != nil {
+		if r.Error != "" {
 			rows = append(rows, Tr(Td(Textf("%s/%s", r.From, r.To)), Td(Text("Error"))))
 			continue
 		}
@@ -414,8 +533,8 @@ func renderQuote(quote *commands.QuoteResult) H {
 		return P(Text("Click 'Get Quote' to get a conversion quote"))
 	}
 
-	if quote.Error != nil {
-		return P(Style("color: red;"), Text(quote.Error.Error()))
+	if quote.Error != "" {
+		return P(Style("color: red;"), Text(quote.Error))
 	}
 
 	return Div(
@@ -452,7 +571,7 @@ func renderStatements(statements []commands.StatementResult) H {
 
 	var sections []H
 	for _, s := range statements {
-		if s.Error != nil {
+		if s.Error != "" {
 			sections = append(sections, P(Style("color: red;"), Textf("%s: %v", s.Currency, s.Error)))
 			continue
 		}
@@ -488,8 +607,8 @@ func renderRateHistory(history *commands.HistoryResult) H {
 		return P(Text("Click 'Get Rate History' to view historical exchange rates"))
 	}
 
-	if history.Error != nil {
-		return P(Style("color: red;"), Text(history.Error.Error()))
+	if history.Error != "" {
+		return P(Style("color: red;"), Text(history.Error))
 	}
 
 	var rows []H
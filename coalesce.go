@@ -0,0 +1,14 @@
+package wise
+
+import "golang.org/x/sync/singleflight"
+
+// WithRequestCoalescing shares one network request between concurrent
+// identical GET requests, such as a dashboard refreshing rates and profiles
+// from several places at once. Callers waiting on a shared request get its
+// result, including its error, so a request cancelled by the caller that
+// started it fails for everyone waiting on it.
+func WithRequestCoalescing() ClientOption {
+	return func(c *Client) {
+		c.flight = &singleflight.Group{}
+	}
+}
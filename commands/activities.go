@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// ActivityFilter selects activities for GetActivities. Zero fields match all.
+type ActivityFilter struct {
+	ProfileID int64 // Default: all profiles
+	Kind      string
+	Status    string
+	Since     time.Time
+	Until     time.Time
+	Limit     int // Maximum number of activities in the feed; 0 for all
+}
+
+// ActivityResult holds an activity of a profile, with markup removed from
+// its display strings.
+type ActivityResult struct {
+	ID              string `json:"id" csv:"id"`
+	ProfileID       int64  `json:"profileId" csv:"profile_id"`
+	Kind            string `json:"kind" csv:"kind"` // A wise.ActivityType, e.g. TRANSFER
+	ResourceID      string `json:"resourceId" csv:"resource_id"`
+	Title           string `json:"title" csv:"title"`
+	Description     string `json:"description" csv:"description"`
+	PrimaryAmount   string `json:"primaryAmount" csv:"primary_amount"`
+	SecondaryAmount string `json:"secondaryAmount" csv:"secondary_amount"`
+	Status          string `json:"status" csv:"status"`
+	Created         string `json:"created" csv:"created"`
+}
+
+// GetActivities fetches the activities of all profiles matching filter and
+// merges them into one feed, newest first. With filter.Limit set, at most
+// that many activities are fetched per profile and returned.
+func GetActivities(ctx context.Context, client *wise.Client, filter ActivityFilter) ([]ActivityResult, error) {
+	profileIDs := []int64{filter.ProfileID}
+	if filter.ProfileID == 0 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		profileIDs = profileIDs[:0]
+		for _, p := range profiles {
+			profileIDs = append(profileIDs, p.ID)
+		}
+	}
+
+	params := wise.ListActivitiesParams{
+		MonetaryResourceType: wise.ActivityType(strings.ToUpper(filter.Kind)),
+		Status:               wise.ActivityStatus(strings.ToUpper(filter.Status)),
+	}
+	if !filter.Since.IsZero() {
+		params.Since = filter.Since.UTC().Format(time.RFC3339)
+	}
+	if !filter.Until.IsZero() {
+		params.Until = filter.Until.UTC().Format(time.RFC3339)
+	}
+
+	type entry struct {
+		created time.Time
+		result  ActivityResult
+	}
+	var entries []entry
+	for _, profileID := range profileIDs {
+		var activities []wise.Activity
+		var err error
+		if filter.Limit > 0 {
+			params.Size = min(filter.Limit, 100)
+			var page *wise.ActivitiesPage
+			if page, err = client.Activities.List(ctx, profileID, &params); err == nil {
+				activities = page.Activities
+			}
+		} else {
+			activities, err = client.Activities.ListAll(ctx, profileID, &params)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("profile %d: %w", profileID, err)
+		}
+
+		for _, a := range activities {
+			entries = append(entries, entry{created: a.CreatedOn.Time, result: ActivityResult{
+				ID:              a.ID,
+				ProfileID:       profileID,
+				Kind:            string(a.Type),
+				ResourceID:      a.Resource.ID,
+				Title:           stripMarkup(a.Title),
+				Description:     stripMarkup(a.Description),
+				PrimaryAmount:   stripMarkup(a.PrimaryAmount),
+				SecondaryAmount: stripMarkup(a.SecondaryAmount),
+				Status:          string(a.Status),
+				Created:         a.CreatedOn.Format("2006-01-02 15:04"),
+			}})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].created.After(entries[j].created)
+	})
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[:filter.Limit]
+	}
+	results := make([]ActivityResult, len(entries))
+	for i, e := range entries {
+		results[i] = e.result
+	}
+	return results, nil
+}
+
+var markupTag = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+
+// stripMarkup removes the HTML tags Wise puts in activity display strings.
+func stripMarkup(s string) string {
+	return strings.TrimSpace(markupTag.ReplaceAllString(s, ""))
+}
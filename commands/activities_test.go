@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestGetActivities(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	const businessID = 2
+	srv.AddProfile(wise.Profile{ID: businessID, Type: wise.ProfileTypeBusiness})
+
+	day := func(d int) wise.Timestamp {
+		return wise.Timestamp{Time: time.Date(2025, 3, d, 12, 0, 0, 0, time.UTC)}
+	}
+	srv.AddActivity(wisetest.DefaultProfileID, wise.Activity{ID: "a1", Type: wise.ActivityTypeTransfer, Title: "To <strong>Alice</strong>", PrimaryAmount: "250 EUR", Status: wise.ActivityStatusCompleted, CreatedOn: day(1)})
+	srv.AddActivity(businessID, wise.Activity{ID: "b1", Type: wise.ActivityTypeCardPayment, Title: "Coffee", Status: wise.ActivityStatusCompleted, CreatedOn: day(2)})
+	srv.AddActivity(wisetest.DefaultProfileID, wise.Activity{ID: "a2", Type: wise.ActivityTypeConversion, PrimaryAmount: "<positive>+ 100 USD</positive>", Status: wise.ActivityStatusCompleted, CreatedOn: day(3)})
+	client := srv.Client()
+
+	results, err := GetActivities(context.Background(), client, ActivityFilter{})
+	if err != nil {
+		t.Fatalf("GetActivities failed: %v", err)
+	}
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	if len(ids) != 3 || ids[0] != "a2" || ids[1] != "b1" || ids[2] != "a1" {
+		t.Fatalf("got %v, want [a2 b1 a1]", ids)
+	}
+	if r := results[2]; r.Title != "To Alice" || r.Kind != "TRANSFER" || r.ProfileID != wisetest.DefaultProfileID {
+		t.Errorf("unexpected result: %+v", r)
+	}
+	if r := results[0]; r.PrimaryAmount != "+ 100 USD" {
+		t.Errorf("PrimaryAmount = %q", r.PrimaryAmount)
+	}
+
+	results, err = GetActivities(context.Background(), client, ActivityFilter{Limit: 1})
+	if err != nil {
+		t.Fatalf("GetActivities failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "a2" {
+		t.Errorf("with limit 1, got %+v", results)
+	}
+
+	results, err = GetActivities(context.Background(), client, ActivityFilter{Kind: "card_payment"})
+	if err != nil {
+		t.Fatalf("GetActivities failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "b1" {
+		t.Errorf("with kind filter, got %+v", results)
+	}
+}
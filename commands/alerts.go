@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Alert directions.
+const (
+	AlertAbove = "above"
+	AlertBelow = "below"
+)
+
+// RateAlert fires when the rate of a currency pair crosses a threshold.
+type RateAlert struct {
+	ID        string  `json:"id,omitempty" csv:"id"` // Optional, for the caller to identify the alert
+	From      string  `json:"from" csv:"from"`
+	To        string  `json:"to" csv:"to"`
+	Direction string  `json:"direction" csv:"direction"` // AlertAbove or AlertBelow
+	Threshold float64 `json:"threshold" csv:"threshold"`
+}
+
+// String returns the alert in the format accepted by ParseRateAlert.
+func (a RateAlert) String() string {
+	op := ">"
+	if a.Direction == AlertBelow {
+		op = "<"
+	}
+	return fmt.Sprintf("%s/%s%s%g", a.From, a.To, op, a.Threshold)
+}
+
+// FiredAlert is an alert whose threshold was crossed.
+type FiredAlert struct {
+	Alert    RateAlert `json:"alert" csv:"-"`
+	Rate     float64   `json:"rate" csv:"rate"`
+	Previous float64   `json:"previous" csv:"previous"` // 0 if there was no previous rate
+}
+
+// ParseRateAlert parses an alert such as "EUR/USD>1.10" or "GBP/EUR<1.15".
+func ParseRateAlert(s string) (RateAlert, error) {
+	i := strings.IndexAny(s, "<>")
+	if i < 0 {
+		return RateAlert{}, fmt.Errorf("invalid alert %q: want e.g. EUR/USD>1.10", s)
+	}
+	from, to, ok := strings.Cut(strings.TrimSpace(s[:i]), "/")
+	if !ok || from == "" || to == "" {
+		return RateAlert{}, fmt.Errorf("invalid alert %q: want a currency pair like EUR/USD", s)
+	}
+	threshold, err := strconv.ParseFloat(strings.TrimSpace(s[i+1:]), 64)
+	if err != nil || threshold <= 0 {
+		return RateAlert{}, fmt.Errorf("invalid alert %q: threshold must be a positive number", s)
+	}
+
+	alert := RateAlert{
+		From:      strings.ToUpper(strings.TrimSpace(from)),
+		To:        strings.ToUpper(strings.TrimSpace(to)),
+		Direction: AlertAbove,
+		Threshold: threshold,
+	}
+	if s[i] == '<' {
+		alert.Direction = AlertBelow
+	}
+	return alert, nil
+}
+
+// EvaluateRateAlerts returns the alerts whose threshold the current rates
+// have crossed since the previous rates, in the order of alerts. Without a
+// previous rate for a pair, an alert fires if its condition holds. Rates
+// with an error are ignored. previous may be nil.
+func EvaluateRateAlerts(alerts []RateAlert, current, previous []RateResult) []FiredAlert {
+	now, before := rateMap(current), rateMap(previous)
+
+	var fired []FiredAlert
+	for _, a := range alerts {
+		pair := a.From + "/" + a.To
+		rate, ok := now[pair]
+		if !ok {
+			continue
+		}
+		prev, hadPrev := before[pair]
+		if a.met(rate) && !(hadPrev && a.met(prev)) {
+			fired = append(fired, FiredAlert{Alert: a, Rate: rate, Previous: prev})
+		}
+	}
+	return fired
+}
+
+func (a RateAlert) met(rate float64) bool {
+	if a.Direction == AlertBelow {
+		return rate <= a.Threshold
+	}
+	return rate >= a.Threshold
+}
+
+func rateMap(rates []RateResult) map[string]float64 {
+	m := make(map[string]float64, len(rates))
+	for _, r := range rates {
+		if r.Error == "" && r.Rate > 0 {
+			m[r.From+"/"+r.To] = r.Rate
+		}
+	}
+	return m
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"testing"
+)
+
+func TestParseRateAlert(t *testing.T) {
+	a, err := ParseRateAlert("eur/usd > 1.10")
+	if err != nil {
+		t.Fatalf("ParseRateAlert failed: %v", err)
+	}
+	if a != (RateAlert{From: "EUR", To: "USD", Direction: AlertAbove, Threshold: 1.1}) {
+		t.Errorf("unexpected alert: %+v", a)
+	}
+	if a.String() != "EUR/USD>1.1" {
+		t.Errorf("String() = %q", a.String())
+	}
+
+	for _, bad := range []string{"EUR/USD", "EURUSD>1", "EUR/USD<abc", "EUR/USD>-1", "/USD>1"} {
+		if _, err := ParseRateAlert(bad); err == nil {
+			t.Errorf("ParseRateAlert(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestEvaluateRateAlerts(t *testing.T) {
+	alerts := []RateAlert{
+		{ID: "up", From: "EUR", To: "USD", Direction: AlertAbove, Threshold: 1.10},
+		{ID: "down", From: "GBP", To: "USD", Direction: AlertBelow, Threshold: 1.25},
+		{ID: "missing", From: "USD", To: "JPY", Direction: AlertAbove, Threshold: 100},
+	}
+	rates := func(eurUSD, gbpUSD float64) []RateResult {
+		return []RateResult{
+			{From: "EUR", To: "USD", Rate: eurUSD},
+			{From: "GBP", To: "USD", Rate: gbpUSD},
+			{From: "USD", To: "JPY", Error: "unavailable"},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		current, previous []RateResult
+		want              []string
+	}{
+		{"no previous, condition holds", rates(1.12, 1.30), nil, []string{"up"}},
+		{"crossing up and down", rates(1.11, 1.24), rates(1.09, 1.26), []string{"up", "down"}},
+		{"already past threshold", rates(1.12, 1.20), rates(1.11, 1.24), nil},
+		{"no crossing", rates(1.05, 1.30), rates(1.04, 1.31), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fired := EvaluateRateAlerts(alerts, tt.current, tt.previous)
+			var got []string
+			for _, f := range fired {
+				got = append(got, f.Alert.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("fired %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("fired %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"os"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// TokenStoreFromEnv returns the OAuth token store shared by the CLI and the
+// server, so a login in one is picked up by the other.
+//
+//	WISE_TOKEN_STORE  "keyring" for the OS keychain, otherwise a file path
+//	                  (default: wise.DefaultTokenFile)
+//	WISE_TOKEN_KEY    Passphrase to encrypt the token file with (optional)
+func TokenStoreFromEnv() (wise.TokenStore, error) {
+	location := os.Getenv("WISE_TOKEN_STORE")
+	if location == "" {
+		path, err := wise.DefaultTokenFile()
+		if err != nil {
+			return nil, err
+		}
+		location = path
+	}
+	return tokenStore(location, "")
+}
+
+// tokenStore returns the token store at location, "keyring" or a file
+// path, encrypting token files with WISE_TOKEN_KEY if set. The keychain
+// entry of a named account is kept apart from the others.
+func tokenStore(location, account string) (wise.TokenStore, error) {
+	if location == "keyring" {
+		user := "oauth-token"
+		if account != "" {
+			user += "-" + account
+		}
+		return wise.NewKeyringTokenStore("plat-wise", user), nil
+	}
+
+	if passphrase := os.Getenv("WISE_TOKEN_KEY"); passphrase != "" {
+		return wise.NewPassphraseTokenStore(location, passphrase), nil
+	}
+	return wise.NewFileTokenStore(location, nil), nil
+}
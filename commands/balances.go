@@ -0,0 +1,247 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/export"
+)
+
+// ConvertResult holds the result of a conversion between balances.
+type ConvertResult struct {
+	ProfileID    int64   `json:"profileId" csv:"profile_id"`
+	From         string  `json:"from" csv:"from"`
+	To           string  `json:"to" csv:"to"`
+	SourceAmount float64 `json:"sourceAmount" csv:"source_amount"`
+	TargetAmount float64 `json:"targetAmount" csv:"target_amount"`
+	Rate         float64 `json:"rate" csv:"rate"`
+	Fee          float64 `json:"fee" csv:"fee"` // In the source currency
+	QuoteID      string  `json:"quoteId" csv:"quote_id"`
+	Converted    bool    `json:"converted" csv:"converted"` // False for a quote not yet executed
+	Error        string  `json:"error,omitempty" csv:"error"`
+}
+
+// ConvertBalance converts amount of the from balance into the to balance of
+// the first profile, using a BALANCE to BALANCE quote. Both balances must
+// exist.
+func ConvertBalance(ctx context.Context, client *wise.Client, from, to string, amount float64) ConvertResult {
+	result := QuoteConversion(ctx, client, from, to, amount)
+	if result.Error != "" {
+		return result
+	}
+	return ExecuteConversion(ctx, client, result)
+}
+
+// QuoteConversion quotes converting amount of the from balance into the to
+// balance of the first profile without converting, e.g. to confirm the
+// rate and fee first. ExecuteConversion converts at the quoted rate until
+// the quote expires.
+func QuoteConversion(ctx context.Context, client *wise.Client, from, to string, amount float64) ConvertResult {
+	result := ConvertResult{From: from, To: to, SourceAmount: amount}
+	if amount <= 0 {
+		result.Error = "amount must be positive"
+		return result
+	}
+
+	profiles, err := client.Profiles.List(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if len(profiles) == 0 {
+		result.Error = "no profiles found"
+		return result
+	}
+	result.ProfileID = profiles[0].ID
+
+	quote, err := client.Quotes.Create(ctx, result.ProfileID, &wise.CreateQuoteRequest{
+		SourceCurrency: wise.Currency(from),
+		TargetCurrency: wise.Currency(to),
+		SourceAmount:   &amount,
+		PayOut:         "BALANCE",
+		PreferredPayIn: "BALANCE",
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.QuoteID = quote.ID
+	result.Rate = quote.Rate
+	result.SourceAmount, result.TargetAmount, result.Fee = balanceOption(quote)
+	return result
+}
+
+// ExecuteConversion converts the balances as quoted by QuoteConversion.
+func ExecuteConversion(ctx context.Context, client *wise.Client, quoted ConvertResult) ConvertResult {
+	result := quoted
+	if err := client.Balances.Convert(ctx, result.ProfileID, result.QuoteID); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Converted = true
+	return result
+}
+
+// PortfolioResult holds the value of all balances in one currency.
+type PortfolioResult struct {
+	Currency string    `json:"currency" csv:"currency"`
+	Total    float64   `json:"total" csv:"total"`
+	Holdings []Holding `json:"holdings" csv:"-"`
+}
+
+// Holding is a balance valued in the portfolio currency. Holdings whose
+// rate could not be fetched have Error set and are left out of the total;
+// so does the one holding of a profile whose balances cannot be listed.
+type Holding struct {
+	ProfileID int64   `json:"profileId" csv:"profile_id"`
+	Currency  string  `json:"currency" csv:"currency"`
+	Type      string  `json:"type,omitempty" csv:"type"` // STANDARD or SAVINGS
+	Amount    float64 `json:"amount" csv:"amount"`
+	Rate      float64 `json:"rate" csv:"rate"`
+	Value     float64 `json:"value" csv:"value"`
+	Error     string  `json:"error,omitempty" csv:"error"`
+}
+
+// PortfolioValue values the non-empty balances and savings jars of all
+// profiles in currency at current mid-market rates. Rates are fetched once
+// per currency.
+func PortfolioValue(ctx context.Context, client *wise.Client, currency string) (PortfolioResult, error) {
+	currency = strings.ToUpper(currency)
+	target := wise.Currency(currency)
+	result := PortfolioResult{Currency: currency}
+
+	balances, err := getBalances(ctx, client, true)
+	if err != nil {
+		return result, err
+	}
+
+	rates := map[string]float64{currency: 1}
+	rateErrs := map[string]error{}
+	for _, profile := range balances {
+		if profile.Error != "" {
+			result.Holdings = append(result.Holdings, Holding{ProfileID: profile.ProfileID, Error: profile.Error})
+			continue
+		}
+		for _, b := range profile.Balances {
+			if b.Amount == 0 {
+				continue
+			}
+			holding := Holding{ProfileID: profile.ProfileID, Currency: b.Currency, Type: b.Type, Amount: b.Amount}
+
+			rate, ok := rates[b.Currency]
+			if !ok && rateErrs[b.Currency] == nil {
+				r, err := client.ExchangeRates.Get(ctx, wise.Currency(b.Currency), target)
+				if err != nil {
+					rateErrs[b.Currency] = err
+				} else {
+					rate, rates[b.Currency] = r.Rate, r.Rate
+				}
+			}
+			if err := rateErrs[b.Currency]; err != nil {
+				holding.Error = err.Error()
+			} else {
+				holding.Rate = rate
+				holding.Value = target.Round(b.Amount * rate)
+				result.Total += holding.Value
+			}
+			result.Holdings = append(result.Holdings, holding)
+		}
+	}
+	result.Total = target.Round(result.Total)
+	return result, nil
+}
+
+// snapshotCSVHeader is the header row written by WriteSnapshotCSV.
+var snapshotCSVHeader = []string{"time", "profile_id", "currency", "amount", "base_currency", "rate", "value", "error"}
+
+// SnapshotRecord is one balance at the time of a BalanceSnapshot. Records
+// are flat so that snapshots can be appended to a CSV file or a database
+// table and charted over time.
+type SnapshotRecord struct {
+	Time         string  `json:"time" csv:"time"` // RFC 3339, the same for all records of a snapshot
+	ProfileID    int64   `json:"profileId" csv:"profile_id"`
+	Currency     string  `json:"currency" csv:"currency"`
+	Amount       float64 `json:"amount" csv:"amount"`
+	BaseCurrency string  `json:"baseCurrency" csv:"base_currency"`
+	Rate         float64 `json:"rate" csv:"rate"`   // From Currency to BaseCurrency
+	Value        float64 `json:"value" csv:"value"` // Amount in BaseCurrency
+	Error        string  `json:"error,omitempty" csv:"error"`
+}
+
+// BalanceSnapshot captures every balance of every profile, including empty
+// ones, valued in base at current mid-market rates. Rates are fetched once
+// per currency. A profile whose balances cannot be listed gets one record
+// with Error set; so does a balance whose rate cannot be fetched.
+func BalanceSnapshot(ctx context.Context, client *wise.Client, base string) ([]SnapshotRecord, error) {
+	base = strings.ToUpper(base)
+	now := time.Now().UTC().Format(time.RFC3339)
+	target := wise.Currency(base)
+
+	balances, err := GetBalances(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := map[string]float64{base: 1}
+	rateErrs := map[string]string{}
+	var records []SnapshotRecord
+	for _, profile := range balances {
+		if profile.Error != "" {
+			records = append(records, SnapshotRecord{Time: now, ProfileID: profile.ProfileID, BaseCurrency: base, Error: profile.Error})
+			continue
+		}
+		for _, b := range profile.Balances {
+			record := SnapshotRecord{Time: now, ProfileID: profile.ProfileID, Currency: b.Currency, Amount: b.Amount, BaseCurrency: base}
+			rate, ok := rates[b.Currency]
+			if !ok && rateErrs[b.Currency] == "" {
+				r, err := client.ExchangeRates.Get(ctx, wise.Currency(b.Currency), target)
+				if err != nil {
+					rateErrs[b.Currency] = err.Error()
+				} else {
+					rate, rates[b.Currency] = r.Rate, r.Rate
+				}
+			}
+			if msg := rateErrs[b.Currency]; msg != "" {
+				record.Error = msg
+			} else {
+				record.Rate = rate
+				record.Value = target.Round(b.Amount * rate)
+			}
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// WriteSnapshotCSV writes records to w as CSV, preceded by a header row if
+// header is set. Leave out the header when appending to an existing file.
+func WriteSnapshotCSV(w io.Writer, records []SnapshotRecord, header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(snapshotCSVHeader); err != nil {
+			return err
+		}
+	}
+	for _, r := range records {
+		row := []string{
+			r.Time,
+			strconv.FormatInt(r.ProfileID, 10),
+			r.Currency,
+			export.FormatAmount(r.Amount, wise.Currency(r.Currency)),
+			r.BaseCurrency,
+			strconv.FormatFloat(r.Rate, 'f', -1, 64),
+			export.FormatAmount(r.Value, wise.Currency(r.BaseCurrency)),
+			r.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestConvertBalance(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	result := ConvertBalance(ctx, client, "EUR", "USD", 100)
+	if result.Error != "" {
+		t.Fatalf("ConvertBalance failed: %v", result.Error)
+	}
+	if result.Rate != 1.08 || result.Fee != 0.5 || result.TargetAmount != 107.46 || result.QuoteID == "" || !result.Converted {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	usd, err := client.Balances.GetByCurrency(ctx, wisetest.DefaultProfileID, wise.USD)
+	if err != nil {
+		t.Fatalf("GetByCurrency failed: %v", err)
+	}
+	if usd.Amount.Value != 357.46 {
+		t.Errorf("expected USD balance 357.46, got %v", usd.Amount.Value)
+	}
+
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 5000); result.Error == "" {
+		t.Error("expected insufficient funds error")
+	}
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 0); result.Error == "" || result.QuoteID != "" {
+		t.Errorf("expected a zero amount to be rejected before quoting, got %+v", result)
+	}
+}
+
+func TestQuoteConversion(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	quoted := QuoteConversion(ctx, client, "EUR", "USD", 100)
+	if quoted.Error != "" || quoted.Converted || quoted.TargetAmount != 107.46 {
+		t.Fatalf("unexpected quote: %+v", quoted)
+	}
+	usd, _ := client.Balances.GetByCurrency(ctx, wisetest.DefaultProfileID, wise.USD)
+	if usd.Amount.Value != 250 {
+		t.Errorf("quoting changed the USD balance to %v", usd.Amount.Value)
+	}
+
+	result := ExecuteConversion(ctx, client, quoted)
+	if result.Error != "" || !result.Converted {
+		t.Fatalf("ExecuteConversion failed: %+v", result)
+	}
+	usd, _ = client.Balances.GetByCurrency(ctx, wisetest.DefaultProfileID, wise.USD)
+	if usd.Amount.Value != 357.46 {
+		t.Errorf("expected USD balance 357.46, got %v", usd.Amount.Value)
+	}
+}
+
+// failPath answers requests for path with 403 Forbidden and sends the
+// others on.
+type failPath string
+
+func (p failPath) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path != string(p) {
+		return http.DefaultTransport.RoundTrip(req)
+	}
+	return &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"message": "Forbidden"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestPortfolioValue(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	srv.AddBalance(wisetest.DefaultProfileID, wise.CHF, 10) // No rate to USD
+	srv.AddSavings(wisetest.DefaultProfileID, wise.EUR, "Holiday", 100)
+	srv.AddProfile(wise.Profile{ID: 99, Type: wise.ProfileTypeBusiness})
+	client := srv.Client(wise.WithTransport(failPath("/v4/profiles/99/balances")))
+
+	result, err := PortfolioValue(context.Background(), client, "usd")
+	if err != nil {
+		t.Fatalf("PortfolioValue failed: %v", err)
+	}
+	if result.Currency != "USD" {
+		t.Errorf("expected currency USD, got %q", result.Currency)
+	}
+	// 1100 EUR * 1.08 + 500 GBP * 1.27 + 250 USD
+	if result.Total != 2073 {
+		t.Errorf("expected total 2073, got %v", result.Total)
+	}
+	if len(result.Holdings) != 6 {
+		t.Fatalf("expected 6 holdings, got %+v", result.Holdings)
+	}
+	for _, h := range result.Holdings {
+		failed := h.Currency == "CHF" || h.ProfileID == 99
+		if failed != (h.Error != "") {
+			t.Errorf("unexpected holding: %+v", h)
+		}
+		if h.Type == string(wise.BalanceTypeSavings) && (h.Currency != "EUR" || h.Value != 108) {
+			t.Errorf("unexpected savings holding: %+v", h)
+		}
+	}
+}
+
+func TestBalanceSnapshot(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	srv.AddBalance(wisetest.DefaultProfileID, wise.CHF, 0) // No rate to USD
+
+	records, err := BalanceSnapshot(context.Background(), srv.Client(), "usd")
+	if err != nil {
+		t.Fatalf("BalanceSnapshot failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %+v", records)
+	}
+	for _, r := range records {
+		if r.Time != records[0].Time || r.BaseCurrency != "USD" {
+			t.Errorf("unexpected record: %+v", r)
+		}
+	}
+	if eur := records[0]; eur.Currency != "EUR" || eur.Rate != 1.08 || eur.Value != 1080 {
+		t.Errorf("unexpected EUR record: %+v", eur)
+	}
+	if chf := records[3]; chf.Currency != "CHF" || chf.Error == "" {
+		t.Errorf("expected a rate error for CHF, got %+v", chf)
+	}
+
+	var buf strings.Builder
+	if err := WriteSnapshotCSV(&buf, records[:1], true); err != nil {
+		t.Fatalf("WriteSnapshotCSV failed: %v", err)
+	}
+	want := "time,profile_id,currency,amount,base_currency,rate,value,error\n" +
+		records[0].Time + ",1,EUR,1000.00,USD,1.08,1080.00,\n"
+	if buf.String() != want {
+		t.Errorf("got CSV\n%s\nwant\n%s", buf.String(), want)
+	}
+}
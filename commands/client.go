@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// DefaultClientOptions returns the client options shared by the CLI, the
+// MCP server and the web server: the application's user agent, retries of
+// rate limits and transient failures, and the SCA signing key in
+// WISE_SCA_KEY_FILE if set. A positive cache caches rates, currencies and
+// profiles for that long across repeated calls, which suits the servers.
+func DefaultClientOptions(userAgent string, cache time.Duration) ([]wise.ClientOption, error) {
+	opts := []wise.ClientOption{
+		wise.WithUserAgent(userAgent),
+		wise.WithRetryPolicy(wise.DefaultRetryPolicy()),
+	}
+	if cache > 0 {
+		opts = append(opts, wise.WithCache(cache))
+	}
+	if keyFile := os.Getenv("WISE_SCA_KEY_FILE"); keyFile != "" {
+		key, err := wise.LoadSCAPrivateKey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, wise.WithSCAPrivateKey(key))
+	}
+	return opts, nil
+}
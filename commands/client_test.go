@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDefaultClientOptions(t *testing.T) {
+	t.Setenv("WISE_SCA_KEY_FILE", "")
+	opts, err := DefaultClientOptions("test", 0)
+	if err != nil || len(opts) != 2 {
+		t.Errorf("expected the user agent and retry options, got %d, %v", len(opts), err)
+	}
+	if cached, _ := DefaultClientOptions("test", time.Minute); len(cached) != 3 {
+		t.Errorf("expected a cache option, got %d options", len(cached))
+	}
+
+	t.Setenv("WISE_SCA_KEY_FILE", filepath.Join(t.TempDir(), "missing.pem"))
+	if _, err := DefaultClientOptions("test", 0); err == nil {
+		t.Error("expected an error for a missing SCA key file")
+	}
+}
@@ -4,107 +4,223 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	wise "github.com/joeblew999/plat-wise"
+	"golang.org/x/sync/errgroup"
 )
 
-// RateResult holds an exchange rate result.
+// Result structs are tagged so that they can be written as JSON (MCP, REST)
+// or CSV rows (CLI) as they are. Errors are strings, since error values
+// marshal to {}; fields holding nested lists are left out of CSV.
+
+// RateResult holds an exchange rate result. Reference and Markup are set
+// by CompareRates.
 type RateResult struct {
-	From  string
-	To    string
-	Rate  float64
-	Error error
+	From           string  `json:"from" csv:"from"`
+	To             string  `json:"to" csv:"to"`
+	Rate           float64 `json:"rate" csv:"rate"`
+	Time           string  `json:"time,omitempty" csv:"time"` // When Wise published the rate, RFC 3339
+	Reference      float64 `json:"reference,omitempty" csv:"reference"`
+	Markup         float64 `json:"markup,omitempty" csv:"markup"` // Percent the rate is worse than Reference
+	ReferenceError string  `json:"referenceError,omitempty" csv:"reference_error"`
+	Error          string  `json:"error,omitempty" csv:"error"`
+}
+
+// CurrencyPair is a source and target currency, e.g. EUR/USD.
+type CurrencyPair struct {
+	From string `json:"from" csv:"from"`
+	To   string `json:"to" csv:"to"`
+}
+
+// String returns the pair as "FROM/TO".
+func (p CurrencyPair) String() string {
+	return p.From + "/" + p.To
+}
+
+// ParseCurrencyPair parses a pair such as "EUR/USD" or "eur-usd".
+func ParseCurrencyPair(s string) (CurrencyPair, error) {
+	from, to, ok := strings.Cut(strings.ToUpper(strings.TrimSpace(s)), "/")
+	if !ok {
+		from, to, ok = strings.Cut(strings.ToUpper(strings.TrimSpace(s)), "-")
+	}
+	if !ok || !wise.Currency(from).IsValid() || !wise.Currency(to).IsValid() {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q: want e.g. EUR/USD", s)
+	}
+	return CurrencyPair{From: from, To: to}, nil
 }
 
 // ProfileResult holds a profile result.
 type ProfileResult struct {
-	ID   int64
-	Type string
+	ID   int64  `json:"id" csv:"id"`
+	Type string `json:"type" csv:"type"`
+}
+
+// UserResult holds the authenticated user.
+type UserResult struct {
+	ID    int64  `json:"id" csv:"id"`
+	Name  string `json:"name" csv:"name"`
+	Email string `json:"email" csv:"email"`
+}
+
+// CurrencyResult holds a currency supported by Wise.
+type CurrencyResult struct {
+	Code     string `json:"code" csv:"code"`
+	Name     string `json:"name" csv:"name"`
+	Symbol   string `json:"symbol" csv:"symbol"`
+	Decimals int    `json:"decimals" csv:"decimals"`
 }
 
 // BalanceResult holds balance information for a profile.
 type BalanceResult struct {
-	ProfileID   int64
-	ProfileType string
-	Balances    []CurrencyBalance
-	Error       error
+	ProfileID   int64             `json:"profileId" csv:"profile_id"`
+	ProfileType string            `json:"profileType" csv:"profile_type"`
+	Balances    []CurrencyBalance `json:"balances" csv:"-"`
+	Error       string            `json:"error,omitempty" csv:"error"`
 }
 
 // CurrencyBalance holds a single currency balance.
 type CurrencyBalance struct {
-	Currency string
-	Amount   float64
+	Currency string  `json:"currency" csv:"currency"`
+	Amount   float64 `json:"amount" csv:"amount"`
+	Type     string  `json:"type,omitempty" csv:"type"` // STANDARD or SAVINGS
 }
 
 // StatementResult holds statement information.
 type StatementResult struct {
-	Currency    string
-	BalanceID   int64
-	Transactions []Transaction
-	Error       error
+	Currency     string        `json:"currency" csv:"currency"`
+	BalanceID    int64         `json:"balanceId" csv:"balance_id"`
+	Transactions []Transaction `json:"transactions" csv:"-"`
+	Error        string        `json:"error,omitempty" csv:"error"`
 }
 
 // Transaction holds a single transaction.
 type Transaction struct {
-	Date     string
-	Type     string
-	Amount   float64
-	Currency string
+	Date     string  `json:"date" csv:"date"`
+	Type     string  `json:"type" csv:"type"`
+	Amount   float64 `json:"amount" csv:"amount"`
+	Currency string  `json:"currency" csv:"currency"`
 }
 
-// QuoteResult holds a quote result.
+// QuoteResult holds a quote result. Amounts, fee and delivery are those of
+// the cheapest enabled payment option, if the quote has one. Options lists
+// every enabled payment option, cheapest first.
 type QuoteResult struct {
-	From         string
-	To           string
-	SourceAmount float64
-	TargetAmount float64
-	Rate         float64
-	QuoteID      string
-	Expires      string
-	Error        error
+	From              string         `json:"from" csv:"from"`
+	To                string         `json:"to" csv:"to"`
+	SourceAmount      float64        `json:"sourceAmount" csv:"source_amount"`
+	TargetAmount      float64        `json:"targetAmount" csv:"target_amount"`
+	Rate              float64        `json:"rate" csv:"rate"`
+	Fee               wise.Money     `json:"fee" csv:"fee"`
+	FeePercentage     float64        `json:"feePercentage" csv:"fee_percentage"` // Fee as a percentage of the source amount
+	PayIn             string         `json:"payIn" csv:"pay_in"`
+	PayOut            string         `json:"payOut" csv:"pay_out"`
+	EstimatedDelivery string         `json:"estimatedDelivery" csv:"estimated_delivery"` // e.g. "2006-01-02 15:04"
+	QuoteID           string         `json:"quoteId" csv:"quote_id"`
+	Expires           string         `json:"expires" csv:"expires"`
+	Options           []OptionResult `json:"options,omitempty" csv:"-"`
+	Error             string         `json:"error,omitempty" csv:"error"`
 }
 
 // HistoryResult holds rate history information.
 type HistoryResult struct {
-	From       string
-	To         string
-	DataPoints []HistoryPoint
-	Min        float64
-	Max        float64
-	First      float64
-	Last       float64
-	Error      error
+	From       string         `json:"from" csv:"from"`
+	To         string         `json:"to" csv:"to"`
+	DataPoints []HistoryPoint `json:"dataPoints" csv:"-"`
+	Min        float64        `json:"min" csv:"min"`
+	MinTime    string         `json:"minTime" csv:"min_time"`
+	Max        float64        `json:"max" csv:"max"`
+	MaxTime    string         `json:"maxTime" csv:"max_time"`
+	First      float64        `json:"first" csv:"first"`
+	Last       float64        `json:"last" csv:"last"`
+	Change     float64        `json:"change" csv:"change"`  // Percent change from First to Last
+	StdDev     float64        `json:"stdDev" csv:"std_dev"` // Standard deviation of the rates
+	Trend      string         `json:"trend" csv:"trend"`    // TrendUp, TrendDown or TrendFlat
+	Error      string         `json:"error,omitempty" csv:"error"`
 }
 
-// HistoryPoint holds a single historical rate point.
+// HistoryPoint holds a single historical rate point. SMA and EMA are set
+// by HistoryResult.MovingAverages.
 type HistoryPoint struct {
-	Time string
-	Rate float64
+	Time string  `json:"time" csv:"time"`
+	Rate float64 `json:"rate" csv:"rate"`
+	SMA  float64 `json:"sma,omitempty" csv:"sma"`
+	EMA  float64 `json:"ema,omitempty" csv:"ema"`
+}
+
+// DefaultCurrencies are the currencies PairsFrom fans out to by default.
+var DefaultCurrencies = []string{"USD", "EUR", "GBP", "JPY"}
+
+// DefaultPairs returns the currency pairs GetRates fetches when given none.
+func DefaultPairs() []CurrencyPair {
+	return []CurrencyPair{
+		{From: "USD", To: "EUR"},
+		{From: "GBP", To: "USD"},
+		{From: "EUR", To: "GBP"},
+		{From: "USD", To: "JPY"},
+	}
 }
 
-// GetRates fetches exchange rates for common currency pairs.
-func GetRates(ctx context.Context, client *wise.Client) []RateResult {
-	pairs := [][2]wise.Currency{
-		{wise.USD, wise.EUR},
-		{wise.GBP, wise.USD},
-		{wise.EUR, wise.GBP},
-		{wise.USD, wise.JPY},
+// PairsFrom returns the pairs from base to each of targets, skipping base
+// itself. Without targets, DefaultCurrencies are used.
+func PairsFrom(base string, targets ...string) []CurrencyPair {
+	if len(targets) == 0 {
+		targets = DefaultCurrencies
+	}
+	base = strings.ToUpper(base)
+	pairs := make([]CurrencyPair, 0, len(targets))
+	for _, t := range targets {
+		if t = strings.ToUpper(t); t != base {
+			pairs = append(pairs, CurrencyPair{From: base, To: t})
+		}
 	}
+	return pairs
+}
 
-	results := make([]RateResult, 0, len(pairs))
-	for _, pair := range pairs {
-		result := RateResult{From: string(pair[0]), To: string(pair[1])}
-		rate, err := client.ExchangeRates.Get(ctx, pair[0], pair[1])
+// ParseCurrencyPairs parses a comma-separated list of pairs such as
+// "EUR/USD,GBP-USD".
+func ParseCurrencyPairs(s string) ([]CurrencyPair, error) {
+	var pairs []CurrencyPair
+	for _, field := range strings.Split(s, ",") {
+		if strings.TrimSpace(field) == "" {
+			continue
+		}
+		p, err := ParseCurrencyPair(field)
 		if err != nil {
-			result.Error = err
-		} else {
-			result.Rate = rate.Rate
+			return nil, err
 		}
-		results = append(results, result)
+		pairs = append(pairs, p)
 	}
-	return results
+	return pairs, nil
+}
+
+// GetRates fetches exchange rates for pairs, or for DefaultPairs if pairs
+// is empty. A failure for one pair is reported in its result. If ctx is
+// cancelled, the rates fetched so far are returned with a *PartialError.
+func GetRates(ctx context.Context, client *wise.Client, pairs []CurrencyPair) ([]RateResult, error) {
+	if len(pairs) == 0 {
+		pairs = DefaultPairs()
+	}
+
+	results := make([]RateResult, len(pairs))
+	done := make([]bool, len(pairs))
+	for i, pair := range pairs {
+		if ctx.Err() != nil {
+			break
+		}
+		results[i] = GetRate(ctx, client, pair.From, pair.To)
+		done[i] = results[i].Error == "" || ctx.Err() == nil
+	}
+	return gather(ctx, results, done, func(r RateResult) string {
+		if r.Error == "" {
+			return ""
+		}
+		return r.From + "/" + r.To + ": " + r.Error
+	})
 }
 
 // GetRate fetches a single exchange rate.
@@ -112,9 +228,12 @@ func GetRate(ctx context.Context, client *wise.Client, from, to string) RateResu
 	result := RateResult{From: from, To: to}
 	rate, err := client.ExchangeRates.Get(ctx, wise.Currency(from), wise.Currency(to))
 	if err != nil {
-		result.Error = err
+		result.Error = err.Error()
 	} else {
 		result.Rate = rate.Rate
+		if !rate.Time.IsZero() {
+			result.Time = rate.Time.UTC().Format(time.RFC3339)
+		}
 	}
 	return result
 }
@@ -133,92 +252,301 @@ func GetProfiles(ctx context.Context, client *wise.Client) ([]ProfileResult, err
 	return results, nil
 }
 
-// GetBalances fetches balances for all profiles.
+// GetCurrentUser fetches the authenticated user.
+func GetCurrentUser(ctx context.Context, client *wise.Client) (UserResult, error) {
+	user, err := client.Users.Me(ctx)
+	if err != nil {
+		return UserResult{}, err
+	}
+	return UserResult{ID: user.ID, Name: user.Name, Email: user.Email}, nil
+}
+
+// GetCurrencies fetches the currencies supported by Wise.
+func GetCurrencies(ctx context.Context, client *wise.Client) ([]CurrencyResult, error) {
+	currencies, err := client.Currencies.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CurrencyResult, 0, len(currencies))
+	for _, c := range currencies {
+		symbol := c.Symbol
+		if symbol == "" {
+			symbol = c.Code.Symbol()
+		}
+		results = append(results, CurrencyResult{
+			Code:     string(c.Code),
+			Name:     c.Name,
+			Symbol:   symbol,
+			Decimals: c.DecimalPlaces(),
+		})
+	}
+	return results, nil
+}
+
+// KnownCurrencies lists every ISO 4217 currency without calling the API.
+// Use it as a fallback until GetCurrencies has loaded.
+func KnownCurrencies() []CurrencyResult {
+	all := wise.AllCurrencies()
+	results := make([]CurrencyResult, 0, len(all))
+	for _, c := range all {
+		results = append(results, CurrencyResult{
+			Code:     string(c.Code),
+			Name:     c.Name,
+			Symbol:   c.Symbol,
+			Decimals: c.MinorUnits,
+		})
+	}
+	return results
+}
+
+// maxConcurrency limits the API calls GetBalances and GetStatements make at once.
+const maxConcurrency = 4
+
+// PartialError is returned with the results of a batch command that was
+// cancelled before it had fetched everything. The results returned with it
+// are the ones completed; items that were not fetched, or failed because
+// of the cancellation, are left out.
+type PartialError struct {
+	Done  int   // Number of results returned
+	Total int   // Number of results requested; a profile whose balances were not listed counts as one
+	Err   error // The context's error, joined with the errors of returned results
+}
+
+// Error implements error.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("partial results (%d of %d): %v", e.Done, e.Total, e.Err)
+}
+
+// Unwrap returns the joined errors, so that errors.Is(err, context.Canceled)
+// reports whether the command was cancelled.
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// gather returns the results marked done. If ctx was cancelled before all
+// were done, it also returns a *PartialError joining ctx.Err() with the
+// errors of the returned results, as reported by errOf.
+func gather[T any](ctx context.Context, results []T, done []bool, errOf func(T) string) ([]T, error) {
+	if ctx.Err() == nil || !slices.Contains(done, false) {
+		return results, nil
+	}
+	errs := []error{ctx.Err()}
+	gathered := make([]T, 0, len(results))
+	for i, r := range results {
+		if !done[i] {
+			continue
+		}
+		gathered = append(gathered, r)
+		if msg := errOf(r); msg != "" {
+			errs = append(errs, errors.New(msg))
+		}
+	}
+	return gathered, &PartialError{Done: len(gathered), Total: len(results), Err: errors.Join(errs...)}
+}
+
+// GetBalances fetches balances for all profiles, several profiles at a time.
+// A failure for one profile is reported in its result. If ctx is cancelled,
+// the profiles fetched so far are returned with a *PartialError.
 func GetBalances(ctx context.Context, client *wise.Client) ([]BalanceResult, error) {
+	return getBalances(ctx, client, false)
+}
+
+// getBalances is GetBalances, with savings jars too if savings is set.
+func getBalances(ctx context.Context, client *wise.Client, savings bool) ([]BalanceResult, error) {
 	profiles, err := client.Profiles.List(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	results := make([]BalanceResult, 0, len(profiles))
-	for _, p := range profiles {
-		result := BalanceResult{ProfileID: p.ID, ProfileType: string(p.Type)}
-		balances, err := client.Balances.List(ctx, p.ID, nil)
-		if err != nil {
-			result.Error = err
-		} else {
-			for _, b := range balances {
-				result.Balances = append(result.Balances, CurrencyBalance{
-					Currency: string(b.Currency),
-					Amount:   b.Amount.Value,
-				})
+	results := make([]BalanceResult, len(profiles))
+	done := make([]bool, len(profiles))
+	var g errgroup.Group
+	g.SetLimit(maxConcurrency)
+	for i, p := range profiles {
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
 			}
-		}
-		results = append(results, result)
+			result := BalanceResult{ProfileID: p.ID, ProfileType: string(p.Type)}
+			var balances []wise.Balance
+			var err error
+			if savings {
+				balances, err = client.Balances.ListAll(ctx, p.ID)
+			} else {
+				balances, err = client.Balances.List(ctx, p.ID, nil)
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				result.Error = err.Error()
+			} else {
+				for _, b := range balances {
+					result.Balances = append(result.Balances, CurrencyBalance{
+						Currency: string(b.Currency),
+						Amount:   b.Amount.Value,
+						Type:     string(b.Type),
+					})
+				}
+			}
+			results[i], done[i] = result, true
+			return nil
+		})
 	}
-	return results, nil
+	g.Wait()
+	return gather(ctx, results, done, func(r BalanceResult) string {
+		if r.Error == "" {
+			return ""
+		}
+		return fmt.Sprintf("profile %d: %s", r.ProfileID, r.Error)
+	})
+}
+
+// StatementOptions selects the statements fetched by GetStatementsRange.
+type StatementOptions struct {
+	Start time.Time // Default: 30 days before End
+	End   time.Time // Default: now
+	// IncludeEmpty also fetches the statements of balances that are empty
+	// now. Money may still have moved through them during the interval.
+	IncludeEmpty bool
 }
 
-// GetStatements fetches statements for all profiles.
+// GetStatements fetches the statements of the last days days for all
+// non-empty balances of all profiles. See GetStatementsRange.
 func GetStatements(ctx context.Context, client *wise.Client, days int) ([]StatementResult, error) {
 	if days <= 0 {
 		days = 30
 	}
+	end := time.Now().UTC()
+	return GetStatementsRange(ctx, client, StatementOptions{Start: end.AddDate(0, 0, -days), End: end})
+}
+
+// GetStatementsRange fetches statements for the balances of all profiles,
+// several at a time. Intervals longer than the API allows are fetched in
+// several requests. Results are in profile and balance order. A failure
+// for one profile or balance is reported in its result. If ctx is
+// cancelled, the statements fetched so far are returned with a
+// *PartialError.
+func GetStatementsRange(ctx context.Context, client *wise.Client, opts StatementOptions) ([]StatementResult, error) {
+	end := opts.End
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+	start := opts.Start
+	if start.IsZero() {
+		start = end.AddDate(0, 0, -30)
+	}
 
 	profiles, err := client.Profiles.List(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	end := time.Now().UTC()
-	start := end.AddDate(0, 0, -days)
-	startStr := start.Format(time.RFC3339)
-	endStr := end.Format(time.RFC3339)
+	// List the balances of each profile. A profile that is not listed
+	// because ctx was cancelled is left out, counting as one result not
+	// fetched.
+	balances := make([][]wise.Balance, len(profiles))
+	listErrs := make([]error, len(profiles))
+	listed := make([]bool, len(profiles))
+	var g errgroup.Group
+	g.SetLimit(maxConcurrency)
+	for i, p := range profiles {
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			balances[i], listErrs[i] = client.Balances.List(ctx, p.ID, nil)
+			listed[i] = listErrs[i] == nil || ctx.Err() == nil
+			return nil
+		})
+	}
+	g.Wait()
 
+	// Then fetch the statements, keeping a slot for each result.
 	var results []StatementResult
-	for _, p := range profiles {
-		balances, err := client.Balances.List(ctx, p.ID, nil)
-		if err != nil {
-			results = append(results, StatementResult{Error: fmt.Errorf("profile %d: %w", p.ID, err)})
+	var done []bool
+	type job struct {
+		index     int
+		profileID int64
+		balance   wise.Balance
+	}
+	var jobs []job
+	for i, p := range profiles {
+		if !listed[i] {
+			results = append(results, StatementResult{})
+			done = append(done, false)
 			continue
 		}
-
-		for _, b := range balances {
-			if b.Amount.Value == 0 {
+		if listErrs[i] != nil {
+			results = append(results, StatementResult{Error: fmt.Sprintf("profile %d: %v", p.ID, listErrs[i])})
+			done = append(done, true)
+			continue
+		}
+		for _, b := range balances[i] {
+			if b.Amount.Value == 0 && !opts.IncludeEmpty {
 				continue
 			}
-			result := StatementResult{Currency: string(b.Currency), BalanceID: b.ID}
-			statements, err := client.Balances.GetStatement(ctx, p.ID, b.ID, b.Currency, startStr, endStr)
+			jobs = append(jobs, job{index: len(results), profileID: p.ID, balance: b})
+			results = append(results, StatementResult{Currency: string(b.Currency), BalanceID: b.ID})
+			done = append(done, false)
+		}
+	}
+
+	for _, j := range jobs {
+		g.Go(func() error {
+			if ctx.Err() != nil {
+				return nil
+			}
+			result := &results[j.index]
+			statements, err := getStatement(ctx, client, j.profileID, j.balance, start, end)
 			if err != nil {
-				result.Error = err
-			} else {
-				for _, s := range statements {
-					result.Transactions = append(result.Transactions, Transaction{
-						Date:     s.Date.Format("2006-01-02"),
-						Type:     s.Type,
-						Amount:   s.Amount.Value,
-						Currency: string(s.Amount.Currency),
-					})
+				if ctx.Err() == nil {
+					result.Error = err.Error()
+					done[j.index] = true
 				}
+				return nil
 			}
-			results = append(results, result)
-		}
+			for _, s := range statements {
+				result.Transactions = append(result.Transactions, Transaction{
+					Date:     s.Date.Format("2006-01-02"),
+					Type:     s.Type,
+					Amount:   s.Amount.Value,
+					Currency: string(s.Amount.Currency),
+				})
+			}
+			done[j.index] = true
+			return nil
+		})
 	}
-	return results, nil
+	g.Wait()
+	return gather(ctx, results, done, func(r StatementResult) string {
+		if r.Error == "" || r.Currency == "" {
+			return r.Error
+		}
+		return r.Currency + " balance: " + r.Error
+	})
 }
 
 // GetQuote creates a quote for currency conversion.
 func GetQuote(ctx context.Context, client *wise.Client, from, to string, amount float64) QuoteResult {
+	return GetQuoteForPayIn(ctx, client, from, to, amount, "")
+}
+
+// GetQuoteForPayIn is GetQuote for paying in a given way, e.g. BALANCE or
+// BANK_TRANSFER: amounts, fee, delivery and Options are those of payIn
+// options only. An empty payIn allows all.
+func GetQuoteForPayIn(ctx context.Context, client *wise.Client, from, to string, amount float64, payIn string) QuoteResult {
 	result := QuoteResult{From: from, To: to, SourceAmount: amount}
 
 	profiles, err := client.Profiles.List(ctx)
 	if err != nil {
-		result.Error = err
+		result.Error = err.Error()
 		return result
 	}
 
 	if len(profiles) == 0 {
-		result.Error = fmt.Errorf("no profiles found")
+		result.Error = "no profiles found"
 		return result
 	}
 
@@ -231,18 +559,34 @@ func GetQuote(ctx context.Context, client *wise.Client, from, to string, amount
 
 	quote, err := client.Quotes.CreateV2(ctx, req)
 	if err != nil {
-		result.Error = err
+		result.Error = err.Error()
 		return result
 	}
 
 	result.TargetAmount = quote.TargetAmount
-	if result.TargetAmount == 0 && len(quote.PaymentOptions) > 0 {
-		result.TargetAmount = quote.PaymentOptions[0].TargetAmount
-	}
+	result.PayOut = quote.PayOut
 	result.Rate = quote.Rate
 	result.QuoteID = quote.ID
 	result.Expires = quote.RateExpirationTime.Format("2006-01-02 15:04:05")
+	result.Options = rankOptions(quote, payIn)
+	if len(result.Options) == 0 && payIn != "" {
+		result.Error = fmt.Sprintf("no %s payment option for %s to %s, available: %s",
+			strings.ToUpper(payIn), from, to, strings.Join(payIns(quote), ", "))
+		return result
+	}
 
+	if len(result.Options) > 0 {
+		cheapest := result.Options[0]
+		if cheapest.SourceAmount > 0 {
+			result.SourceAmount = cheapest.SourceAmount
+		}
+		result.TargetAmount = cheapest.TargetAmount
+		result.Fee = wise.Money{Value: cheapest.Fee, Currency: wise.Currency(from)}
+		result.FeePercentage = cheapest.FeePercent
+		result.PayIn = cheapest.PayIn
+		result.PayOut = cheapest.PayOut
+		result.EstimatedDelivery = cheapest.Delivery
+	}
 	return result
 }
 
@@ -271,33 +615,22 @@ func GetRateHistory(ctx context.Context, client *wise.Client, from, to string, d
 
 	rates, err := client.ExchangeRates.GetHistory(ctx, params)
 	if err != nil {
-		result.Error = err
+		result.Error = err.Error()
 		return result
 	}
 
 	if len(rates) == 0 {
-		result.Error = fmt.Errorf("no historical data found")
+		result.Error = "no historical data found"
 		return result
 	}
 
-	// Calculate stats
-	result.First = rates[0].Rate
-	result.Last = rates[len(rates)-1].Rate
-	result.Min = rates[0].Rate
-	result.Max = rates[0].Rate
-
 	for _, r := range rates {
 		result.DataPoints = append(result.DataPoints, HistoryPoint{
 			Time: r.Time.Format("2006-01-02 15:04"),
 			Rate: r.Rate,
 		})
-		if r.Rate < result.Min {
-			result.Min = r.Rate
-		}
-		if r.Rate > result.Max {
-			result.Max = r.Rate
-		}
 	}
+	result.analyze()
 
 	return result
 }
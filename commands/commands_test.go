@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+// inFlight counts concurrent requests and remembers the maximum.
+type inFlight struct {
+	now, max atomic.Int32
+}
+
+func (f *inFlight) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := f.now.Add(1)
+	defer f.now.Add(-1)
+	for {
+		m := f.max.Load()
+		if n <= m || f.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestGetStatements_Concurrent(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	currencies := []wise.Currency{wise.AUD, wise.CAD, wise.CHF, wise.CNY, wise.INR, wise.SGD, wise.JPY}
+	for _, c := range currencies {
+		srv.AddBalance(wisetest.DefaultProfileID, c, 10)
+	}
+	flight := &inFlight{}
+	client := srv.Client(wise.WithTransport(flight))
+
+	results, err := GetStatements(context.Background(), client, 7)
+	if err != nil {
+		t.Fatalf("GetStatements failed: %v", err)
+	}
+	// EUR, GBP and USD come first, then the balances added above in order.
+	if len(results) != 3+len(currencies) {
+		t.Fatalf("expected %d results, got %d", 3+len(currencies), len(results))
+	}
+	for i, c := range currencies {
+		if r := results[3+i]; r.Currency != string(c) || r.Error != "" {
+			t.Errorf("result %d: got %+v, want %s", 3+i, r, c)
+		}
+	}
+	if max := flight.max.Load(); max > maxConcurrency {
+		t.Errorf("%d concurrent requests, limit is %d", max, maxConcurrency)
+	}
+}
+
+func TestGetBalances_Cancelled(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := GetBalances(ctx, srv.Client()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestResultsMarshalJSON(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+
+	rate := GetRate(context.Background(), srv.Client(), "EUR", "XYZ")
+	data, err := json.Marshal(rate)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"from":"EUR"`) || !strings.Contains(string(data), `"error":"`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	balances, err := GetBalances(context.Background(), srv.Client())
+	if err != nil {
+		t.Fatalf("GetBalances failed: %v", err)
+	}
+	data, _ = json.Marshal(balances)
+	if !strings.Contains(string(data), `"profileId":1`) || strings.Contains(string(data), `"error"`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestGetRates_Pairs(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	srv.SetRate(wise.SGD, wise.INR, 62.5)
+	client := srv.Client()
+
+	if results, err := GetRates(context.Background(), client, nil); err != nil || len(results) != len(DefaultPairs()) {
+		t.Errorf("expected %d default rates, got %d", len(DefaultPairs()), len(results))
+	}
+
+	pairs, err := ParseCurrencyPairs("sgd/inr, EUR-USD")
+	if err != nil {
+		t.Fatalf("ParseCurrencyPairs failed: %v", err)
+	}
+	results, err := GetRates(context.Background(), client, pairs)
+	if err != nil || len(results) != 2 || results[0].Rate != 62.5 || results[1].Rate != 1.08 {
+		t.Errorf("unexpected rates: %+v", results)
+	}
+
+	pairs = PairsFrom("eur")
+	if len(pairs) != len(DefaultCurrencies)-1 || pairs[0] != (CurrencyPair{From: "EUR", To: "USD"}) {
+		t.Errorf("unexpected pairs: %v", pairs)
+	}
+}
+
+func TestGetStatementsRange(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+	srv.AddBalance(wisetest.DefaultProfileID, wise.CHF, 0)
+
+	// Two conversions further apart than one statement request may cover.
+	now := time.Now().UTC()
+	for _, at := range []time.Time{now.AddDate(-2, 0, 0), now.Add(-time.Hour)} {
+		srv.Now = func() time.Time { return at }
+		if result := ConvertBalance(ctx, client, "EUR", "USD", 10); result.Error != "" {
+			t.Fatalf("ConvertBalance failed: %s", result.Error)
+		}
+	}
+	srv.Now = time.Now
+
+	results, err := GetStatementsRange(ctx, client, StatementOptions{Start: now.AddDate(-3, 0, 0), End: now})
+	if err != nil {
+		t.Fatalf("GetStatementsRange failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected the 3 non-empty balances, got %+v", results)
+	}
+	if eur := results[0]; eur.Currency != "EUR" || eur.Error != "" || len(eur.Transactions) != 2 {
+		t.Errorf("expected 2 EUR transactions, got %+v", eur)
+	}
+
+	results, err = GetStatementsRange(ctx, client, StatementOptions{Start: now.AddDate(0, 0, -1), IncludeEmpty: true})
+	if err != nil {
+		t.Fatalf("GetStatementsRange failed: %v", err)
+	}
+	if len(results) != 4 || results[3].Currency != "CHF" || results[3].Error != "" {
+		t.Errorf("expected the empty CHF balance to be included, got %+v", results)
+	}
+}
+
+// cancelAfter cancels a context once n responses have been received. It
+// reads each body in full, so the response that triggers the cancellation
+// is still delivered.
+type cancelAfter struct {
+	n      atomic.Int32
+	cancel context.CancelFunc
+}
+
+func (c *cancelAfter) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if c.n.Add(-1) == 0 {
+		c.cancel()
+	}
+	return resp, nil
+}
+
+func TestGetRates_Partial(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	transport := &cancelAfter{cancel: cancel}
+	transport.n.Store(2)
+	client := srv.Client(wise.WithTransport(transport))
+
+	results, err := GetRates(ctx, client, nil)
+	var partial *PartialError
+	if !errors.As(err, &partial) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a *PartialError wrapping context.Canceled, got %v", err)
+	}
+	if len(results) != 2 || partial.Done != 2 || partial.Total != len(DefaultPairs()) {
+		t.Errorf("expected 2 of %d rates, got %+v (%v)", len(DefaultPairs()), results, err)
+	}
+	for _, r := range results {
+		if r.Error != "" || r.Rate == 0 {
+			t.Errorf("unexpected result: %+v", r)
+		}
+	}
+}
+
+func TestGetStatements_Partial(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	for _, c := range []wise.Currency{wise.AUD, wise.CAD, wise.CHF, wise.CNY, wise.INR, wise.SGD, wise.JPY} {
+		srv.AddBalance(wisetest.DefaultProfileID, c, 10)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Profiles, balances and one statement.
+	transport := &cancelAfter{cancel: cancel}
+	transport.n.Store(3)
+	client := srv.Client(wise.WithTransport(transport))
+
+	results, err := GetStatements(ctx, client, 7)
+	var partial *PartialError
+	if !errors.As(err, &partial) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a *PartialError wrapping context.Canceled, got %v", err)
+	}
+	if len(results) == 0 || len(results) != partial.Done || partial.Done >= partial.Total || partial.Total != 10 {
+		t.Errorf("unexpected partial results: %d results, %v", len(results), err)
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			t.Errorf("unexpected failed result: %+v", r)
+		}
+	}
+
+	// Cancelled before the balances were listed, the profile still counts.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	transport = &cancelAfter{cancel: cancel}
+	transport.n.Store(1)
+	client = srv.Client(wise.WithTransport(transport))
+	results, err = GetStatements(ctx, client, 7)
+	if !errors.As(err, &partial) || len(results) != 0 || partial.Total != 1 {
+		t.Errorf("expected 0 of 1 results, got %d results, %v", len(results), err)
+	}
+}
+
+func TestGather_DoneBeforeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := gather(ctx, []string{"a", "b"}, []bool{true, true}, func(string) string { return "" })
+	if err != nil || len(results) != 2 {
+		t.Errorf("expected every result and no error, got %v, %v", results, err)
+	}
+}
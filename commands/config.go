@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// Config is the configuration file of the CLI, holding named accounts so
+// one machine can use several, e.g. a personal and a sandbox work account:
+//
+//	default = "personal"
+//
+//	[accounts.personal]
+//	token = "..."
+//	base_currency = "EUR"
+//	currencies = ["USD", "GBP"]
+//
+//	[accounts.work]
+//	client_id = "..."
+//	client_secret = "..."
+//	token_store = "/home/me/.config/wise/work-token.json"
+//	sandbox = true
+type Config struct {
+	Default  string             `toml:"default"` // Account used when none is named
+	Accounts map[string]Account `toml:"accounts"`
+}
+
+// Account holds the credentials and preferences of a named account. It
+// authenticates with Token, or with ClientID and ClientSecret and the OAuth
+// token saved in TokenStore.
+type Account struct {
+	Name         string   `toml:"-"` // Name in the config file, empty for the environment
+	Token        string   `toml:"token"`
+	ClientID     string   `toml:"client_id"`
+	ClientSecret string   `toml:"client_secret"`
+	TokenStore   string   `toml:"token_store"` // As WISE_TOKEN_STORE: "keyring" or a file path
+	Sandbox      bool     `toml:"sandbox"`
+	Base         string   `toml:"base_currency"` // Default base currency for rates
+	Currencies   []string `toml:"currencies"`    // Default target currencies for rates
+}
+
+// DefaultConfigFile returns the path of the configuration file:
+// WISE_CONFIG if set, otherwise wise/config.toml in $XDG_CONFIG_HOME,
+// which defaults to ~/.config.
+func DefaultConfigFile() (string, error) {
+	if path := os.Getenv("WISE_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "wise", "config.toml"), nil
+}
+
+// LoadConfig reads the configuration file at path. A missing file is an
+// empty configuration. Unknown keys are an error, so a misspelt setting is
+// not silently ignored. As the file holds tokens and secrets, it is refused
+// if other users can read it.
+func LoadConfig(path string) (*Config, error) {
+	if err := checkPrivate(path); err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	meta, err := toml.DecodeFile(path, &cfg)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("reading %s: unknown key %s", path, undecoded[0])
+	}
+	return &cfg, nil
+}
+
+// checkPrivate returns an error if the file at path is readable by its
+// group or other users. Windows has no such permission bits.
+func checkPrivate(path string) error {
+	info, err := os.Stat(path)
+	if err != nil || runtime.GOOS == "windows" {
+		return nil
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		return fmt.Errorf("%s is accessible by other users (mode %#o): run chmod 600 %s", path, perm, path)
+	}
+	return nil
+}
+
+// Account returns the named account, or the default account if name is
+// empty. Without a name or default, it returns the zero Account.
+func (c *Config) Account(name string) (Account, error) {
+	if name == "" {
+		name = c.Default
+		if name == "" {
+			return Account{}, nil
+		}
+	}
+	acct, ok := c.Accounts[name]
+	if !ok {
+		names := make([]string, 0, len(c.Accounts))
+		for n := range c.Accounts {
+			names = append(names, n)
+		}
+		slices.Sort(names)
+		if len(names) == 0 {
+			return Account{}, fmt.Errorf("unknown account %q: no accounts configured", name)
+		}
+		return Account{}, fmt.Errorf("unknown account %q: want one of %s", name, strings.Join(names, ", "))
+	}
+	acct.Name = name
+	return acct, nil
+}
+
+// LoadAccount returns the named account from the configuration file. If
+// name is empty, the default account is used, overridden by the
+// WISE_API_TOKEN, WISE_CLIENT_ID and WISE_CLIENT_SECRET environment
+// variables if they are set, so setups that only use the environment keep
+// working, with the token store of TokenStoreFromEnv. A named account
+// ignores the environment.
+func LoadAccount(name string) (Account, error) {
+	path, err := DefaultConfigFile()
+	if err != nil {
+		return Account{}, err
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return Account{}, err
+	}
+	acct, err := cfg.Account(name)
+	if err != nil || name != "" {
+		return acct, err
+	}
+
+	token := os.Getenv("WISE_API_TOKEN")
+	clientID, clientSecret := os.Getenv("WISE_CLIENT_ID"), os.Getenv("WISE_CLIENT_SECRET")
+	if token != "" {
+		acct.Name, acct.Token, acct.ClientID, acct.ClientSecret = "", token, "", ""
+	} else if clientID != "" && clientSecret != "" {
+		acct.Name, acct.Token, acct.ClientID, acct.ClientSecret = "", "", clientID, clientSecret
+	}
+	return acct, nil
+}
+
+// HasCredentials reports whether the account has a token or OAuth client
+// credentials.
+func (a Account) HasCredentials() bool {
+	return a.Token != "" || (a.ClientID != "" && a.ClientSecret != "")
+}
+
+// OpenTokenStore returns the store of the account's OAuth token:
+// a.TokenStore if set, otherwise WISE_TOKEN_STORE or wise.DefaultTokenFile
+// as in TokenStoreFromEnv. Unless a.TokenStore is a file path, the token of
+// a named account is kept apart from the others: in token-<name>.json next
+// to the default file, or in a keychain entry of its own.
+func (a Account) OpenTokenStore() (wise.TokenStore, error) {
+	if a.TokenStore != "" && a.TokenStore != "keyring" {
+		return tokenStore(a.TokenStore, "")
+	}
+	if a.Name == "" {
+		if a.TokenStore == "keyring" {
+			return tokenStore(a.TokenStore, "")
+		}
+		return TokenStoreFromEnv()
+	}
+	if strings.ContainsAny(a.Name, `/\`) {
+		return nil, fmt.Errorf("account %q: name cannot contain a path separator", a.Name)
+	}
+
+	location := a.TokenStore
+	if location == "" {
+		location = os.Getenv("WISE_TOKEN_STORE")
+	}
+	if location == "" {
+		path, err := wise.DefaultTokenFile()
+		if err != nil {
+			return nil, err
+		}
+		location = path
+	}
+	if location != "keyring" {
+		ext := filepath.Ext(location)
+		location = strings.TrimSuffix(location, ext) + "-" + a.Name + ext
+	}
+	return tokenStore(location, a.Name)
+}
+
+// RatePairs returns the pairs to fetch rates for when none are given on the
+// command line: from base, or the account's base currency, to its
+// currencies. It returns nil, meaning DefaultPairs, if there is no base.
+func (a Account) RatePairs(base string) []CurrencyPair {
+	if base == "" {
+		base = a.Base
+	}
+	if base == "" {
+		return nil
+	}
+	return PairsFrom(base, a.Currencies...)
+}
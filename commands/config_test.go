@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+const configTOML = `
+default = "personal"
+
+[accounts.personal]
+token = "personal-token"
+base_currency = "eur"
+currencies = ["USD", "GBP"]
+
+[accounts.work]
+client_id = "id"
+client_secret = "secret"
+sandbox = true
+`
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, configTOML))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	acct, err := cfg.Account("")
+	if err != nil || acct.Token != "personal-token" {
+		t.Fatalf("default account = %+v, %v", acct, err)
+	}
+	pairs := acct.RatePairs("")
+	if len(pairs) != 2 || pairs[0].String() != "EUR/USD" || pairs[1].String() != "EUR/GBP" {
+		t.Errorf("RatePairs = %v", pairs)
+	}
+	if pairs := acct.RatePairs("SGD"); len(pairs) != 2 || pairs[0].From != "SGD" {
+		t.Errorf("RatePairs(SGD) = %v", pairs)
+	}
+
+	work, err := cfg.Account("work")
+	if err != nil || !work.Sandbox || !work.HasCredentials() || work.Token != "" {
+		t.Errorf("work account = %+v, %v", work, err)
+	}
+	if _, err := cfg.Account("home"); err == nil || !strings.Contains(err.Error(), "personal, work") {
+		t.Errorf("expected an error listing the accounts, got %v", err)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	cfg, err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+	if err != nil || len(cfg.Accounts) != 0 {
+		t.Errorf("missing file: got %+v, %v", cfg, err)
+	}
+	if _, err := LoadConfig(writeConfig(t, "[accounts.x]\ntokn = \"t\"\n")); err == nil || !strings.Contains(err.Error(), "tokn") {
+		t.Errorf("expected an unknown key error, got %v", err)
+	}
+}
+
+func TestLoadAccount_Env(t *testing.T) {
+	t.Setenv("WISE_CONFIG", writeConfig(t, configTOML))
+	t.Setenv("WISE_CLIENT_ID", "")
+	t.Setenv("WISE_CLIENT_SECRET", "")
+
+	t.Setenv("WISE_API_TOKEN", "env-token")
+	acct, err := LoadAccount("")
+	if err != nil || acct.Token != "env-token" || acct.Base != "eur" {
+		t.Errorf("default account = %+v, %v; want the env token with config preferences", acct, err)
+	}
+	acct, err = LoadAccount("work")
+	if err != nil || acct.Token != "" || acct.ClientID != "id" {
+		t.Errorf("named account = %+v, %v; want the environment ignored", acct, err)
+	}
+}
+
+func TestLoadConfig_Permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no permission bits on Windows")
+	}
+	path := writeConfig(t, configTOML)
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "chmod 600") {
+		t.Errorf("expected a world-readable config to be refused, got %v", err)
+	}
+}
+
+func TestAccount_OpenTokenStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("WISE_TOKEN_STORE", filepath.Join(dir, "token.json"))
+	t.Setenv("WISE_TOKEN_KEY", "")
+	cfg, err := LoadConfig(writeConfig(t, configTOML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, name := range []string{"", "work"} {
+		acct, err := cfg.Account(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		store, err := acct.OpenTokenStore()
+		if err != nil {
+			t.Fatalf("OpenTokenStore(%q) failed: %v", name, err)
+		}
+		if err := store.Save(ctx, &wise.Token{AccessToken: "token-" + acct.Name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"token-personal.json", "token-work.json"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("expected a token file per account: %v", err)
+		}
+	}
+
+	if store, err := (Account{}).OpenTokenStore(); err != nil {
+		t.Fatal(err)
+	} else if _, err := store.Load(ctx); !errors.Is(err, wise.ErrNoStoredToken) {
+		t.Errorf("the environment's store should be apart from the accounts', got %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package commands
+
+import "math"
+
+// Trends reported in HistoryResult.Trend.
+const (
+	TrendUp   = "up"
+	TrendDown = "down"
+	TrendFlat = "flat"
+)
+
+// flatTrend is the fitted change, in percent over the whole period, below
+// which a trend is flat.
+const flatTrend = 0.1
+
+// analyze sets the statistics of r from its data points.
+func (r *HistoryResult) analyze() {
+	points := r.DataPoints
+	if len(points) == 0 {
+		return
+	}
+	r.First = points[0].Rate
+	r.Last = points[len(points)-1].Rate
+	r.Min, r.MinTime = points[0].Rate, points[0].Time
+	r.Max, r.MaxTime = points[0].Rate, points[0].Time
+
+	var sum float64
+	for _, p := range points {
+		sum += p.Rate
+		if p.Rate < r.Min {
+			r.Min, r.MinTime = p.Rate, p.Time
+		}
+		if p.Rate > r.Max {
+			r.Max, r.MaxTime = p.Rate, p.Time
+		}
+	}
+	if r.First != 0 {
+		r.Change = (r.Last - r.First) / r.First * 100
+	}
+
+	n := float64(len(points))
+	mean := sum / n
+	var variance, sxy, sxx float64
+	for i, p := range points {
+		variance += (p.Rate - mean) * (p.Rate - mean)
+		// Least-squares fit of the rate against the point index.
+		x := float64(i) - (n-1)/2
+		sxy += x * (p.Rate - mean)
+		sxx += x * x
+	}
+	r.StdDev = math.Sqrt(variance / n)
+
+	r.Trend = TrendFlat
+	if sxx > 0 && mean != 0 {
+		fitted := sxy / sxx * (n - 1) / mean * 100
+		switch {
+		case fitted >= flatTrend:
+			r.Trend = TrendUp
+		case fitted <= -flatTrend:
+			r.Trend = TrendDown
+		}
+	}
+}
+
+// MovingAverages sets the simple and exponential moving averages over
+// window points on each data point from the window-th on. The EMA starts
+// from the first SMA.
+func (r *HistoryResult) MovingAverages(window int) {
+	if window <= 0 || window > len(r.DataPoints) {
+		return
+	}
+	alpha := 2 / float64(window+1)
+	var sum float64
+	for i := range r.DataPoints {
+		p := &r.DataPoints[i]
+		sum += p.Rate
+		if i >= window {
+			sum -= r.DataPoints[i-window].Rate
+		}
+		if i < window-1 {
+			continue
+		}
+		p.SMA = sum / float64(window)
+		if i == window-1 {
+			p.EMA = p.SMA
+		} else {
+			p.EMA = alpha*p.Rate + (1-alpha)*r.DataPoints[i-1].EMA
+		}
+	}
+}
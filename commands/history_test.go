@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"math"
+	"testing"
+)
+
+func historyOf(rates ...float64) HistoryResult {
+	var r HistoryResult
+	for i, rate := range rates {
+		r.DataPoints = append(r.DataPoints, HistoryPoint{Time: string(rune('a' + i)), Rate: rate})
+	}
+	r.analyze()
+	return r
+}
+
+func TestHistoryResult_Analyze(t *testing.T) {
+	r := historyOf(1.0, 1.2, 0.9, 1.1)
+	if r.Min != 0.9 || r.MinTime != "c" || r.Max != 1.2 || r.MaxTime != "b" {
+		t.Errorf("unexpected min/max: %+v", r)
+	}
+	if math.Abs(r.Change-10) > 1e-9 {
+		t.Errorf("Change = %v, want 10", r.Change)
+	}
+	if math.Abs(r.StdDev-0.111803) > 1e-6 {
+		t.Errorf("StdDev = %v, want 0.111803", r.StdDev)
+	}
+
+	for _, tt := range []struct {
+		rates []float64
+		want  string
+	}{
+		{[]float64{1.00, 1.01, 1.02, 1.03}, TrendUp},
+		{[]float64{1.03, 1.02, 1.01, 1.00}, TrendDown},
+		{[]float64{1.00, 1.0001, 0.9999, 1.00}, TrendFlat},
+	} {
+		if got := historyOf(tt.rates...).Trend; got != tt.want {
+			t.Errorf("trend of %v = %s, want %s", tt.rates, got, tt.want)
+		}
+	}
+}
+
+func TestHistoryResult_MovingAverages(t *testing.T) {
+	r := historyOf(1, 2, 3, 4, 5)
+	r.MovingAverages(3)
+
+	wantSMA := []float64{0, 0, 2, 3, 4}
+	wantEMA := []float64{0, 0, 2, 3, 4}
+	for i, p := range r.DataPoints {
+		if math.Abs(p.SMA-wantSMA[i]) > 1e-9 || math.Abs(p.EMA-wantEMA[i]) > 1e-9 {
+			t.Errorf("point %d: SMA %v, EMA %v, want %v, %v", i, p.SMA, p.EMA, wantSMA[i], wantEMA[i])
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// FeeAnalysisResult compares the ways of paying for a transfer.
+type FeeAnalysisResult struct {
+	From         string         `json:"from" csv:"from"`
+	To           string         `json:"to" csv:"to"`
+	SourceAmount float64        `json:"sourceAmount" csv:"source_amount"`
+	Rate         float64        `json:"rate" csv:"rate"` // Mid-market rate of the quote
+	Options      []OptionResult `json:"options" csv:"-"`
+	Error        string         `json:"error,omitempty" csv:"error"`
+}
+
+// OptionResult is one way of paying for a transfer, e.g. from a balance,
+// by bank transfer or by card.
+type OptionResult struct {
+	Rank          int     `json:"rank" csv:"rank"` // 1 is the cheapest
+	PayIn         string  `json:"payIn" csv:"pay_in"`
+	PayOut        string  `json:"payOut" csv:"pay_out"`
+	Fee           float64 `json:"fee" csv:"fee"`                // In the source currency
+	FeePercent    float64 `json:"feePercent" csv:"fee_percent"` // Fee as a percentage of the source amount
+	SourceAmount  float64 `json:"sourceAmount" csv:"source_amount"`
+	TargetAmount  float64 `json:"targetAmount" csv:"target_amount"`
+	EffectiveRate float64 `json:"effectiveRate" csv:"effective_rate"` // Target amount per unit of source amount, after fees
+	Delivery      string  `json:"delivery" csv:"delivery"`            // Estimated delivery, e.g. "2006-01-02 15:04"
+	DeliveryText  string  `json:"deliveryText" csv:"delivery_text"`   // Wise's description, e.g. "by Monday"
+	Fastest       bool    `json:"fastest" csv:"fastest"`
+}
+
+// FeeAnalysis quotes sending amount from one currency to another and ranks
+// the enabled payment options by fee, then by amount received, then by
+// delivery time.
+func FeeAnalysis(ctx context.Context, client *wise.Client, from, to string, amount float64) FeeAnalysisResult {
+	result := FeeAnalysisResult{From: from, To: to, SourceAmount: amount}
+
+	profiles, err := client.Profiles.List(ctx)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if len(profiles) == 0 {
+		result.Error = "no profiles found"
+		return result
+	}
+
+	quote, err := client.Quotes.CreateV2(ctx, &wise.CreateQuoteRequest{
+		SourceCurrency: wise.Currency(from),
+		TargetCurrency: wise.Currency(to),
+		SourceAmount:   &amount,
+		Profile:        profiles[0].ID,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Rate = quote.Rate
+	result.Options = rankOptions(quote, "")
+	if len(result.Options) == 0 {
+		result.Error = fmt.Sprintf("no payment options available for %s to %s", from, to)
+	}
+	return result
+}
+
+// rankOptions ranks the enabled payment options of quote by fee, then by
+// amount received, then by delivery time. With payIn set, only options
+// paid in that way are included.
+func rankOptions(quote *wise.Quote, payIn string) []OptionResult {
+	options := make([]wise.PaymentOption, 0, len(quote.PaymentOptions))
+	for _, opt := range quote.PaymentOptions {
+		if !opt.Disabled && (payIn == "" || strings.EqualFold(opt.PayIn, payIn)) {
+			options = append(options, opt)
+		}
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if a.Fee.Total != b.Fee.Total {
+			return a.Fee.Total < b.Fee.Total
+		}
+		if a.TargetAmount != b.TargetAmount {
+			return a.TargetAmount > b.TargetAmount
+		}
+		return a.EstimatedDelivery.Before(b.EstimatedDelivery.Time)
+	})
+
+	fastest := quote.FastestOption()
+	results := make([]OptionResult, 0, len(options))
+	for i, opt := range options {
+		o := OptionResult{
+			Rank:         i + 1,
+			PayIn:        opt.PayIn,
+			PayOut:       opt.PayOut,
+			Fee:          opt.Fee.Total,
+			SourceAmount: opt.SourceAmount,
+			TargetAmount: opt.TargetAmount,
+			DeliveryText: opt.FormattedEstimatedDelivery,
+		}
+		if opt.SourceAmount > 0 {
+			o.FeePercent = opt.Fee.Total / opt.SourceAmount * 100
+			o.EffectiveRate = opt.TargetAmount / opt.SourceAmount
+		}
+		if !opt.EstimatedDelivery.IsZero() {
+			o.Delivery = opt.EstimatedDelivery.Format("2006-01-02 15:04")
+			o.Fastest = fastest != nil && opt.PayIn == fastest.PayIn && opt.PayOut == fastest.PayOut
+		}
+		results = append(results, o)
+	}
+	return results
+}
+
+// payIns lists the pay-in methods of the enabled payment options of quote.
+func payIns(quote *wise.Quote) []string {
+	var methods []string
+	for _, opt := range quote.PaymentOptions {
+		if !opt.Disabled && !slices.Contains(methods, opt.PayIn) {
+			methods = append(methods, opt.PayIn)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestFeeAnalysis(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+
+	result := FeeAnalysis(context.Background(), srv.Client(), "EUR", "USD", 100)
+	if result.Error != "" {
+		t.Fatalf("FeeAnalysis failed: %v", result.Error)
+	}
+	if len(result.Options) != 3 {
+		t.Fatalf("expected 3 options, got %+v", result.Options)
+	}
+
+	// BALANCE and BANK_TRANSFER cost the same; BALANCE arrives sooner.
+	want := []struct {
+		payIn   string
+		fee     float64
+		fastest bool
+	}{
+		{"BALANCE", 0.5, false},
+		{"BANK_TRANSFER", 0.5, false},
+		{"DEBIT", 1.5, true},
+	}
+	for i, w := range want {
+		o := result.Options[i]
+		if o.Rank != i+1 || o.PayIn != w.payIn || o.Fee != w.fee || o.Fastest != w.fastest {
+			t.Errorf("option %d: got %+v, want %+v", i, o, w)
+		}
+	}
+	if o := result.Options[0]; o.FeePercent != 0.5 || o.EffectiveRate != 1.0746 {
+		t.Errorf("unexpected fee percent or effective rate: %+v", o)
+	}
+}
+
+func TestGetQuote(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+
+	result := GetQuote(context.Background(), srv.Client(), "EUR", "USD", 100)
+	if result.Error != "" {
+		t.Fatalf("GetQuote failed: %v", result.Error)
+	}
+	if result.Fee != (wise.Money{Value: 0.5, Currency: wise.EUR}) || result.FeePercentage != 0.5 {
+		t.Errorf("unexpected fee: %+v, %v%%", result.Fee, result.FeePercentage)
+	}
+	if result.PayIn != "BALANCE" || result.TargetAmount != 107.46 || result.EstimatedDelivery == "" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestGetQuoteForPayIn(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	all := GetQuote(ctx, srv.Client(), "EUR", "USD", 100)
+	if len(all.Options) != 3 || all.Options[0].PayIn != "BALANCE" || all.Options[2].PayIn != "DEBIT" {
+		t.Fatalf("expected 3 options, cheapest first, got %+v", all.Options)
+	}
+
+	card := GetQuoteForPayIn(ctx, srv.Client(), "EUR", "USD", 100, "debit")
+	if card.Error != "" {
+		t.Fatalf("GetQuoteForPayIn failed: %v", card.Error)
+	}
+	if len(card.Options) != 1 || card.PayIn != "DEBIT" || card.Fee.Value != 1.5 || card.TargetAmount >= all.TargetAmount {
+		t.Errorf("unexpected card quote: %+v", card)
+	}
+
+	if r := GetQuoteForPayIn(ctx, srv.Client(), "EUR", "USD", 100, "SWIFT"); !strings.Contains(r.Error, "BALANCE, BANK_TRANSFER, DEBIT") {
+		t.Errorf("expected an error listing the pay-in methods, got %q", r.Error)
+	}
+}
@@ -0,0 +1,217 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// RecipientResult holds a recipient.
+type RecipientResult struct {
+	ID        int64  `json:"id" csv:"id"`
+	ProfileID int64  `json:"profileId" csv:"profile_id"`
+	Name      string `json:"name" csv:"name"`
+	Nickname  string `json:"nickname,omitempty" csv:"nickname"`
+	Currency  string `json:"currency" csv:"currency"`
+	Type      string `json:"type" csv:"type"`
+	Country   string `json:"country,omitempty" csv:"country"`
+	Account   string `json:"account" csv:"account"` // Main account identifier, e.g. the IBAN or account number
+	Active    bool   `json:"active" csv:"active"`
+	// Details are the bank details as Wise returns them.
+	Details map[string]interface{} `json:"details,omitempty" csv:"-"`
+}
+
+// GetRecipients fetches the active recipients of a profile (all profiles
+// if profileID is 0), optionally only those in currency.
+func GetRecipients(ctx context.Context, client *wise.Client, profileID int64, currency string) ([]RecipientResult, error) {
+	recipients, err := client.Recipients.ListAll(ctx, &wise.ListRecipientsParams{
+		ProfileID: profileID,
+		Currency:  wise.Currency(currency),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RecipientResult, 0, len(recipients))
+	for _, r := range recipients {
+		if r.Active {
+			results = append(results, recipientResult(&r))
+		}
+	}
+	return results, nil
+}
+
+// GetRecipient fetches a recipient, including inactive ones.
+func GetRecipient(ctx context.Context, client *wise.Client, recipientID int64) (RecipientResult, error) {
+	recipient, err := client.Recipients.Get(ctx, recipientID)
+	if err != nil {
+		return RecipientResult{}, err
+	}
+	return recipientResult(recipient), nil
+}
+
+// CreateRecipient validates req against the account requirements for its
+// currency and creates the recipient. If req.Type is empty and the currency
+// has a single account type, that type is used. req.Profile defaults to
+// the first profile.
+func CreateRecipient(ctx context.Context, client *wise.Client, req wise.CreateRecipientRequest) (RecipientResult, error) {
+	if req.Profile == 0 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			return RecipientResult{}, err
+		}
+		if len(profiles) == 0 {
+			return RecipientResult{}, fmt.Errorf("no profiles found")
+		}
+		req.Profile = profiles[0].ID
+	}
+
+	recipient, err := createRecipient(ctx, client, &req)
+	if err != nil {
+		return RecipientResult{}, err
+	}
+	return recipientResult(recipient), nil
+}
+
+// createRecipient validates req against the account requirements and
+// creates the recipient.
+func createRecipient(ctx context.Context, client *wise.Client, req *wise.CreateRecipientRequest) (*wise.Recipient, error) {
+	if req.AccountHolderName == "" || req.Currency == "" {
+		return nil, errors.New("account holder name and currency are required")
+	}
+	requirements, err := client.Recipients.RefreshRequirements(ctx, "", req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching account requirements: %w", err)
+	}
+	if err := validateRecipient(requirements, req); err != nil {
+		return nil, err
+	}
+	return client.Recipients.Create(ctx, req)
+}
+
+// DeleteRecipient deactivates a recipient. Past transfers keep referring to it.
+func DeleteRecipient(ctx context.Context, client *wise.Client, recipientID int64) error {
+	return client.Recipients.Delete(ctx, recipientID)
+}
+
+func recipientResult(r *wise.Recipient) RecipientResult {
+	account := ""
+	for _, key := range []string{"IBAN", "accountNumber", "email", "clabe", "cardNumber"} {
+		if v := detail(r.Details, key); v != "" {
+			account = v
+			break
+		}
+	}
+	if code := detail(r.Details, "sortCode"); code != "" && account != "" {
+		account = code + " " + account
+	}
+	return RecipientResult{
+		ID:        r.ID,
+		ProfileID: r.Profile,
+		Name:      r.AccountHolderName,
+		Nickname:  r.Nickname,
+		Currency:  string(r.Currency),
+		Type:      string(r.Type),
+		Country:   r.Country,
+		Account:   account,
+		Active:    r.Active,
+		Details:   r.Details,
+	}
+}
+
+// validateRecipient checks req.Details against the requirements of the
+// account type req.Type, and returns an error listing every problem.
+func validateRecipient(requirements []wise.RecipientRequirements, req *wise.CreateRecipientRequest) error {
+	if len(requirements) == 0 {
+		return nil
+	}
+	if req.Type == "" && len(requirements) == 1 {
+		req.Type = wise.RecipientType(requirements[0].Type)
+	}
+
+	var types []string
+	for _, r := range requirements {
+		if r.Type != string(req.Type) {
+			types = append(types, r.Type)
+			continue
+		}
+
+		var problems []string
+		for _, field := range r.Fields {
+			for _, g := range field.Group {
+				value := detail(req.Details, g.Key)
+				if g.Key == "accountHolderName" {
+					value = req.AccountHolderName
+				}
+				if problem := checkField(g, value); problem != "" {
+					problems = append(problems, problem)
+				}
+			}
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("invalid %s recipient: %s", r.Type, strings.Join(problems, "; "))
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported account type %q for %s, use one of: %s", req.Type, req.Currency, strings.Join(types, ", "))
+}
+
+// checkField returns a description of what is wrong with value, or "".
+func checkField(g wise.RecipientFieldGroup, value string) string {
+	if value == "" {
+		if g.Required {
+			return g.Key + " is required"
+		}
+		return ""
+	}
+	if g.MinLength > 0 && len(value) < g.MinLength {
+		return fmt.Sprintf("%s must be at least %d characters", g.Key, g.MinLength)
+	}
+	if g.MaxLength > 0 && len(value) > g.MaxLength {
+		return fmt.Sprintf("%s must be at most %d characters", g.Key, g.MaxLength)
+	}
+	if g.ValidationRegexp != "" {
+		if re, err := regexp.Compile(g.ValidationRegexp); err == nil && !re.MatchString(value) {
+			if g.Example != "" {
+				return fmt.Sprintf("%s is invalid (e.g. %s)", g.Key, g.Example)
+			}
+			return g.Key + " is invalid"
+		}
+	}
+	if len(g.ValuesAllowed) > 0 {
+		keys := make([]string, 0, len(g.ValuesAllowed))
+		for _, v := range g.ValuesAllowed {
+			keys = append(keys, v.Key)
+		}
+		if !slices.Contains(keys, value) {
+			return fmt.Sprintf("%s must be one of %s", g.Key, strings.Join(keys, ", "))
+		}
+	}
+	return ""
+}
+
+// detail returns the string value of a recipient detail. Keys of nested
+// details are joined with dots, e.g. "address.city".
+func detail(details map[string]interface{}, key string) string {
+	var v interface{} = details
+	for _, part := range strings.Split(key, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		v = m[part]
+	}
+	switch v := v.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestRecipients(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	created, err := CreateRecipient(ctx, client, wise.CreateRecipientRequest{
+		AccountHolderName: "Jane Doe",
+		Currency:          wise.GBP,
+		Details:           map[string]interface{}{"sortCode": "231470", "accountNumber": "28821822"},
+	})
+	if err != nil {
+		t.Fatalf("CreateRecipient failed: %v", err)
+	}
+	if created.Type != "sort_code" || created.Account != "231470 28821822" || created.ProfileID != wisetest.DefaultProfileID {
+		t.Errorf("unexpected recipient: %+v", created)
+	}
+
+	recipients, err := GetRecipients(ctx, client, 0, "GBP")
+	if err != nil {
+		t.Fatalf("GetRecipients failed: %v", err)
+	}
+	if len(recipients) != 1 || recipients[0].ID != created.ID {
+		t.Errorf("unexpected recipients: %+v", recipients)
+	}
+
+	got, err := GetRecipient(ctx, client, created.ID)
+	if err != nil {
+		t.Fatalf("GetRecipient failed: %v", err)
+	}
+	if got.Name != "Jane Doe" || detail(got.Details, "sortCode") != "231470" {
+		t.Errorf("unexpected recipient: %+v", got)
+	}
+
+	if err := DeleteRecipient(ctx, client, created.ID); err != nil {
+		t.Fatalf("DeleteRecipient failed: %v", err)
+	}
+	if recipients, _ := GetRecipients(ctx, client, 0, ""); len(recipients) != 0 {
+		t.Errorf("expected no recipients after delete, got %+v", recipients)
+	}
+}
+
+func TestCreateRecipient_Validation(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		req  wise.CreateRecipientRequest
+		want []string
+	}{
+		{
+			name: "missing and invalid fields",
+			req: wise.CreateRecipientRequest{
+				AccountHolderName: "John Smith",
+				Currency:          wise.USD,
+				Details:           map[string]interface{}{"abartn": "12", "accountType": "BROKERAGE"},
+			},
+			want: []string{"abartn is invalid (e.g. 026009593)", "accountNumber is required", "accountType must be one of CHECKING, SAVINGS"},
+		},
+		{
+			name: "unsupported type",
+			req:  wise.CreateRecipientRequest{AccountHolderName: "Jane Doe", Currency: wise.EUR, Type: "sort_code"},
+			want: []string{`unsupported account type "sort_code" for EUR, use one of: iban`},
+		},
+		{
+			name: "missing name",
+			req:  wise.CreateRecipientRequest{Currency: wise.EUR},
+			want: []string{"account holder name and currency are required"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CreateRecipient(context.Background(), srv.Client(), tt.req)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+	if len(srv.Recipients()) != 0 {
+		t.Error("invalid recipient was created")
+	}
+}
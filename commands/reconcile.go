@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// ReconcileOptions configures Reconcile.
+type ReconcileOptions struct {
+	DateTolerance   time.Duration // Default: 3 days
+	AmountTolerance float64       // Default: 0.01
+	Currency        string        // Currency of CSV rows without a currency column
+	// DecimalSeparator of the CSV amounts, "." or ",". By default it is
+	// detected in each amount, e.g. "1.234,50" and "12,50" use ",".
+	DecimalSeparator string
+}
+
+// ExternalTransaction is a row of the CSV being reconciled.
+type ExternalTransaction struct {
+	Line      int     `json:"line" csv:"line"`
+	Date      string  `json:"date" csv:"date"`
+	Amount    float64 `json:"amount" csv:"amount"`
+	Currency  string  `json:"currency,omitempty" csv:"currency"`
+	Reference string  `json:"reference,omitempty" csv:"reference"`
+
+	date    time.Time
+	dateEnd time.Time // End of the day for rows without a time
+}
+
+// ReconcileMatch pairs a CSV row with the Wise statement entry it matched.
+type ReconcileMatch struct {
+	External    ExternalTransaction `json:"external"`
+	Transaction TransactionResult   `json:"transaction"`
+}
+
+// ReconcileResult holds the outcome of a reconciliation. Missing rows are
+// in the CSV but not in Wise; unexpected transactions are in Wise but not
+// in the CSV.
+type ReconcileResult struct {
+	Since           string                `json:"since" csv:"since"`
+	Until           string                `json:"until" csv:"until"`
+	MatchedCount    int                   `json:"matchedCount" csv:"matched"`
+	MissingCount    int                   `json:"missingCount" csv:"missing"`
+	UnexpectedCount int                   `json:"unexpectedCount" csv:"unexpected"`
+	Matched         []ReconcileMatch      `json:"matched" csv:"-"`
+	Missing         []ExternalTransaction `json:"missing" csv:"-"`
+	Unexpected      []TransactionResult   `json:"unexpected" csv:"-"`
+}
+
+// reconcileLayouts are the date formats accepted in reconciled CSVs.
+var reconcileLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02", "2006/01/02"}
+
+// Reconcile matches the rows of a bank or accounting CSV export against
+// the Wise statements of all balances over the same period.
+//
+// The CSV needs a header with date and amount columns, and may have
+// currency and reference (or description, memo) columns. Columns are
+// found by name, ignoring case. A row matches an entry in the same
+// currency whose amount and date are within the tolerances. Pairs are
+// matched best first, whatever the row order: those whose reference or
+// description contains the row's reference, then those with the exact
+// amount, then the closest in date. Each entry matches at most one row.
+func Reconcile(ctx context.Context, client *wise.Client, r io.Reader, opts ReconcileOptions) (ReconcileResult, error) {
+	if opts.DateTolerance == 0 {
+		opts.DateTolerance = 3 * 24 * time.Hour
+	}
+	if opts.AmountTolerance == 0 {
+		opts.AmountTolerance = 0.01
+	}
+	if sep := opts.DecimalSeparator; sep != "" && sep != "." && sep != "," {
+		return ReconcileResult{}, fmt.Errorf("invalid decimal separator %q: want \".\" or \",\"", sep)
+	}
+
+	rows, err := readExternalCSV(r, opts.Currency, opts.DecimalSeparator)
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+	if len(rows) == 0 {
+		return ReconcileResult{}, errors.New("no rows to reconcile")
+	}
+
+	since, until := rows[0].date, rows[0].dateEnd
+	for _, row := range rows {
+		since = minTime(since, row.date)
+		until = maxTime(until, row.dateEnd)
+	}
+	since = since.Add(-opts.DateTolerance)
+	until = until.Add(opts.DateTolerance)
+
+	entries, err := statementEntries(ctx, client, since, until)
+	if err != nil {
+		return ReconcileResult{}, err
+	}
+
+	result := ReconcileResult{
+		Since: since.UTC().Format(time.RFC3339),
+		Until: until.UTC().Format(time.RFC3339),
+	}
+	// Score every candidate pair, then match the best pairs first.
+	type candidate struct {
+		row, entry int
+		ref, exact bool
+		diff       time.Duration
+	}
+	var candidates []candidate
+	for r, row := range rows {
+		for i, e := range entries {
+			if row.Currency != "" && !strings.EqualFold(row.Currency, string(e.Currency)) {
+				continue
+			}
+			amountDiff := math.Abs(e.Amount.Value - row.Amount)
+			if amountDiff > opts.AmountTolerance {
+				continue
+			}
+			diff := row.distance(e.Date.Time)
+			if diff > opts.DateTolerance {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				row:   r,
+				entry: i,
+				ref:   row.Reference != "" && containsFold(row.Reference, e.Details.Description, e.Details.PaymentReference, e.ReferenceNumber),
+				exact: amountDiff < 1e-9,
+				diff:  diff,
+			})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.ref != b.ref {
+			return boolOrder(a.ref)
+		}
+		if a.exact != b.exact {
+			return boolOrder(a.exact)
+		}
+		return cmp.Compare(a.diff, b.diff)
+	})
+
+	used := make([]bool, len(entries))
+	matched := make([]int, len(rows))
+	for r := range matched {
+		matched[r] = -1
+	}
+	for _, c := range candidates {
+		if used[c.entry] || matched[c.row] >= 0 {
+			continue
+		}
+		used[c.entry] = true
+		matched[c.row] = c.entry
+	}
+	for r, row := range rows {
+		if matched[r] < 0 {
+			result.Missing = append(result.Missing, row)
+			continue
+		}
+		result.Matched = append(result.Matched, ReconcileMatch{External: row, Transaction: transactionResult(entries[matched[r]])})
+	}
+	for i, e := range entries {
+		if !used[i] {
+			result.Unexpected = append(result.Unexpected, transactionResult(e))
+		}
+	}
+
+	result.MatchedCount = len(result.Matched)
+	result.MissingCount = len(result.Missing)
+	result.UnexpectedCount = len(result.Unexpected)
+	return result, nil
+}
+
+// boolOrder sorts true before false.
+func boolOrder(first bool) int {
+	if first {
+		return -1
+	}
+	return 1
+}
+
+// readExternalCSV parses the CSV to reconcile. Rows without a currency get
+// defaultCurrency.
+func readExternalCSV(r io.Reader, defaultCurrency, decimalSeparator string) ([]ExternalTransaction, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	cols := map[string]int{"date": -1, "amount": -1, "currency": -1, "reference": -1}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "description", "memo", "payee":
+			if cols["reference"] < 0 {
+				cols["reference"] = i
+			}
+		default:
+			if c, ok := cols[name]; ok && c < 0 {
+				cols[name] = i
+			}
+		}
+	}
+	if cols["date"] < 0 || cols["amount"] < 0 {
+		return nil, errors.New("CSV header must have date and amount columns")
+	}
+
+	field := func(record []string, name string) string {
+		if i := cols[name]; i >= 0 && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []ExternalTransaction
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		date, dateEnd, err := parseReconcileDate(field(record, "date"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		amount, err := parseAmount(field(record, "amount"), decimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid amount %q", line, field(record, "amount"))
+		}
+		currency := strings.ToUpper(field(record, "currency"))
+		if currency == "" {
+			currency = strings.ToUpper(defaultCurrency)
+		}
+		rows = append(rows, ExternalTransaction{
+			Line:      line,
+			Date:      date.UTC().Format(time.RFC3339),
+			Amount:    amount,
+			Currency:  currency,
+			Reference: field(record, "reference"),
+			date:      date,
+			dateEnd:   dateEnd,
+		})
+	}
+}
+
+// parseAmount parses a CSV amount with the given decimal separator, or
+// else the one detected: the last of "." and "," if both are used, the
+// only one if it appears once, except a comma before exactly three digits,
+// as in "1,250", which is read as a thousands separator. Spaces and
+// apostrophes are thousands separators too.
+func parseAmount(s, decimalSeparator string) (float64, error) {
+	s = strings.NewReplacer(" ", "", "'", "").Replace(s)
+	sep := decimalSeparator
+	if sep == "" {
+		dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+		switch {
+		case dot >= 0 && comma >= 0:
+			sep = "."
+			if comma > dot {
+				sep = ","
+			}
+		case comma >= 0:
+			if strings.Count(s, ",") == 1 && len(s)-comma-1 != 3 {
+				sep = ","
+			}
+		case dot >= 0:
+			if strings.Count(s, ".") > 1 {
+				sep = ","
+			}
+		}
+	}
+	if sep == "," {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// parseReconcileDate returns the start and end of the period a CSV date
+// covers: a whole day for a date without a time, else an instant.
+func parseReconcileDate(s string) (time.Time, time.Time, error) {
+	for _, layout := range reconcileLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			if !strings.Contains(layout, "15") {
+				return t, t.AddDate(0, 0, 1), nil
+			}
+			return t, t, nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", s)
+}
+
+// distance returns how far t is from the period the row's date covers.
+func (x ExternalTransaction) distance(t time.Time) time.Duration {
+	switch {
+	case t.Before(x.date):
+		return x.date.Sub(t)
+	case t.After(x.dateEnd):
+		return t.Sub(x.dateEnd)
+	}
+	return 0
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestReconcile(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+	srv.Now = func() time.Time { return time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC) }
+
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 100); result.Error != "" {
+		t.Fatalf("ConvertBalance failed: %s", result.Error)
+	}
+	sent := SendMoney(ctx, client, SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "GBP",
+		SourceAmount:   50,
+		NewRecipient: &wise.CreateRecipientRequest{
+			AccountHolderName: "Jane Doe",
+			Type:              "sort_code",
+			Details:           map[string]interface{}{"sortCode": "231470", "accountNumber": "28821822"},
+		},
+	})
+	if sent.Error != "" {
+		t.Fatalf("SendMoney failed: %s", sent.Error)
+	}
+
+	books := `Date,Description,Amount
+2024-03-14,Rent,-50.00
+2024-03-15,Converted to USD,"-100.00"
+2024-03-20,Coffee,-3.50
+`
+	result, err := Reconcile(ctx, client, strings.NewReader(books), ReconcileOptions{Currency: "eur"})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.MatchedCount != 2 || result.MissingCount != 1 || result.UnexpectedCount != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if m := result.Matched[0]; m.External.Line != 2 || m.Transaction.Type != "TRANSFER" {
+		t.Errorf("expected rent to match the transfer, got %+v", m)
+	}
+	if m := result.Matched[1]; m.Transaction.Type != "CONVERSION" || m.Transaction.Currency != "EUR" {
+		t.Errorf("expected the conversion to match, got %+v", m)
+	}
+	if result.Missing[0].Reference != "Coffee" || result.Missing[0].Currency != "EUR" {
+		t.Errorf("unexpected missing row: %+v", result.Missing[0])
+	}
+	if result.Unexpected[0].Currency != "USD" || result.Unexpected[0].Amount != 107.46 {
+		t.Errorf("unexpected unexpected entry: %+v", result.Unexpected[0])
+	}
+
+	// Outside the date tolerance nothing matches.
+	strict, err := Reconcile(ctx, client, strings.NewReader(books), ReconcileOptions{Currency: "EUR", DateTolerance: time.Hour})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if strict.MatchedCount != 1 || strict.MissingCount != 2 {
+		t.Errorf("expected only the conversion to match, got %+v", strict)
+	}
+
+	// A later row with a matching reference wins over an earlier one.
+	books = "Date,Description,Amount\n2024-03-14,Groceries,\"-50,00\"\n2024-03-14,Sent money,-50.00\n"
+	result, err = Reconcile(ctx, client, strings.NewReader(books), ReconcileOptions{Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.MatchedCount != 1 || result.Matched[0].External.Reference != "Sent money" || result.Missing[0].Reference != "Groceries" {
+		t.Errorf("expected the referenced row to match, got %+v", result)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in, sep string
+		want    float64
+	}{
+		{"-50.00", "", -50},
+		{"12,50", "", 12.5},
+		{"1,250", "", 1250},
+		{"1,234.56", "", 1234.56},
+		{"1.234,56", "", 1234.56},
+		{"1.234.567", "", 1234567},
+		{"1 234,5", "", 1234.5},
+		{"1,250", ",", 1.25},
+		{"1.250", ".", 1.25},
+	}
+	for _, tt := range tests {
+		if got, err := parseAmount(tt.in, tt.sep); err != nil || got != tt.want {
+			t.Errorf("parseAmount(%q, %q) = %v, %v, want %v", tt.in, tt.sep, got, err, tt.want)
+		}
+	}
+}
+
+func TestReconcile_InvalidCSV(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+
+	tests := map[string]string{
+		"no amount column": "date,reference\n2024-03-14,rent\n",
+		"bad date":         "date,amount\n14 March,-50\n",
+		"bad amount":       "date,amount\n2024-03-14,fifty\n",
+		"no rows":          "date,amount\n",
+	}
+	for name, input := range tests {
+		if _, err := Reconcile(context.Background(), srv.Client(), strings.NewReader(input), ReconcileOptions{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
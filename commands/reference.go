@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReferenceRater provides reference rates, such as mid-market rates, to
+// compare Wise rates against.
+type ReferenceRater interface {
+	ReferenceRate(ctx context.Context, from, to string) (float64, error)
+}
+
+// FixedRates is a ReferenceRater backed by rates given by the caller,
+// keyed by pair, e.g. "EUR/USD". Inverse rates are derived.
+type FixedRates map[string]float64
+
+// ReferenceRate implements ReferenceRater.
+func (f FixedRates) ReferenceRate(ctx context.Context, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if rate := f[from+"/"+to]; rate > 0 {
+		return rate, nil
+	}
+	if rate := f[to+"/"+from]; rate > 0 {
+		return 1 / rate, nil
+	}
+	return 0, fmt.Errorf("no reference rate for %s/%s", from, to)
+}
+
+// CompareRates sets the reference rate of each rate fetched without error
+// and how far the rate is from it. A reference rate that cannot be found
+// is reported in ReferenceError.
+func CompareRates(ctx context.Context, rates []RateResult, ref ReferenceRater) []RateResult {
+	for i := range rates {
+		r := &rates[i]
+		if r.Error != "" || r.Rate == 0 {
+			continue
+		}
+		reference, err := ref.ReferenceRate(ctx, r.From, r.To)
+		if err != nil {
+			r.ReferenceError = err.Error()
+			continue
+		}
+		r.Reference = reference
+		r.Markup = (reference - r.Rate) / reference * 100
+	}
+	return rates
+}
+
+// ECBDailyRatesURL is where the European Central Bank publishes its daily
+// euro reference rates.
+const ECBDailyRatesURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBRates is a ReferenceRater backed by the ECB's daily euro reference
+// rates. Rates between other currencies are crossed through EUR. The rates
+// are fetched once and refreshed after MaxAge.
+type ECBRates struct {
+	URL        string        // Default: ECBDailyRatesURL
+	HTTPClient *http.Client  // Default: http.DefaultClient
+	MaxAge     time.Duration // Default: 1 hour
+
+	mu      sync.Mutex
+	rates   map[string]float64 // Units per euro, by currency
+	fetched time.Time
+}
+
+// ReferenceRate implements ReferenceRater.
+func (e *ECBRates) ReferenceRate(ctx context.Context, from, to string) (float64, error) {
+	rates, err := e.load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	perFrom, ok1 := rates[from]
+	perTo, ok2 := rates[to]
+	if !ok1 || !ok2 {
+		return 0, fmt.Errorf("no ECB reference rate for %s/%s", from, to)
+	}
+	return perTo / perFrom, nil
+}
+
+// ecbEnvelope is the part of the ECB daily rates XML that is used.
+type ecbEnvelope struct {
+	Rates []struct {
+		Currency string  `xml:"currency,attr"`
+		Rate     float64 `xml:"rate,attr"`
+	} `xml:"Cube>Cube>Cube"`
+}
+
+func (e *ECBRates) load(ctx context.Context) (map[string]float64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	maxAge := e.MaxAge
+	if maxAge <= 0 {
+		maxAge = time.Hour
+	}
+	if e.rates != nil && time.Since(e.fetched) < maxAge {
+		return e.rates, nil
+	}
+
+	url := e.URL
+	if url == "" {
+		url = ECBDailyRatesURL
+	}
+	client := e.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching ECB rates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching ECB rates: %s", resp.Status)
+	}
+
+	var env ecbEnvelope
+	if err := xml.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return nil, fmt.Errorf("decoding ECB rates: %w", err)
+	}
+	rates := map[string]float64{"EUR": 1}
+	for _, r := range env.Rates {
+		if r.Rate > 0 {
+			rates[r.Currency] = r.Rate
+		}
+	}
+	e.rates, e.fetched = rates, time.Now()
+	return rates, nil
+}
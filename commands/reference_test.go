@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const ecbXML = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2025-03-14">
+			<Cube currency="USD" rate="1.0900"/>
+			<Cube currency="GBP" rate="0.8400"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`
+
+func TestCompareRates(t *testing.T) {
+	rates := []RateResult{
+		{From: "EUR", To: "USD", Rate: 1.0846},
+		{From: "USD", To: "EUR", Rate: 0.92},
+		{From: "EUR", To: "JPY", Rate: 160},
+		{From: "EUR", To: "CHF", Error: "unavailable"},
+	}
+	rates = CompareRates(context.Background(), rates, FixedRates{"EUR/USD": 1.09})
+
+	if r := rates[0]; r.Reference != 1.09 || math.Abs(r.Markup-0.4954) > 0.0001 {
+		t.Errorf("unexpected comparison: %+v", r)
+	}
+	if r := rates[1]; math.Abs(r.Reference-1/1.09) > 1e-9 {
+		t.Errorf("expected the inverse reference rate, got %+v", r)
+	}
+	if r := rates[2]; r.Reference != 0 || r.ReferenceError == "" {
+		t.Errorf("expected a reference error, got %+v", r)
+	}
+	if r := rates[3]; r.ReferenceError != "" {
+		t.Errorf("failed rates should not be compared, got %+v", r)
+	}
+}
+
+func TestECBRates(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(ecbXML))
+	}))
+	defer srv.Close()
+
+	ecb := &ECBRates{URL: srv.URL}
+	ctx := context.Background()
+	if rate, err := ecb.ReferenceRate(ctx, "EUR", "USD"); err != nil || rate != 1.09 {
+		t.Errorf("EUR/USD = %v, %v", rate, err)
+	}
+	if rate, err := ecb.ReferenceRate(ctx, "gbp", "usd"); err != nil || math.Abs(rate-1.09/0.84) > 1e-9 {
+		t.Errorf("GBP/USD = %v, %v", rate, err)
+	}
+	if _, err := ecb.ReferenceRate(ctx, "EUR", "XYZ"); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+	if requests != 1 {
+		t.Errorf("expected the rates to be fetched once, got %d requests", requests)
+	}
+}
@@ -0,0 +1,242 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// Cadences of a recurring transfer.
+const (
+	CadenceDaily   = "daily"
+	CadenceWeekly  = "weekly"
+	CadenceMonthly = "monthly"
+)
+
+// Execution statuses reported in ScheduledExecution.Status.
+const (
+	ExecutionUpcoming = "upcoming"
+	ExecutionDue      = "due"
+	ExecutionDone     = "done"
+)
+
+// scheduleNamespace is the UUID namespace customer transaction IDs of
+// recurring transfers are derived in.
+var scheduleNamespace = uuid.MustParse("0b6c3c4e-8f3a-4c1e-9d35-6f2f5a7e9b21")
+
+// RecurringTransfer is a rule for sending the same transfer on a schedule,
+// e.g. 500 EUR to the landlord on the 1st of every month.
+//
+// ID identifies the rule: the customer transaction ID of each execution is
+// derived from it and the execution date, so an execution is never created
+// twice. Changing the ID of a rule starts a new series.
+type RecurringTransfer struct {
+	ID       string
+	Transfer SendMoneyRequest // DryRun and CustomerTransactionID are ignored
+	Cadence  string           // CadenceDaily, CadenceWeekly or CadenceMonthly
+	Interval int              // Every Interval cadences, default 1
+	Start    time.Time        // Date of the first execution
+	End      time.Time        // Optional. No executions after End
+}
+
+// ScheduledExecution is one execution of a recurring transfer. A due
+// execution with a TransferID has a transfer that is not funded yet.
+type ScheduledExecution struct {
+	Index                 int    `json:"index" csv:"index"` // 0 for the first execution
+	Date                  string `json:"date" csv:"date"`
+	CustomerTransactionID string `json:"customerTransactionId" csv:"customer_transaction_id"`
+	Status                string `json:"status" csv:"status"` // ExecutionUpcoming, ExecutionDue or ExecutionDone
+	TransferID            int64  `json:"transferId,omitempty" csv:"transfer_id"`
+}
+
+// ScheduleOptions configures ScheduleRecurringTransfer.
+type ScheduleOptions struct {
+	Now      time.Time // Default: time.Now()
+	Upcoming int       // Number of upcoming executions to list, default 5
+	// Execute sends the due execution if it has not been sent yet, or
+	// funds its transfer if that failed before. Otherwise
+	// ScheduleRecurringTransfer only plans.
+	Execute bool
+}
+
+// ScheduleResult holds the plan of a recurring transfer and, if an
+// execution was sent, its result.
+type ScheduleResult struct {
+	RuleID   string               `json:"ruleId" csv:"rule_id"`
+	Due      *ScheduledExecution  `json:"due,omitempty" csv:"-"` // Latest execution on or before Now
+	Upcoming []ScheduledExecution `json:"upcoming" csv:"-"`
+	Sent     *SendMoneyResult     `json:"sent,omitempty" csv:"-"`
+	Error    string               `json:"error,omitempty" csv:"error"`
+}
+
+// ScheduleRecurringTransfer plans the executions of rule around opts.Now:
+// the latest execution due by then and the upcoming ones after it. It
+// looks up whether the due execution was already sent and funded and,
+// with opts.Execute, sends or funds it if not. An execution whose transfer
+// was cancelled or refunded is an error, as it cannot be sent again.
+//
+// Only the latest due execution is considered; missed earlier ones are not
+// caught up. Running it repeatedly, e.g. from cron, is safe: the derived
+// customer transaction ID lets Wise reject a duplicate even if the lookup
+// misses a transfer.
+func ScheduleRecurringTransfer(ctx context.Context, client *wise.Client, rule RecurringTransfer, opts ScheduleOptions) ScheduleResult {
+	result := ScheduleResult{RuleID: rule.ID}
+	if err := rule.validate(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	count := opts.Upcoming
+	if count <= 0 {
+		count = 5
+	}
+
+	// The first execution after now, then step back to the one due.
+	next := 0
+	for {
+		date, ok := rule.occurrence(next)
+		if !ok || date.After(now) {
+			break
+		}
+		next++
+	}
+	for i := next; len(result.Upcoming) < count; i++ {
+		date, ok := rule.occurrence(i)
+		if !ok {
+			break
+		}
+		result.Upcoming = append(result.Upcoming, rule.execution(i, date, ExecutionUpcoming))
+	}
+	if next == 0 {
+		return result
+	}
+	date, _ := rule.occurrence(next - 1)
+	due := rule.execution(next-1, date, ExecutionDue)
+	result.Due = &due
+
+	transfer, profileID, err := findExecution(ctx, client, rule, due, date)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if transfer != nil {
+		due.TransferID = transfer.ID
+		switch transfer.Status {
+		case wise.TransferStatusIncomingPaymentWaiting:
+		case wise.TransferStatusCancelled, wise.TransferStatusFundsRefunded,
+			wise.TransferStatusBounced, wise.TransferStatusChargedBack:
+			result.Error = fmt.Sprintf("transfer %d of the execution is %s", transfer.ID, transfer.Status)
+			return result
+		default:
+			due.Status = ExecutionDone
+			return result
+		}
+		if !opts.Execute {
+			return result
+		}
+		// A previous run created the transfer but failed to fund it.
+		if _, err := client.Transfers.Fund(ctx, profileID, transfer.ID); err != nil {
+			result.Error = fmt.Sprintf("funding transfer %d: %v", transfer.ID, err)
+			return result
+		}
+		due.Status = ExecutionDone
+		return result
+	}
+	if !opts.Execute {
+		return result
+	}
+
+	req := rule.Transfer
+	req.DryRun = false
+	req.CustomerTransactionID = due.CustomerTransactionID
+	sent := SendMoney(ctx, client, req)
+	result.Sent = &sent
+	due.TransferID = sent.TransferID
+	if sent.Error != "" {
+		result.Error = sent.Error
+		return result
+	}
+	due.Status = ExecutionDone
+	return result
+}
+
+func (r RecurringTransfer) validate() error {
+	switch {
+	case r.ID == "":
+		return errors.New("recurring transfer has no ID")
+	case r.Start.IsZero():
+		return errors.New("recurring transfer has no start date")
+	case r.Cadence != CadenceDaily && r.Cadence != CadenceWeekly && r.Cadence != CadenceMonthly:
+		return fmt.Errorf("invalid cadence %q: want daily, weekly or monthly", r.Cadence)
+	}
+	return nil
+}
+
+// occurrence returns the date of execution i, and false if it is after
+// r.End. Monthly executions on days a month lacks fall on its last day.
+func (r RecurringTransfer) occurrence(i int) (time.Time, bool) {
+	step := max(r.Interval, 1) * i
+	var date time.Time
+	switch r.Cadence {
+	case CadenceDaily:
+		date = r.Start.AddDate(0, 0, step)
+	case CadenceWeekly:
+		date = r.Start.AddDate(0, 0, 7*step)
+	default:
+		y, m, d := r.Start.Date()
+		first := time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, r.Start.Location())
+		last := first.AddDate(0, 1, -1).Day()
+		hh, mm, ss := r.Start.Clock()
+		date = time.Date(first.Year(), first.Month(), min(d, last), hh, mm, ss, 0, r.Start.Location())
+	}
+	if !r.End.IsZero() && date.After(r.End) {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func (r RecurringTransfer) execution(i int, date time.Time, status string) ScheduledExecution {
+	day := date.Format("2006-01-02")
+	return ScheduledExecution{
+		Index:                 i,
+		Date:                  day,
+		CustomerTransactionID: uuid.NewSHA1(scheduleNamespace, []byte(r.ID+"/"+day)).String(),
+		Status:                status,
+	}
+}
+
+// findExecution returns the transfer created for execution e, or nil if
+// there is none, and the profile it was looked up in.
+func findExecution(ctx context.Context, client *wise.Client, rule RecurringTransfer, e ScheduledExecution, date time.Time) (*wise.Transfer, int64, error) {
+	profileID := rule.Transfer.ProfileID
+	if profileID == 0 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(profiles) == 0 {
+			return nil, 0, errors.New("no profiles found")
+		}
+		profileID = profiles[0].ID
+	}
+	transfers, err := client.Transfers.ListAll(ctx, &wise.ListTransfersParams{
+		ProfileID:        profileID,
+		CreatedDateStart: date.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, t := range transfers {
+		if t.CustomerTransactionID == e.CustomerTransactionID {
+			return &t, profileID, nil
+		}
+	}
+	return nil, profileID, nil
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestRecurringTransfer_Occurrence(t *testing.T) {
+	rule := RecurringTransfer{
+		ID:      "rent",
+		Cadence: CadenceMonthly,
+		Start:   time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC),
+		End:     time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+	}
+	want := []string{"2025-01-31", "2025-02-28", "2025-03-31"}
+	for i, w := range want {
+		date, ok := rule.occurrence(i)
+		if !ok || date.Format("2006-01-02") != w {
+			t.Errorf("occurrence(%d) = %v, %v, want %s", i, date, ok, w)
+		}
+	}
+	if _, ok := rule.occurrence(3); ok {
+		t.Error("occurrence(3) is after End")
+	}
+}
+
+func TestScheduleRecurringTransfer(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	id := srv.AddRecipient(wise.Recipient{AccountHolderName: "Jane Doe", Currency: wise.USD})
+	now := time.Now().UTC()
+	rule := RecurringTransfer{
+		ID: "allowance",
+		Transfer: SendMoneyRequest{
+			SourceCurrency: "EUR",
+			TargetCurrency: "USD",
+			SourceAmount:   20,
+			RecipientID:    id,
+		},
+		Cadence: CadenceWeekly,
+		Start:   now.AddDate(0, 0, -10),
+	}
+
+	plan := ScheduleRecurringTransfer(ctx, client, rule, ScheduleOptions{Now: now, Upcoming: 3})
+	if plan.Error != "" {
+		t.Fatalf("ScheduleRecurringTransfer failed: %v", plan.Error)
+	}
+	if plan.Due == nil || plan.Due.Index != 1 || plan.Due.Status != ExecutionDue || len(plan.Upcoming) != 3 || plan.Sent != nil {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	if plan.Upcoming[0].Index != 2 || plan.Upcoming[0].CustomerTransactionID == plan.Due.CustomerTransactionID {
+		t.Errorf("unexpected upcoming executions: %+v", plan.Upcoming)
+	}
+
+	for range 2 {
+		result := ScheduleRecurringTransfer(ctx, client, rule, ScheduleOptions{Now: now, Execute: true})
+		if result.Error != "" {
+			t.Fatalf("ScheduleRecurringTransfer failed: %v", result.Error)
+		}
+		if result.Due.Status != ExecutionDone || result.Due.CustomerTransactionID != plan.Due.CustomerTransactionID {
+			t.Errorf("unexpected due execution: %+v", result.Due)
+		}
+	}
+	transfers := srv.Transfers()
+	if len(transfers) != 1 || transfers[0].CustomerTransactionID != plan.Due.CustomerTransactionID {
+		t.Errorf("expected the due execution to be sent once, got %+v", transfers)
+	}
+
+	// A transfer left unfunded by a failed run is funded by the next one.
+	srv.SetTransferStatus(transfers[0].ID, wise.TransferStatusIncomingPaymentWaiting)
+	plan = ScheduleRecurringTransfer(ctx, client, rule, ScheduleOptions{Now: now})
+	if plan.Error != "" || plan.Due.Status != ExecutionDue || plan.Due.TransferID != transfers[0].ID {
+		t.Errorf("expected the unfunded execution to be due, got %+v", plan)
+	}
+	result := ScheduleRecurringTransfer(ctx, client, rule, ScheduleOptions{Now: now, Execute: true})
+	if result.Error != "" || result.Due.Status != ExecutionDone || result.Sent != nil {
+		t.Errorf("expected the unfunded execution to be funded, got %+v", result)
+	}
+	if transfers := srv.Transfers(); len(transfers) != 1 || transfers[0].Status != wise.TransferStatusProcessing {
+		t.Errorf("expected the transfer to be funded, got %+v", transfers)
+	}
+
+	srv.SetTransferStatus(transfers[0].ID, wise.TransferStatusCancelled)
+	result = ScheduleRecurringTransfer(ctx, client, rule, ScheduleOptions{Now: now, Execute: true})
+	if result.Error == "" || result.Due.Status == ExecutionDone {
+		t.Errorf("expected a cancelled execution to be an error, got %+v", result)
+	}
+}
+
+func TestScheduleRecurringTransfer_Invalid(t *testing.T) {
+	result := ScheduleRecurringTransfer(context.Background(), nil, RecurringTransfer{ID: "x", Cadence: "yearly", Start: time.Now()}, ScheduleOptions{})
+	if result.Error == "" {
+		t.Error("expected an error for an invalid cadence")
+	}
+}
@@ -0,0 +1,509 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/export"
+	"golang.org/x/sync/errgroup"
+)
+
+// statementCSVHeader is the header row written by ExportStatementsCSV.
+var statementCSVHeader = []string{"date", "description", "amount", "currency", "fees", "running_balance", "reference"}
+
+// ExportStatementsCSV writes the statement entries of every balance of
+// every profile between start and end to w as CSV, with a header row.
+// Entries are grouped by balance and in date order within a balance.
+// Dates are RFC 3339 in UTC; amounts are negative for money out and use
+// the currency's minor units.
+func ExportStatementsCSV(ctx context.Context, client *wise.Client, w io.Writer, start, end time.Time) error {
+	return exportStatementsCSV(ctx, client, w, start, end, "")
+}
+
+// exportStatementsCSV is ExportStatementsCSV, limited to the balances in
+// currency if it is set.
+func exportStatementsCSV(ctx context.Context, client *wise.Client, w io.Writer, start, end time.Time, currency string) error {
+	profiles, err := client.Profiles.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(statementCSVHeader); err != nil {
+		return err
+	}
+
+	windows := statementWindows(start, end)
+	for _, p := range profiles {
+		balances, err := client.Balances.List(ctx, p.ID, nil)
+		if err != nil {
+			return fmt.Errorf("profile %d: %w", p.ID, err)
+		}
+		for _, b := range balances {
+			if currency != "" && !strings.EqualFold(string(b.Currency), currency) {
+				continue
+			}
+			for _, win := range windows {
+				if err := writeStatementCSV(ctx, client, cw, p.ID, b, win[0], win[1]); err != nil {
+					return fmt.Errorf("profile %d, %s balance: %w", p.ID, b.Currency, err)
+				}
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Statement file formats of ExportStatements.
+const (
+	ExportCSV = "csv"
+	ExportOFX = export.FormatOFX
+	ExportQIF = export.FormatQIF
+	ExportPDF = "pdf"
+)
+
+// ExportOptions configures ExportStatements.
+type ExportOptions struct {
+	Format     string // ExportCSV, ExportOFX, ExportQIF or ExportPDF
+	ProfileID  int64  // Default: the first profile. Ignored by CSV, which covers all profiles
+	Currency   string // Balance to export. CSV exports all balances if empty
+	Start, End time.Time
+}
+
+// ExportStatements writes the statement of a balance between opts.Start
+// and opts.End to w in opts.Format. The statement is streamed from the
+// API in intervals of at most wise.MaxStatementInterval, so exports
+// spanning years are not held in memory.
+//
+// OFX, QIF and PDF files hold one balance: opts.Currency picks it, and may
+// only be left empty if the profile has a single balance. Wise renders
+// PDF statements itself, so they cannot span more than one interval.
+func ExportStatements(ctx context.Context, client *wise.Client, w io.Writer, opts ExportOptions) error {
+	switch opts.Format {
+	case ExportCSV:
+		return exportStatementsCSV(ctx, client, w, opts.Start, opts.End, opts.Currency)
+	case ExportOFX, ExportQIF, ExportPDF:
+	default:
+		return fmt.Errorf("unknown statement format %q: want csv, ofx, qif or pdf", opts.Format)
+	}
+
+	windows := statementWindows(opts.Start, opts.End)
+	if opts.Format == ExportPDF && len(windows) > 1 {
+		return fmt.Errorf("PDF statements can cover at most %d days", int(wise.MaxStatementInterval.Hours()/24))
+	}
+	profileID, b, err := exportBalance(ctx, client, opts.ProfileID, opts.Currency)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == ExportPDF {
+		body, err := client.Balances.DownloadStatement(ctx, profileID, b.ID, b.Currency, windows[0][0], windows[0][1], wise.StatementFormatPDF)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		_, err = io.Copy(w, body)
+		return err
+	}
+
+	ew, err := export.NewWriter(opts.Format, w, export.Account{
+		ID:       strconv.FormatInt(b.ID, 10),
+		Currency: b.Currency,
+		Start:    opts.Start,
+		End:      opts.End,
+	})
+	if err != nil {
+		return err
+	}
+	for _, win := range windows {
+		if err := writeStatement(ctx, client, ew, profileID, b, win[0], win[1]); err != nil {
+			return err
+		}
+	}
+	return ew.Close()
+}
+
+// exportBalance returns the balance in currency of the profile, or its only
+// balance if currency is empty. profileID defaults to the first profile.
+func exportBalance(ctx context.Context, client *wise.Client, profileID int64, currency string) (int64, wise.Balance, error) {
+	if profileID == 0 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			return 0, wise.Balance{}, err
+		}
+		if len(profiles) == 0 {
+			return 0, wise.Balance{}, fmt.Errorf("no profiles found")
+		}
+		profileID = profiles[0].ID
+	}
+	balances, err := client.Balances.List(ctx, profileID, nil)
+	if err != nil {
+		return 0, wise.Balance{}, fmt.Errorf("profile %d: %w", profileID, err)
+	}
+	if currency == "" {
+		if len(balances) != 1 {
+			return 0, wise.Balance{}, fmt.Errorf("profile %d has %d balances, choose one by currency", profileID, len(balances))
+		}
+		return profileID, balances[0], nil
+	}
+	for _, b := range balances {
+		if strings.EqualFold(string(b.Currency), currency) {
+			return profileID, b, nil
+		}
+	}
+	return 0, wise.Balance{}, fmt.Errorf("profile %d has no %s balance", profileID, strings.ToUpper(currency))
+}
+
+// writeStatement streams the statement of balance b between start and end
+// into ew.
+func writeStatement(ctx context.Context, client *wise.Client, ew export.Writer, profileID int64, b wise.Balance, start, end string) error {
+	stream, err := client.Balances.StreamStatement(ctx, profileID, b.ID, b.Currency, start, end)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for st, err := range stream.All() {
+		if err != nil {
+			return err
+		}
+		if err := ew.Write(st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeStatementCSV(ctx context.Context, client *wise.Client, cw *csv.Writer, profileID int64, b wise.Balance, start, end string) error {
+	stream, err := client.Balances.StreamStatement(ctx, profileID, b.ID, b.Currency, start, end)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	for st, err := range stream.All() {
+		if err != nil {
+			return err
+		}
+		currency := st.Amount.Currency
+		if currency == "" {
+			currency = b.Currency
+		}
+		description := st.Details.Description
+		if description == "" {
+			description = st.Details.Type
+		}
+		record := []string{
+			st.Date.UTC().Format(time.RFC3339),
+			description,
+			export.FormatAmount(st.Amount.Value, currency),
+			string(currency),
+			export.FormatAmount(st.TotalFees.Value, currency),
+			export.FormatAmount(st.RunningBalance.Value, currency),
+			st.ReferenceNumber,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statementWindows splits start to end into consecutive intervals no
+// longer than wise.MaxStatementInterval, formatted for the statement API.
+// The end is rounded up, as the API takes whole seconds.
+func statementWindows(start, end time.Time) [][2]string {
+	start = start.UTC().Truncate(time.Second)
+	end = end.UTC().Add(time.Second - 1).Truncate(time.Second)
+	var windows [][2]string
+	for {
+		winEnd := start.Add(wise.MaxStatementInterval)
+		if !winEnd.Before(end) {
+			return append(windows, [2]string{start.Format(time.RFC3339), end.Format(time.RFC3339)})
+		}
+		windows = append(windows, [2]string{start.Format(time.RFC3339), winEnd.Format(time.RFC3339)})
+		start = winEnd.Add(time.Second)
+	}
+}
+
+// getStatement fetches the statement of balance b between start and end,
+// in as many requests as the interval needs.
+func getStatement(ctx context.Context, client *wise.Client, profileID int64, b wise.Balance, start, end time.Time) ([]wise.BalanceStatement, error) {
+	var all []wise.BalanceStatement
+	for _, win := range statementWindows(start, end) {
+		statements, err := client.Balances.GetStatement(ctx, profileID, b.ID, b.Currency, win[0], win[1])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, statements...)
+	}
+	return all, nil
+}
+
+// statementEntry is a statement entry with the balance it belongs to.
+type statementEntry struct {
+	wise.BalanceStatement
+	ProfileID int64
+	BalanceID int64
+	Currency  wise.Currency
+}
+
+// statementEntries fetches the statement entries of every balance of every
+// profile between start and end, several balances at a time. Entries are
+// grouped by balance and in date order within a balance.
+func statementEntries(ctx context.Context, client *wise.Client, start, end time.Time) ([]statementEntry, error) {
+	profiles, err := client.Profiles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type job struct {
+		profileID int64
+		balance   wise.Balance
+	}
+	var jobs []job
+	for _, p := range profiles {
+		balances, err := client.Balances.List(ctx, p.ID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("profile %d: %w", p.ID, err)
+		}
+		for _, b := range balances {
+			jobs = append(jobs, job{profileID: p.ID, balance: b})
+		}
+	}
+
+	entries := make([][]statementEntry, len(jobs))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrency)
+	for i, j := range jobs {
+		g.Go(func() error {
+			b := j.balance
+			statements, err := getStatement(gctx, client, j.profileID, b, start, end)
+			if err != nil {
+				return fmt.Errorf("profile %d, %s balance: %w", j.profileID, b.Currency, err)
+			}
+			for _, st := range statements {
+				entries[i] = append(entries[i], statementEntry{BalanceStatement: st, ProfileID: j.profileID, BalanceID: b.ID, Currency: b.Currency})
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var all []statementEntry
+	for _, e := range entries {
+		all = append(all, e...)
+	}
+	return all, nil
+}
+
+// MonthSummary totals the statement entries of one currency in one month.
+// Conversions between balances are counted separately from money in and
+// out. All totals are positive.
+type MonthSummary struct {
+	Month           string  `json:"month" csv:"month"` // e.g. "2024-03"
+	Currency        string  `json:"currency" csv:"currency"`
+	MoneyIn         float64 `json:"moneyIn" csv:"money_in"`
+	MoneyInCount    int     `json:"moneyInCount" csv:"money_in_count"`
+	MoneyOut        float64 `json:"moneyOut" csv:"money_out"`
+	MoneyOutCount   int     `json:"moneyOutCount" csv:"money_out_count"`
+	ConvertedIn     float64 `json:"convertedIn" csv:"converted_in"`
+	ConvertedOut    float64 `json:"convertedOut" csv:"converted_out"`
+	ConversionCount int     `json:"conversionCount" csv:"conversion_count"`
+	Fees            float64 `json:"fees" csv:"fees"`
+	FeeCount        int     `json:"feeCount" csv:"fee_count"`
+	Net             float64 `json:"net" csv:"net"` // Change in balance, including conversions
+}
+
+// MonthlySummary aggregates the statements of all balances between start
+// and end by month and currency, ordered by month then currency.
+func MonthlySummary(ctx context.Context, client *wise.Client, start, end time.Time) ([]MonthSummary, error) {
+	entries, err := statementEntries(ctx, client, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ month, currency string }
+	summaries := make(map[key]*MonthSummary)
+	for _, e := range entries {
+		k := key{e.Date.UTC().Format("2006-01"), string(e.Currency)}
+		m := summaries[k]
+		if m == nil {
+			m = &MonthSummary{Month: k.month, Currency: k.currency}
+			summaries[k] = m
+		}
+
+		v := e.Amount.Value
+		switch {
+		case e.Details.Type == "CONVERSION" && v >= 0:
+			m.ConvertedIn += v
+			m.ConversionCount++
+		case e.Details.Type == "CONVERSION":
+			m.ConvertedOut -= v
+			m.ConversionCount++
+		case v >= 0:
+			m.MoneyIn += v
+			m.MoneyInCount++
+		default:
+			m.MoneyOut -= v
+			m.MoneyOutCount++
+		}
+		if fee := e.TotalFees.Value; fee != 0 {
+			m.Fees += max(fee, -fee)
+			m.FeeCount++
+		}
+		m.Net += v
+	}
+
+	results := make([]MonthSummary, 0, len(summaries))
+	for _, m := range summaries {
+		c := wise.Currency(m.Currency)
+		m.MoneyIn, m.MoneyOut = c.Round(m.MoneyIn), c.Round(m.MoneyOut)
+		m.ConvertedIn, m.ConvertedOut = c.Round(m.ConvertedIn), c.Round(m.ConvertedOut)
+		m.Fees, m.Net = c.Round(m.Fees), c.Round(m.Net)
+		results = append(results, *m)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Month != results[j].Month {
+			return results[i].Month < results[j].Month
+		}
+		return results[i].Currency < results[j].Currency
+	})
+	return results, nil
+}
+
+// TransactionFilter selects statement entries for SearchTransactions.
+// Zero fields match everything. Text matching is case-insensitive.
+type TransactionFilter struct {
+	Query        string // Matched against description, payment reference and reference number
+	Counterparty string // Matched against sender, recipient and merchant names and accounts
+	Currency     string
+	MinAmount    float64   // Absolute amount, so 50 matches both +50 and -50
+	MaxAmount    float64   // Absolute amount
+	Since        time.Time // Default: one year before Until
+	Until        time.Time // Default: now
+}
+
+// TransactionResult holds a statement entry and the balance it belongs to.
+type TransactionResult struct {
+	ProfileID      int64   `json:"profileId" csv:"profile_id"`
+	BalanceID      int64   `json:"balanceId" csv:"balance_id"`
+	Date           string  `json:"date" csv:"date"`
+	Type           string  `json:"type" csv:"type"`
+	Description    string  `json:"description" csv:"description"`
+	Counterparty   string  `json:"counterparty,omitempty" csv:"counterparty"`
+	Amount         float64 `json:"amount" csv:"amount"`
+	Currency       string  `json:"currency" csv:"currency"`
+	Fees           float64 `json:"fees" csv:"fees"`
+	RunningBalance float64 `json:"runningBalance" csv:"running_balance"`
+	Reference      string  `json:"reference" csv:"reference"`
+}
+
+// SearchTransactions searches the statements of all balances for entries
+// matching filter, newest first.
+func SearchTransactions(ctx context.Context, client *wise.Client, filter TransactionFilter) ([]TransactionResult, error) {
+	until := filter.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := filter.Since
+	if since.IsZero() {
+		since = until.AddDate(-1, 0, 0)
+	}
+
+	entries, err := statementEntries(ctx, client, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []TransactionResult
+	for _, e := range entries {
+		if !filter.matches(e) {
+			continue
+		}
+		results = append(results, transactionResult(e))
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Date > results[j].Date })
+	return results, nil
+}
+
+func (f TransactionFilter) matches(e statementEntry) bool {
+	if f.Currency != "" && !strings.EqualFold(f.Currency, string(e.Currency)) {
+		return false
+	}
+	amount := max(e.Amount.Value, -e.Amount.Value)
+	if f.MinAmount > 0 && amount < f.MinAmount {
+		return false
+	}
+	if f.MaxAmount > 0 && amount > f.MaxAmount {
+		return false
+	}
+	if f.Query != "" && !containsFold(f.Query, e.Details.Description, e.Details.PaymentReference, e.ReferenceNumber) {
+		return false
+	}
+	if f.Counterparty != "" && !containsFold(f.Counterparty, counterparties(e.Details)...) {
+		return false
+	}
+	return true
+}
+
+// counterparties returns the names and accounts of the sender, recipient
+// and merchant of an entry.
+func counterparties(d wise.StatementDetails) []string {
+	fields := []string{d.SenderName, d.SenderAccount}
+	if d.Recipient != nil {
+		fields = append(fields, d.Recipient.Name, d.Recipient.BankAccount)
+	}
+	if d.Merchant != nil {
+		fields = append(fields, d.Merchant.Name)
+	}
+	return fields
+}
+
+func transactionResult(e statementEntry) TransactionResult {
+	var names []string
+	for _, name := range counterparties(e.Details) {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	counterparty := strings.Join(names, " ")
+	reference := e.Details.PaymentReference
+	if reference == "" {
+		reference = e.ReferenceNumber
+	}
+	return TransactionResult{
+		ProfileID:      e.ProfileID,
+		BalanceID:      e.BalanceID,
+		Date:           e.Date.UTC().Format(time.RFC3339),
+		Type:           e.Details.Type,
+		Description:    e.Details.Description,
+		Counterparty:   counterparty,
+		Amount:         e.Amount.Value,
+		Currency:       string(e.Currency),
+		Fees:           e.TotalFees.Value,
+		RunningBalance: e.RunningBalance.Value,
+		Reference:      reference,
+	}
+}
+
+// containsFold reports whether any of fields contains substr, ignoring case.
+func containsFold(substr string, fields ...string) bool {
+	substr = strings.ToLower(substr)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestExportStatementsCSV(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 100); result.Error != "" {
+		t.Fatalf("ConvertBalance failed: %s", result.Error)
+	}
+
+	var buf bytes.Buffer
+	now := time.Now()
+	if err := ExportStatementsCSV(ctx, client, &buf, now.Add(-time.Hour), now.Add(time.Hour)); err != nil {
+		t.Fatalf("ExportStatementsCSV failed: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 rows, got %v", records)
+	}
+	if records[0][0] != "date" || records[0][6] != "reference" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+	eur, usd := records[1], records[2]
+	if eur[1] != "Converted to USD" || eur[2] != "-100.00" || eur[3] != "EUR" || eur[5] != "900.00" {
+		t.Errorf("unexpected EUR row: %v", eur)
+	}
+	if usd[2] != "107.46" || usd[3] != "USD" || usd[5] != "357.46" || usd[6] == "" {
+		t.Errorf("unexpected USD row: %v", usd)
+	}
+	if _, err := time.Parse(time.RFC3339, eur[0]); err != nil {
+		t.Errorf("date %q is not RFC 3339: %v", eur[0], err)
+	}
+}
+
+func TestExportStatements(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 100); result.Error != "" {
+		t.Fatalf("ConvertBalance failed: %s", result.Error)
+	}
+	now := time.Now()
+	opts := ExportOptions{Currency: "usd", Start: now.AddDate(-3, 0, 0), End: now.Add(time.Hour)}
+
+	for format, want := range map[string]string{
+		ExportCSV: "107.46,USD",
+		ExportOFX: "<TRNAMT>107.46",
+		ExportQIF: "T107.46",
+		ExportPDF: "%PDF-1.4",
+	} {
+		opts := opts
+		opts.Format = format
+		if format == ExportPDF {
+			opts.Start = now.Add(-time.Hour)
+		}
+		var buf bytes.Buffer
+		if err := ExportStatements(ctx, client, &buf, opts); err != nil {
+			t.Errorf("%s: ExportStatements failed: %v", format, err)
+			continue
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%s: expected %q in\n%s", format, want, buf.String())
+		}
+		if strings.Contains(buf.String(), "-100.00") {
+			t.Errorf("%s: EUR entry exported with the USD balance", format)
+		}
+	}
+
+	for name, opts := range map[string]ExportOptions{
+		"long pdf":         {Format: ExportPDF, Currency: "USD", Start: opts.Start, End: opts.End},
+		"no currency":      {Format: ExportOFX, Start: opts.Start, End: opts.End},
+		"missing currency": {Format: ExportQIF, Currency: "CHF", Start: opts.Start, End: opts.End},
+		"unknown format":   {Format: "xlsx", Currency: "USD", Start: opts.Start, End: opts.End},
+	} {
+		if err := ExportStatements(ctx, client, &bytes.Buffer{}, opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMonthlySummary(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+	march := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	srv.Now = func() time.Time { return march }
+
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 100); result.Error != "" {
+		t.Fatalf("ConvertBalance failed: %s", result.Error)
+	}
+	sent := SendMoney(ctx, client, SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "GBP",
+		SourceAmount:   50,
+		NewRecipient: &wise.CreateRecipientRequest{
+			AccountHolderName: "Jane Doe",
+			Type:              "sort_code",
+			Details:           map[string]interface{}{"sortCode": "231470", "accountNumber": "28821822"},
+		},
+	})
+	if sent.Error != "" {
+		t.Fatalf("SendMoney failed: %s", sent.Error)
+	}
+
+	summaries, err := MonthlySummary(ctx, client, march.AddDate(0, -1, 0), march.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatalf("MonthlySummary failed: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected EUR and USD summaries, got %+v", summaries)
+	}
+	eur, usd := summaries[0], summaries[1]
+	if eur.Month != "2024-03" || eur.Currency != "EUR" || eur.ConvertedOut != 100 || eur.ConversionCount != 1 ||
+		eur.MoneyOut != 50 || eur.MoneyOutCount != 1 || eur.MoneyIn != 0 || eur.Net != -150 {
+		t.Errorf("unexpected EUR summary: %+v", eur)
+	}
+	if usd.Currency != "USD" || usd.ConvertedIn != 107.46 || usd.ConversionCount != 1 || usd.Net != 107.46 {
+		t.Errorf("unexpected USD summary: %+v", usd)
+	}
+}
+
+func TestSearchTransactions(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	if result := ConvertBalance(ctx, client, "EUR", "USD", 100); result.Error != "" {
+		t.Fatalf("ConvertBalance failed: %s", result.Error)
+	}
+	sent := SendMoney(ctx, client, SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "GBP",
+		SourceAmount:   50,
+		NewRecipient: &wise.CreateRecipientRequest{
+			AccountHolderName: "Jane Doe",
+			Type:              "sort_code",
+			Details:           map[string]interface{}{"sortCode": "231470", "accountNumber": "28821822"},
+		},
+	})
+	if sent.Error != "" {
+		t.Fatalf("SendMoney failed: %s", sent.Error)
+	}
+
+	tests := []struct {
+		name   string
+		filter TransactionFilter
+		want   int
+	}{
+		{"all", TransactionFilter{}, 3},
+		{"query", TransactionFilter{Query: "CONVERTED"}, 2},
+		{"currency", TransactionFilter{Query: "converted", Currency: "usd"}, 1},
+		{"min amount", TransactionFilter{MinAmount: 100}, 2},
+		{"amount range", TransactionFilter{MinAmount: 40, MaxAmount: 60}, 1},
+		{"counterparty", TransactionFilter{Counterparty: "nobody"}, 0},
+		{"recipient", TransactionFilter{Counterparty: "jane"}, 1},
+		{"date range", TransactionFilter{Until: time.Now().Add(-time.Hour)}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := SearchTransactions(ctx, client, tt.filter)
+			if err != nil {
+				t.Fatalf("SearchTransactions failed: %v", err)
+			}
+			if len(results) != tt.want {
+				t.Errorf("expected %d results, got %+v", tt.want, results)
+			}
+		})
+	}
+
+	results, _ := SearchTransactions(ctx, client, TransactionFilter{MaxAmount: 60})
+	if len(results) != 1 || results[0].Amount != -50 || results[0].Currency != "EUR" || results[0].Type != "TRANSFER" || results[0].Reference == "" || results[0].Counterparty != "Jane Doe" {
+		t.Errorf("unexpected transfer entry: %+v", results)
+	}
+}
@@ -0,0 +1,467 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// Step statuses reported in SendMoneyResult.Steps.
+const (
+	StepDone    = "done"
+	StepSkipped = "skipped"
+	StepFailed  = "failed"
+)
+
+// SendMoneyRequest describes a transfer for SendMoney.
+//
+// Set exactly one of SourceAmount and TargetAmount. The recipient is
+// RecipientID if set, otherwise the recipient in the target currency named
+// RecipientName (or NewRecipient.AccountHolderName). If there is no such
+// recipient and NewRecipient is set, it is created.
+type SendMoneyRequest struct {
+	ProfileID       int64 // Default: the first profile
+	SourceCurrency  string
+	TargetCurrency  string
+	SourceAmount    float64
+	TargetAmount    float64
+	RecipientID     int64
+	RecipientName   string
+	NewRecipient    *wise.CreateRecipientRequest
+	Reference       string
+	TransferPurpose string
+	SourceOfFunds   string
+	// CustomerTransactionID makes creating the transfer idempotent. Default:
+	// a random UUID.
+	CustomerTransactionID string
+	// DryRun creates the quote and looks up the recipient, but does not
+	// create anything that moves money or changes the account.
+	DryRun bool
+}
+
+// SendMoneyStep records the outcome of one step of SendMoney.
+type SendMoneyStep struct {
+	Name   string `json:"name" csv:"name"`     // quote, recipient, requirements, transfer or fund
+	Status string `json:"status" csv:"status"` // StepDone, StepSkipped or StepFailed
+	Detail string `json:"detail" csv:"detail"`
+}
+
+// SendMoneyResult holds the result of SendMoney. On failure, Error is set
+// and Steps shows how far it got; IDs of anything already created are kept.
+type SendMoneyResult struct {
+	DryRun         bool            `json:"dryRun" csv:"dry_run"`
+	ProfileID      int64           `json:"profileId" csv:"profile_id"`
+	QuoteID        string          `json:"quoteId" csv:"quote_id"`
+	RecipientID    int64           `json:"recipientId" csv:"recipient_id"`
+	RecipientName  string          `json:"recipientName" csv:"recipient_name"`
+	TransferID     int64           `json:"transferId" csv:"transfer_id"`
+	Status         string          `json:"status" csv:"status"`
+	SourceCurrency string          `json:"sourceCurrency" csv:"source_currency"`
+	TargetCurrency string          `json:"targetCurrency" csv:"target_currency"`
+	SourceAmount   float64         `json:"sourceAmount" csv:"source_amount"`
+	TargetAmount   float64         `json:"targetAmount" csv:"target_amount"`
+	Rate           float64         `json:"rate" csv:"rate"`
+	Fee            float64         `json:"fee" csv:"fee"`
+	Steps          []SendMoneyStep `json:"steps" csv:"-"`
+	Error          string          `json:"error,omitempty" csv:"error"`
+}
+
+func (r *SendMoneyResult) step(name, status, detail string) {
+	r.Steps = append(r.Steps, SendMoneyStep{Name: name, Status: status, Detail: detail})
+}
+
+func (r *SendMoneyResult) fail(name string, err error) SendMoneyResult {
+	r.step(name, StepFailed, err.Error())
+	r.Error = fmt.Sprintf("%s: %v", name, err)
+	return *r
+}
+
+// SendMoney sends money from a balance to a recipient: it creates a quote,
+// finds or creates the recipient, checks the transfer requirements, then
+// creates and funds the transfer.
+func SendMoney(ctx context.Context, client *wise.Client, req SendMoneyRequest) SendMoneyResult {
+	result := SendMoneyResult{
+		DryRun:         req.DryRun,
+		ProfileID:      req.ProfileID,
+		SourceCurrency: req.SourceCurrency,
+		TargetCurrency: req.TargetCurrency,
+	}
+
+	if req.SourceCurrency == "" || req.TargetCurrency == "" {
+		return result.fail("quote", errors.New("source and target currency are required"))
+	}
+	if (req.SourceAmount > 0) == (req.TargetAmount > 0) {
+		return result.fail("quote", errors.New("set exactly one of source amount and target amount"))
+	}
+
+	if result.ProfileID == 0 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			return result.fail("quote", err)
+		}
+		if len(profiles) == 0 {
+			return result.fail("quote", fmt.Errorf("no profiles found"))
+		}
+		result.ProfileID = profiles[0].ID
+	}
+
+	// Quote
+	quoteReq := &wise.CreateQuoteRequest{
+		SourceCurrency: wise.Currency(req.SourceCurrency),
+		TargetCurrency: wise.Currency(req.TargetCurrency),
+		PreferredPayIn: "BALANCE",
+		TargetAccount:  req.RecipientID,
+	}
+	if req.SourceAmount > 0 {
+		quoteReq.SourceAmount = &req.SourceAmount
+	} else {
+		quoteReq.TargetAmount = &req.TargetAmount
+	}
+	quote, err := client.Quotes.Create(ctx, result.ProfileID, quoteReq)
+	if err != nil {
+		return result.fail("quote", err)
+	}
+	result.setQuote(quote)
+	result.step("quote", StepDone, fmt.Sprintf("%.2f %s -> %.2f %s at %.6f, fee %.2f %s",
+		result.SourceAmount, req.SourceCurrency, result.TargetAmount, req.TargetCurrency, result.Rate, result.Fee, req.SourceCurrency))
+
+	// Recipient
+	recipient, err := findRecipient(ctx, client, result.ProfileID, req)
+	if err != nil {
+		return result.fail("recipient", err)
+	}
+	if recipient == nil {
+		newRecipient := *req.NewRecipient
+		if newRecipient.Profile == 0 {
+			newRecipient.Profile = result.ProfileID
+		}
+		if newRecipient.Currency == "" {
+			newRecipient.Currency = wise.Currency(req.TargetCurrency)
+		}
+		result.RecipientName = newRecipient.AccountHolderName
+		if req.DryRun {
+			result.step("recipient", StepSkipped, "would create recipient "+newRecipient.AccountHolderName)
+			result.step("requirements", StepSkipped, "recipient does not exist yet")
+			result.step("transfer", StepSkipped, "dry run")
+			result.step("fund", StepSkipped, "dry run")
+			return result
+		}
+		if recipient, err = createRecipient(ctx, client, &newRecipient); err != nil {
+			return result.fail("recipient", err)
+		}
+		result.step("recipient", StepDone, fmt.Sprintf("created recipient %d (%s)", recipient.ID, recipient.AccountHolderName))
+	} else {
+		result.step("recipient", StepDone, fmt.Sprintf("using recipient %d (%s)", recipient.ID, recipient.AccountHolderName))
+	}
+	result.RecipientID = recipient.ID
+	result.RecipientName = recipient.AccountHolderName
+
+	// Fees and options can depend on the recipient.
+	if req.RecipientID == 0 {
+		quote, err = client.Quotes.Update(ctx, result.ProfileID, quote.ID, &wise.UpdateQuoteRequest{TargetAccount: recipient.ID})
+		if err != nil {
+			return result.fail("quote", err)
+		}
+		result.setQuote(quote)
+	}
+
+	// Requirements
+	txID := req.CustomerTransactionID
+	if txID == "" {
+		txID = uuid.NewString()
+	}
+	transferReq := &wise.CreateTransferRequest{
+		TargetAccount:         recipient.ID,
+		QuoteUUID:             quote.ID,
+		CustomerTransactionID: txID,
+		Details: wise.TransferDetails{
+			Reference:       req.Reference,
+			TransferPurpose: req.TransferPurpose,
+			SourceOfFunds:   req.SourceOfFunds,
+		},
+	}
+	requirements, err := client.Transfers.GetRequirements(ctx, transferReq)
+	if err != nil {
+		return result.fail("requirements", err)
+	}
+	if missing := missingTransferFields(requirements, transferReq.Details); len(missing) > 0 {
+		return result.fail("requirements", fmt.Errorf("missing required transfer details: %s", strings.Join(missing, ", ")))
+	}
+	result.step("requirements", StepDone, "all required details provided")
+
+	if req.DryRun {
+		result.step("transfer", StepSkipped, "dry run")
+		result.step("fund", StepSkipped, "dry run")
+		return result
+	}
+
+	// Transfer
+	transfer, err := client.Transfers.Create(ctx, transferReq)
+	if err != nil {
+		return result.fail("transfer", err)
+	}
+	result.TransferID = transfer.ID
+	result.Status = string(transfer.Status)
+	result.step("transfer", StepDone, fmt.Sprintf("created transfer %d", transfer.ID))
+
+	// Fund
+	funded, err := client.Transfers.Fund(ctx, result.ProfileID, transfer.ID)
+	if err != nil {
+		return result.fail("fund", err)
+	}
+	if funded.Status != "" {
+		result.Status = string(funded.Status)
+	}
+	result.step("fund", StepDone, fmt.Sprintf("funded from %s balance", req.SourceCurrency))
+	return result
+}
+
+// setQuote copies the amounts of quote, using its balance pay-in option.
+func (r *SendMoneyResult) setQuote(quote *wise.Quote) {
+	r.QuoteID = quote.ID
+	r.Rate = quote.Rate
+	r.SourceAmount, r.TargetAmount, r.Fee = balanceOption(quote)
+}
+
+// balanceOption returns the amounts and fee of the quote's enabled BALANCE
+// pay-in option, or the quote's amounts and no fee if it has none.
+func balanceOption(quote *wise.Quote) (source, target, fee float64) {
+	for _, opt := range quote.PaymentOptions {
+		if opt.PayIn == "BALANCE" && !opt.Disabled {
+			return opt.SourceAmount, opt.TargetAmount, opt.Fee.Total
+		}
+	}
+	return quote.SourceAmount, quote.TargetAmount, 0
+}
+
+// findRecipient returns the recipient for req, or nil if it has to be
+// created from req.NewRecipient.
+func findRecipient(ctx context.Context, client *wise.Client, profileID int64, req SendMoneyRequest) (*wise.Recipient, error) {
+	if req.RecipientID != 0 {
+		recipient, err := client.Recipients.Get(ctx, req.RecipientID)
+		if err != nil {
+			return nil, err
+		}
+		if string(recipient.Currency) != req.TargetCurrency {
+			return nil, fmt.Errorf("recipient %d receives %s, not %s", recipient.ID, recipient.Currency, req.TargetCurrency)
+		}
+		return recipient, nil
+	}
+
+	name := req.RecipientName
+	if name == "" && req.NewRecipient != nil {
+		name = req.NewRecipient.AccountHolderName
+	}
+	if name == "" {
+		return nil, errors.New("no recipient given")
+	}
+
+	recipients, err := client.Recipients.ListAll(ctx, &wise.ListRecipientsParams{
+		ProfileID: profileID,
+		Currency:  wise.Currency(req.TargetCurrency),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var matches []wise.Recipient
+	for _, r := range recipients {
+		if strings.EqualFold(r.AccountHolderName, name) || strings.EqualFold(r.Nickname, name) {
+			matches = append(matches, r)
+		}
+	}
+	switch {
+	case len(matches) == 1:
+		return &matches[0], nil
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%d %s recipients named %q, use a recipient ID", len(matches), req.TargetCurrency, name)
+	case req.NewRecipient == nil:
+		return nil, fmt.Errorf("no %s recipient named %q", req.TargetCurrency, name)
+	}
+	return nil, nil
+}
+
+// missingTransferFields returns the keys of required transfer fields that
+// are not set in details. Keys may be given with a "details." prefix.
+func missingTransferFields(requirements []wise.RecipientRequirements, details wise.TransferDetails) []string {
+	values := map[string]string{
+		"reference":       details.Reference,
+		"transferPurpose": details.TransferPurpose,
+		"sourceOfFunds":   details.SourceOfFunds,
+	}
+	var missing []string
+	for _, req := range requirements {
+		for _, field := range req.Fields {
+			for _, g := range field.Group {
+				key := strings.TrimPrefix(g.Key, "details.")
+				if g.Required && values[key] == "" && !slices.Contains(missing, key) {
+					missing = append(missing, key)
+				}
+			}
+		}
+	}
+	return missing
+}
+
+// TransferFilter selects transfers for ListTransfers. Zero fields match all.
+type TransferFilter struct {
+	ProfileID int64 // Default: all profiles
+	Status    string
+	Since     time.Time
+	Until     time.Time
+}
+
+// TransferResult holds a transfer with its recipient's name.
+type TransferResult struct {
+	ID             int64   `json:"id" csv:"id"`
+	ProfileID      int64   `json:"profileId" csv:"profile_id"`
+	Status         string  `json:"status" csv:"status"`
+	Created        string  `json:"created" csv:"created"`
+	SourceAmount   float64 `json:"sourceAmount" csv:"source_amount"`
+	SourceCurrency string  `json:"sourceCurrency" csv:"source_currency"`
+	TargetAmount   float64 `json:"targetAmount" csv:"target_amount"`
+	TargetCurrency string  `json:"targetCurrency" csv:"target_currency"`
+	Rate           float64 `json:"rate" csv:"rate"`
+	RecipientID    int64   `json:"recipientId" csv:"recipient_id"`
+	RecipientName  string  `json:"recipientName" csv:"recipient_name"`
+	Reference      string  `json:"reference" csv:"reference"`
+}
+
+// ListTransfers fetches all transfers matching filter, newest first as
+// returned by Wise. Recipient names are looked up once per recipient; a
+// recipient that cannot be fetched leaves RecipientName empty.
+func ListTransfers(ctx context.Context, client *wise.Client, filter TransferFilter) ([]TransferResult, error) {
+	profileIDs := []int64{filter.ProfileID}
+	if filter.ProfileID == 0 {
+		profiles, err := client.Profiles.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		profileIDs = profileIDs[:0]
+		for _, p := range profiles {
+			profileIDs = append(profileIDs, p.ID)
+		}
+	}
+
+	params := wise.ListTransfersParams{Status: wise.TransferStatus(filter.Status)}
+	if !filter.Since.IsZero() {
+		params.CreatedDateStart = filter.Since.UTC().Format(time.RFC3339)
+	}
+	if !filter.Until.IsZero() {
+		params.CreatedDateEnd = filter.Until.UTC().Format(time.RFC3339)
+	}
+
+	names := make(map[int64]string)
+	var results []TransferResult
+	for _, profileID := range profileIDs {
+		params.ProfileID = profileID
+		transfers, err := client.Transfers.ListAll(ctx, &params)
+		if err != nil {
+			return nil, fmt.Errorf("profile %d: %w", profileID, err)
+		}
+
+		for _, t := range transfers {
+			name, ok := names[t.TargetAccount]
+			if !ok && t.TargetAccount != 0 {
+				if r, err := client.Recipients.Get(ctx, t.TargetAccount); err == nil {
+					name = r.AccountHolderName
+				}
+				names[t.TargetAccount] = name
+			}
+			results = append(results, transferResult(&t, profileID, name))
+		}
+	}
+	return results, nil
+}
+
+func transferResult(t *wise.Transfer, profileID int64, recipientName string) TransferResult {
+	return TransferResult{
+		ID:             t.ID,
+		ProfileID:      profileID,
+		Status:         string(t.Status),
+		Created:        t.Created.Format("2006-01-02 15:04"),
+		SourceAmount:   t.SourceValue,
+		SourceCurrency: string(t.SourceCurrency),
+		TargetAmount:   t.TargetValue,
+		TargetCurrency: string(t.TargetCurrency),
+		Rate:           t.Rate,
+		RecipientID:    t.TargetAccount,
+		RecipientName:  recipientName,
+		Reference:      t.Reference,
+	}
+}
+
+// TransferStatusResult holds a transfer with what is needed to answer
+// "where is my money": whether it can still be cancelled, its open
+// issues, the delivery estimate and payout tracking.
+type TransferStatusResult struct {
+	TransferResult
+	Cancellable       bool                   `json:"cancellable" csv:"cancellable"`
+	HasActiveIssues   bool                   `json:"hasActiveIssues" csv:"has_active_issues"`
+	EstimatedDelivery string                 `json:"estimatedDelivery,omitempty" csv:"estimated_delivery"`
+	Issues            []wise.TransferIssue   `json:"issues,omitempty" csv:"-"`
+	Tracking          *wise.TransferTracking `json:"tracking,omitempty" csv:"-"`
+}
+
+// GetTransfer fetches a transfer with its recipient's name, issues,
+// delivery estimate and tracking. ProfileID is left 0, as a transfer does
+// not name its profile. Details that cannot be fetched, e.g. tracking
+// before payout, are left empty.
+func GetTransfer(ctx context.Context, client *wise.Client, transferID int64) (TransferStatusResult, error) {
+	t, err := client.Transfers.Get(ctx, transferID)
+	if err != nil {
+		return TransferStatusResult{}, err
+	}
+	name := ""
+	if t.TargetAccount != 0 {
+		if r, err := client.Recipients.Get(ctx, t.TargetAccount); err == nil {
+			name = r.AccountHolderName
+		}
+	}
+	result := TransferStatusResult{
+		TransferResult:  transferResult(t, 0, name),
+		Cancellable:     t.IsCancellable(),
+		HasActiveIssues: t.HasActiveIssues,
+	}
+
+	if issues, err := client.Transfers.GetIssues(ctx, transferID); err == nil {
+		result.Issues = issues
+	}
+	// Once paid out, cancelled or bounced there is nothing to estimate.
+	if !t.Status.IsTerminal() {
+		if eta, err := client.Transfers.GetDeliveryTime(ctx, transferID); err == nil && !eta.IsZero() {
+			result.EstimatedDelivery = eta.Format("2006-01-02 15:04")
+		}
+	}
+	if tracking, err := client.Transfers.GetTracking(ctx, transferID); err == nil {
+		result.Tracking = tracking
+	}
+	return result, nil
+}
+
+// CancelTransfer cancels a transfer after checking it can still be
+// cancelled, so a transfer already paid out is reported without asking
+// Wise to cancel it. The error wraps wise.ErrTransferNotCancellable or
+// wise.ErrTransferAlreadyPaidOut if it cannot.
+func CancelTransfer(ctx context.Context, client *wise.Client, transferID int64) (TransferResult, error) {
+	t, err := client.Transfers.Get(ctx, transferID)
+	if err != nil {
+		return TransferResult{}, err
+	}
+	if !t.IsCancellable() {
+		if t.Status == wise.TransferStatusOutgoingPaymentSent {
+			return transferResult(t, 0, ""), fmt.Errorf("%w: transfer %d", wise.ErrTransferAlreadyPaidOut, transferID)
+		}
+		return transferResult(t, 0, ""), fmt.Errorf("%w: transfer %d is %s", wise.ErrTransferNotCancellable, transferID, t.Status)
+	}
+	cancelled, err := client.Transfers.Cancel(ctx, transferID)
+	if err != nil {
+		return transferResult(t, 0, ""), err
+	}
+	return transferResult(cancelled, 0, ""), nil
+}
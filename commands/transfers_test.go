@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestSendMoney(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	result := SendMoney(ctx, srv.Client(), SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "GBP",
+		SourceAmount:   100,
+		NewRecipient: &wise.CreateRecipientRequest{
+			AccountHolderName: "Jane Doe",
+			Type:              "sort_code",
+			Details:           map[string]interface{}{"sortCode": "231470", "accountNumber": "28821822"},
+		},
+		Reference: "rent",
+	})
+	if result.Error != "" {
+		t.Fatalf("SendMoney failed: %v (steps %+v)", result.Error, result.Steps)
+	}
+	if len(result.Steps) != 5 {
+		t.Errorf("expected 5 steps, got %+v", result.Steps)
+	}
+	if result.Status != string(wise.TransferStatusProcessing) || result.Fee != 0.5 || result.TargetAmount != 84.58 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if len(srv.Recipients()) != 1 || len(srv.Transfers()) != 1 {
+		t.Fatalf("expected 1 recipient and 1 transfer, got %d and %d", len(srv.Recipients()), len(srv.Transfers()))
+	}
+
+	// The second transfer reuses the recipient.
+	again := SendMoney(ctx, srv.Client(), SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "GBP",
+		TargetAmount:   10,
+		RecipientName:  "jane doe",
+	})
+	if again.Error != "" || again.RecipientID != result.RecipientID {
+		t.Errorf("expected recipient %d to be reused, got %+v", result.RecipientID, again)
+	}
+}
+
+func TestSendMoney_DryRun(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+
+	result := SendMoney(context.Background(), srv.Client(), SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "USD",
+		SourceAmount:   50,
+		NewRecipient:   &wise.CreateRecipientRequest{AccountHolderName: "John Smith", Type: "aba"},
+		DryRun:         true,
+	})
+	if result.Error != "" {
+		t.Fatalf("SendMoney failed: %v", result.Error)
+	}
+	if result.QuoteID == "" || result.TransferID != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	for _, step := range result.Steps[1:] {
+		if step.Status != StepSkipped {
+			t.Errorf("step %s: expected skipped, got %s", step.Name, step.Status)
+		}
+	}
+	if len(srv.Recipients()) != 0 || len(srv.Transfers()) != 0 {
+		t.Error("dry run created a recipient or transfer")
+	}
+}
+
+func TestSendMoney_MissingRequirements(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	srv.TransferRequirements = []wise.RecipientRequirements{{
+		Type: "transfer",
+		Fields: []wise.RecipientField{{
+			Name:  "Transfer purpose",
+			Group: []wise.RecipientFieldGroup{{Key: "transferPurpose", Required: true}},
+		}},
+	}}
+	id := srv.AddRecipient(wise.Recipient{AccountHolderName: "Jane Doe", Currency: wise.USD})
+
+	result := SendMoney(context.Background(), srv.Client(), SendMoneyRequest{
+		SourceCurrency: "EUR",
+		TargetCurrency: "USD",
+		SourceAmount:   50,
+		RecipientID:    id,
+	})
+	if result.Error == "" || !strings.Contains(result.Error, "transferPurpose") {
+		t.Fatalf("expected missing transferPurpose error, got %v", result.Error)
+	}
+	if last := result.Steps[len(result.Steps)-1]; last.Name != "requirements" || last.Status != StepFailed {
+		t.Errorf("unexpected last step: %+v", last)
+	}
+	if len(srv.Transfers()) != 0 {
+		t.Error("transfer created despite missing requirements")
+	}
+}
+
+func TestListTransfers(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	id := srv.AddRecipient(wise.Recipient{AccountHolderName: "Jane Doe", Currency: wise.USD})
+	for _, amount := range []float64{10, 20, 30} {
+		if r := SendMoney(ctx, client, SendMoneyRequest{SourceCurrency: "EUR", TargetCurrency: "USD", SourceAmount: amount, RecipientID: id}); r.Error != "" {
+			t.Fatalf("SendMoney failed: %v", r.Error)
+		}
+	}
+	transfers := srv.Transfers()
+	srv.SetTransferStatus(transfers[0].ID, wise.TransferStatusOutgoingPaymentSent)
+
+	all, err := ListTransfers(ctx, client, TransferFilter{})
+	if err != nil {
+		t.Fatalf("ListTransfers failed: %v", err)
+	}
+	if len(all) != 3 || all[0].RecipientName != "Jane Doe" || all[0].ProfileID != wisetest.DefaultProfileID {
+		t.Errorf("unexpected transfers: %+v", all)
+	}
+
+	sent, err := ListTransfers(ctx, client, TransferFilter{Status: string(wise.TransferStatusOutgoingPaymentSent)})
+	if err != nil {
+		t.Fatalf("ListTransfers failed: %v", err)
+	}
+	if len(sent) != 1 || sent[0].ID != transfers[0].ID {
+		t.Errorf("expected transfer %d, got %+v", transfers[0].ID, sent)
+	}
+
+	future, err := ListTransfers(ctx, client, TransferFilter{Since: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("ListTransfers failed: %v", err)
+	}
+	if len(future) != 0 {
+		t.Errorf("expected no transfers, got %d", len(future))
+	}
+}
+
+func TestGetTransferAndCancel(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	client := srv.Client()
+
+	id := srv.AddRecipient(wise.Recipient{AccountHolderName: "Jane Doe", Currency: wise.USD})
+	for _, amount := range []float64{10, 20} {
+		if r := SendMoney(ctx, client, SendMoneyRequest{SourceCurrency: "EUR", TargetCurrency: "USD", SourceAmount: amount, RecipientID: id}); r.Error != "" {
+			t.Fatalf("SendMoney failed: %v", r.Error)
+		}
+	}
+	transfers := srv.Transfers()
+	open, paid := transfers[0].ID, transfers[1].ID
+	srv.SetTransferStatus(paid, wise.TransferStatusOutgoingPaymentSent)
+
+	status, err := GetTransfer(ctx, client, open)
+	if err != nil {
+		t.Fatalf("GetTransfer failed: %v", err)
+	}
+	if !status.Cancellable || status.RecipientName != "Jane Doe" || status.EstimatedDelivery == "" || status.Issues == nil {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if status, _ := GetTransfer(ctx, client, paid); status.Cancellable || status.EstimatedDelivery != "" {
+		t.Errorf("expected a paid out transfer without estimate, got %+v", status)
+	}
+
+	cancelled, err := CancelTransfer(ctx, client, open)
+	if err != nil || cancelled.Status != string(wise.TransferStatusCancelled) {
+		t.Errorf("CancelTransfer = %+v, %v", cancelled, err)
+	}
+	if _, err := CancelTransfer(ctx, client, paid); !errors.Is(err, wise.ErrTransferAlreadyPaidOut) {
+		t.Errorf("expected ErrTransferAlreadyPaidOut, got %v", err)
+	}
+	if _, err := CancelTransfer(ctx, client, open); !errors.Is(err, wise.ErrTransferNotCancellable) {
+		t.Errorf("expected ErrTransferNotCancellable cancelling twice, got %v", err)
+	}
+}
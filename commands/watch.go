@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"math"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// DefaultWatchInterval is how often WatchRates polls when no interval is set.
+const DefaultWatchInterval = time.Minute
+
+// WatchOptions configures WatchRates.
+type WatchOptions struct {
+	Pairs    []CurrencyPair
+	Interval time.Duration // Defaults to DefaultWatchInterval
+	// MinChange is the relative change since the last reported rate needed
+	// to report a pair again, e.g. 0.001 for 0.1%. With 0, every change is
+	// reported.
+	MinChange float64
+}
+
+// RateUpdate is a rate reported by WatchRates.
+type RateUpdate struct {
+	RateResult
+	Previous float64 `json:"previous" csv:"previous"` // Last reported rate, 0 on the first update
+	Change   float64 `json:"change" csv:"change"`     // Relative change since Previous
+	Polled   string  `json:"polled" csv:"polled"`     // When WatchRates fetched the rate, RFC 3339
+}
+
+// WatchRates polls the rates of opts.Pairs until ctx is cancelled and calls
+// fn for each pair whose rate moved by at least opts.MinChange since it was
+// last reported. Every pair is reported on the first poll. A failed fetch
+// is reported with Error set and does not replace the last rate. WatchRates
+// returns ctx.Err().
+func WatchRates(ctx context.Context, client *wise.Client, opts WatchOptions, fn func(RateUpdate)) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	last := make(map[CurrencyPair]float64, len(opts.Pairs))
+	poll := func() {
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, p := range opts.Pairs {
+			if ctx.Err() != nil {
+				return
+			}
+			result := GetRate(ctx, client, p.From, p.To)
+			if result.Error != "" {
+				fn(RateUpdate{RateResult: result, Previous: last[p], Polled: now})
+				continue
+			}
+			prev, seen := last[p]
+			change := 0.0
+			if prev != 0 {
+				change = (result.Rate - prev) / prev
+			}
+			if seen && (result.Rate == prev || math.Abs(change) < opts.MinChange) {
+				continue
+			}
+			last[p] = result.Rate
+			fn(RateUpdate{RateResult: result, Previous: prev, Change: change, Polled: now})
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		poll()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RateUpdates runs WatchRates in a goroutine and sends its updates on the
+// returned channel, which is closed once ctx is cancelled.
+func RateUpdates(ctx context.Context, client *wise.Client, opts WatchOptions) <-chan RateUpdate {
+	ch := make(chan RateUpdate)
+	go func() {
+		defer close(ch)
+		WatchRates(ctx, client, opts, func(u RateUpdate) {
+			select {
+			case ch <- u:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return ch
+}
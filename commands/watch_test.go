@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/wisetest"
+)
+
+func TestParseCurrencyPair(t *testing.T) {
+	for _, s := range []string{"EUR/USD", " eur-usd "} {
+		p, err := ParseCurrencyPair(s)
+		if err != nil {
+			t.Fatalf("ParseCurrencyPair(%q) failed: %v", s, err)
+		}
+		if p != (CurrencyPair{From: "EUR", To: "USD"}) || p.String() != "EUR/USD" {
+			t.Errorf("ParseCurrencyPair(%q) = %v", s, p)
+		}
+	}
+	for _, bad := range []string{"EURUSD", "EUR/", "XXY/USD"} {
+		if _, err := ParseCurrencyPair(bad); err == nil {
+			t.Errorf("ParseCurrencyPair(%q): expected an error", bad)
+		}
+	}
+}
+
+func TestWatchRates(t *testing.T) {
+	srv := wisetest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	opts := WatchOptions{
+		Pairs:     []CurrencyPair{{From: "EUR", To: "USD"}, {From: "GBP", To: "USD"}},
+		Interval:  10 * time.Millisecond,
+		MinChange: 0.01,
+	}
+	updates := RateUpdates(ctx, client, opts)
+
+	// The first poll reports every pair.
+	for range opts.Pairs {
+		if u := <-updates; u.Error != "" || u.Previous != 0 {
+			t.Fatalf("unexpected first update: %+v", u)
+		}
+	}
+
+	// A move below MinChange is not reported, a larger one is.
+	srv.SetRate(wise.GBP, wise.USD, 1.271)
+	srv.SetRate(wise.EUR, wise.USD, 1.10)
+	u := <-updates
+	if u.From != "EUR" || u.Rate != 1.10 || u.Previous != 1.08 {
+		t.Fatalf("unexpected update: %+v", u)
+	}
+	if u.Change < 0.018 || u.Change > 0.019 {
+		t.Errorf("Change = %v, want about 0.0185", u.Change)
+	}
+
+	cancel()
+	for range updates {
+	}
+}
@@ -0,0 +1,106 @@
+package wise
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// ComparisonsService handles the public price comparison API.
+// It compares Wise against banks and other providers for a corridor and amount.
+type ComparisonsService struct {
+	client *Client
+}
+
+// Comparison represents provider pricing for a currency route.
+type Comparison struct {
+	SourceCurrency Currency             `json:"sourceCurrency"`
+	TargetCurrency Currency             `json:"targetCurrency"`
+	SourceCountry  string               `json:"sourceCountry,omitempty"`
+	TargetCountry  string               `json:"targetCountry,omitempty"`
+	Amount         float64              `json:"amount"`
+	AmountType     string               `json:"amountType"` // SEND or RECEIVE
+	Providers      []ComparisonProvider `json:"providers"`
+}
+
+// ComparisonProvider represents one provider (bank or money transfer service) in a comparison.
+type ComparisonProvider struct {
+	ID      int64             `json:"id"`
+	Alias   string            `json:"alias"`
+	Name    string            `json:"name"`
+	Logo    string            `json:"logo,omitempty"`
+	Type    string            `json:"type"`
+	Partner bool              `json:"partner"`
+	Quotes  []ComparisonQuote `json:"quotes"`
+}
+
+// ComparisonQuote is a provider's price for the requested amount.
+type ComparisonQuote struct {
+	Rate               float64             `json:"rate"`
+	Fee                float64             `json:"fee"`
+	ReceivedAmount     float64             `json:"receivedAmount"`
+	Markup             float64             `json:"markup"`
+	DateCollected      Timestamp           `json:"dateCollected"`
+	SourceCountry      string              `json:"sourceCountry,omitempty"`
+	TargetCountry      string              `json:"targetCountry,omitempty"`
+	DeliveryEstimation *DeliveryEstimation `json:"deliveryEstimation,omitempty"`
+}
+
+// DeliveryEstimation is a provider's estimated delivery window.
+type DeliveryEstimation struct {
+	Duration struct {
+		Min string `json:"min,omitempty"` // ISO 8601 duration
+		Max string `json:"max,omitempty"` // ISO 8601 duration
+	} `json:"duration"`
+	ProviderGivesEstimate bool `json:"providerGivesEstimate"`
+}
+
+// ComparisonParams represents the parameters for a price comparison.
+type ComparisonParams struct {
+	SourceCurrency Currency
+	TargetCurrency Currency
+	SendAmount     float64
+	SourceCountry  string // ISO 3166-1 alpha-2, optional
+	TargetCountry  string // ISO 3166-1 alpha-2, optional
+}
+
+// Get returns provider pricing for a currency route and amount.
+// This endpoint is public and does not require authentication.
+// GET /v4/comparisons
+func (s *ComparisonsService) Get(ctx context.Context, params *ComparisonParams) (*Comparison, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.SourceCurrency != "" {
+			query.Set("sourceCurrency", string(params.SourceCurrency))
+		}
+		if params.TargetCurrency != "" {
+			query.Set("targetCurrency", string(params.TargetCurrency))
+		}
+		if params.SendAmount > 0 {
+			query.Set("sendAmount", strconv.FormatFloat(params.SendAmount, 'f', -1, 64))
+		}
+		if params.SourceCountry != "" {
+			query.Set("sourceCountry", params.SourceCountry)
+		}
+		if params.TargetCountry != "" {
+			query.Set("targetCountry", params.TargetCountry)
+		}
+	}
+
+	var comparison Comparison
+	err := s.client.Get(ctx, "/v4/comparisons", query, &comparison)
+	if err != nil {
+		return nil, err
+	}
+	return &comparison, nil
+}
+
+// Provider returns the provider with the given alias (e.g. "wise"), or nil.
+func (c *Comparison) Provider(alias string) *ComparisonProvider {
+	for i := range c.Providers {
+		if c.Providers[i].Alias == alias {
+			return &c.Providers[i]
+		}
+	}
+	return nil
+}
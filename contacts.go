@@ -0,0 +1,100 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ContactsService handles address book API calls.
+// Contacts are the saved recipients shown in the Wise app, with nicknames
+// and ordered by recent activity.
+type ContactsService struct {
+	client *Client
+}
+
+// ContactSort controls the order in which contacts are returned.
+type ContactSort string
+
+const (
+	// ContactSortRecent orders contacts by most recent activity first.
+	ContactSortRecent ContactSort = "RECENT"
+	// ContactSortName orders contacts alphabetically by name.
+	ContactSortName ContactSort = "NAME"
+)
+
+// Contact represents an entry in the profile's address book.
+type Contact struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Nickname       string    `json:"nickname,omitempty"`
+	RecipientID    int64     `json:"accountId,omitempty"` // Recipient (v1/accounts) ID
+	Currency       Currency  `json:"currency,omitempty"`
+	Type           string    `json:"type,omitempty"`
+	AccountSummary string    `json:"accountSummary,omitempty"` // e.g. masked account number
+	AvatarURL      string    `json:"avatarUrl,omitempty"`
+	Email          string    `json:"email,omitempty"`
+	LastUsedAt     Timestamp `json:"lastUsedAt,omitempty"`
+	CreatedAt      Timestamp `json:"createdAt,omitempty"`
+}
+
+// DisplayName returns the nickname if set, otherwise the name.
+func (c *Contact) DisplayName() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Name
+}
+
+// ListContactsParams represents the parameters for listing contacts.
+type ListContactsParams struct {
+	Currency Currency
+	Sort     ContactSort
+	Search   string
+	Limit    int
+	Offset   int
+}
+
+// List returns the contacts in a profile's address book.
+// GET /v2/profiles/{profileId}/contacts
+func (s *ContactsService) List(ctx context.Context, profileID int64, params *ListContactsParams) ([]Contact, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Currency != "" {
+			query.Set("currency", string(params.Currency))
+		}
+		if params.Sort != "" {
+			query.Set("sort", string(params.Sort))
+		}
+		if params.Search != "" {
+			query.Set("search", params.Search)
+		}
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Offset > 0 {
+			query.Set("offset", strconv.Itoa(params.Offset))
+		}
+	}
+
+	var contacts []Contact
+	path := fmt.Sprintf("/v2/profiles/%d/contacts", profileID)
+	err := s.client.Get(ctx, path, query, &contacts)
+	if err != nil {
+		return nil, err
+	}
+	return contacts, nil
+}
+
+// Get retrieves a contact by ID.
+// GET /v2/profiles/{profileId}/contacts/{contactId}
+func (s *ContactsService) Get(ctx context.Context, profileID int64, contactID string) (*Contact, error) {
+	var contact Contact
+	path := fmt.Sprintf("/v2/profiles/%d/contacts/%s", profileID, contactID)
+	err := s.client.Get(ctx, path, nil, &contact)
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
@@ -0,0 +1,86 @@
+package wise
+
+import (
+	"context"
+)
+
+// CurrenciesService handles currency metadata API calls.
+type CurrenciesService struct {
+	client *Client
+}
+
+// CurrencyInfo describes a currency supported by Wise.
+type CurrencyInfo struct {
+	Code                    Currency `json:"code"`
+	Symbol                  string   `json:"symbol"`
+	Name                    string   `json:"name"`
+	CountryKeywords         []string `json:"countryKeywords,omitempty"`
+	SupportsDecimals        bool     `json:"supportsDecimals"`
+	SupportsVolumeDiscounts bool     `json:"supportsVolumeDiscounts,omitempty"`
+}
+
+// DecimalPlaces returns the number of decimal places used for amounts.
+func (c *CurrencyInfo) DecimalPlaces() int {
+	if c.SupportsDecimals {
+		return c.Code.MinorUnits()
+	}
+	return 0
+}
+
+// CurrencyPairs lists the supported routes, keyed by source currency.
+type CurrencyPairs struct {
+	SourceCurrencies []SourceCurrencyRoutes `json:"sourceCurrencies"`
+	Total            int                    `json:"total"`
+}
+
+// SourceCurrencyRoutes lists the currencies that can be sent to from a source currency.
+type SourceCurrencyRoutes struct {
+	CurrencyCode          Currency              `json:"currencyCode"`
+	MaxInvoiceAmount      float64               `json:"maxInvoiceAmount,omitempty"`
+	TargetCurrencies      []TargetCurrencyRoute `json:"targetCurrencies"`
+	TotalTargetCurrencies int                   `json:"totalTargetCurrencies"`
+}
+
+// TargetCurrencyRoute describes a single supported route.
+type TargetCurrencyRoute struct {
+	CurrencyCode              Currency `json:"currencyCode"`
+	MinInvoiceAmount          float64  `json:"minInvoiceAmount,omitempty"`
+	FixedTargetPaymentAllowed bool     `json:"fixedTargetPaymentAllowed"`
+}
+
+// List returns all currencies supported by Wise.
+// GET /v1/currencies
+func (s *CurrenciesService) List(ctx context.Context) ([]CurrencyInfo, error) {
+	var currencies []CurrencyInfo
+	err := s.client.Get(ctx, "/v1/currencies", nil, &currencies)
+	if err != nil {
+		return nil, err
+	}
+	return currencies, nil
+}
+
+// ListPairs returns the supported source and target currency routes.
+// GET /v1/currency-pairs
+func (s *CurrenciesService) ListPairs(ctx context.Context) (*CurrencyPairs, error) {
+	var pairs CurrencyPairs
+	err := s.client.Get(ctx, "/v1/currency-pairs", nil, &pairs)
+	if err != nil {
+		return nil, err
+	}
+	return &pairs, nil
+}
+
+// CanSend reports whether money can be sent from source to target.
+func (p *CurrencyPairs) CanSend(source, target Currency) bool {
+	for _, s := range p.SourceCurrencies {
+		if s.CurrencyCode != source {
+			continue
+		}
+		for _, t := range s.TargetCurrencies {
+			if t.CurrencyCode == target {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package wise
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxDumpBody caps how much of each body is written by WithDebugDump.
+const maxDumpBody = 64 << 10
+
+// redactedHeaders are replaced with "REDACTED" in debug dumps.
+var redactedHeaders = []string{"Authorization", "X-Signature", "Cookie", "Set-Cookie"}
+
+// debugDump writes request/response traces for WithDebugDump.
+type debugDump struct {
+	mu  sync.Mutex
+	w   io.Writer
+	dir string
+	seq int
+}
+
+// WithDebugDump writes a trace of every request and response to w, with
+// credentials redacted, for attaching to bug reports and support tickets.
+// Bodies are truncated to 64KB. Bodies are not redacted and may contain
+// personal or bank details, so review dumps before sharing them.
+func WithDebugDump(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.dump = &debugDump{w: w}
+	}
+}
+
+// WithDebugDumpDir is like WithDebugDump, writing each exchange to its own
+// numbered file in dir.
+func WithDebugDumpDir(dir string) ClientOption {
+	return func(c *Client) {
+		c.dump = &debugDump{dir: dir}
+	}
+}
+
+// record writes a request and its response, or the error that prevented
+// one. The response body is read up to the dump limit and then restored.
+func (d *debugDump) record(req *http.Request, body []byte, resp *http.Response, err error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- %s\n%s %s\n", time.Now().UTC().Format(time.RFC3339), req.Method, req.URL)
+	writeDumpHeader(&buf, req.Header)
+	writeDumpBody(&buf, body)
+
+	if err != nil {
+		fmt.Fprintf(&buf, "\nerror: %v\n", err)
+	} else {
+		fmt.Fprintf(&buf, "\n%s %s\n", resp.Proto, resp.Status)
+		writeDumpHeader(&buf, resp.Header)
+		head, _ := io.ReadAll(io.LimitReader(resp.Body, maxDumpBody+1))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+		writeDumpBody(&buf, head)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seq++
+	if d.dir == "" {
+		d.w.Write(buf.Bytes())
+		return
+	}
+	if err := os.MkdirAll(d.dir, 0o700); err == nil {
+		name := fmt.Sprintf("%04d-%s.txt", d.seq, req.Method)
+		os.WriteFile(filepath.Join(d.dir, name), buf.Bytes(), 0o600)
+	}
+}
+
+func writeDumpHeader(buf *bytes.Buffer, header http.Header) {
+	h := header.Clone()
+	for _, k := range redactedHeaders {
+		if h.Get(k) != "" {
+			h.Set(k, "REDACTED")
+		}
+	}
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range h[k] {
+			fmt.Fprintf(buf, "%s: %s\n", k, v)
+		}
+	}
+}
+
+func writeDumpBody(buf *bytes.Buffer, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	buf.WriteString("\n")
+	if len(body) > maxDumpBody {
+		buf.Write(body[:maxDumpBody])
+		buf.WriteString("\n[truncated]\n")
+		return
+	}
+	buf.Write(body)
+	buf.WriteString("\n")
+}
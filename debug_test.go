@@ -0,0 +1,41 @@
+package wise
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithDebugDump(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 5, "type": "BUSINESS"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := NewClient("secret-token", WithBaseURL(server.URL), WithDebugDump(&buf))
+	profile, err := client.Profiles.Get(context.Background(), 5)
+	if err != nil || profile.ID != 5 {
+		t.Fatalf("Expected the response to decode after dumping, got %v, %v", profile, err)
+	}
+
+	dump := buf.String()
+	if strings.Contains(dump, "secret-token") || !strings.Contains(dump, "Authorization: REDACTED") {
+		t.Errorf("Expected the token to be redacted:\n%s", dump)
+	}
+	if !strings.Contains(dump, "GET "+server.URL+"/v1/profiles/5") || !strings.Contains(dump, `"type": "BUSINESS"`) {
+		t.Errorf("Expected the request and response in the dump:\n%s", dump)
+	}
+
+	dir := t.TempDir()
+	client = NewClient("secret-token", WithBaseURL(server.URL), WithDebugDumpDir(dir))
+	client.Profiles.Get(context.Background(), 5)
+	client.Profiles.Get(context.Background(), 5)
+	if files, _ := os.ReadDir(dir); len(files) != 2 {
+		t.Errorf("Expected a file per exchange, got %d", len(files))
+	}
+}
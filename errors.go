@@ -1,6 +1,26 @@
 package wise
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for common business failures. API errors match them with
+// errors.Is, based on the well-known Wise error codes and field paths in the
+// response:
+//
+//	if errors.Is(err, wise.ErrInsufficientFunds) { ... }
+var (
+	ErrQuoteExpired      = errors.New("wise: quote expired")
+	ErrInsufficientFunds = errors.New("wise: insufficient funds")
+	ErrRecipientInvalid  = errors.New("wise: recipient account is invalid")
+	ErrSCARequired       = errors.New("wise: strong customer authentication required")
+)
 
 // APIError represents an error returned by the Wise API.
 type APIError struct {
@@ -8,6 +28,14 @@ type APIError struct {
 	Type       string           `json:"type,omitempty"`
 	Message    string           `json:"message,omitempty"`
 	Errors     []ValidationError `json:"errors,omitempty"`
+
+	RequestID  string        `json:"-"` // X-Request-Id header, for Wise support
+	RetryAfter time.Duration `json:"-"` // Parsed Retry-After header, zero if absent
+	Body       []byte        `json:"-"` // Raw response body
+
+	// ApprovalToken is the one-time token to sign when the API asks for Strong
+	// Customer Authentication (x-2fa-approval header); see WithSCAPrivateKey.
+	ApprovalToken string `json:"-"`
 }
 
 // ValidationError represents a validation error from the API.
@@ -17,12 +45,106 @@ type ValidationError struct {
 	Path    string `json:"path,omitempty"`
 }
 
+// newAPIError builds an APIError from an error response and its body.
+// Bodies that are not JSON are used as the message.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	var apiErr APIError
+	if err := json.Unmarshal(body, &apiErr); err != nil {
+		apiErr = APIError{Message: string(body)}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.RequestID = resp.Header.Get("X-Request-Id")
+	apiErr.RetryAfter, _ = parseRetryAfter(resp.Header.Get("Retry-After"))
+	apiErr.Body = body
+	apiErr.ApprovalToken = resp.Header.Get("x-2fa-approval")
+	return &apiErr
+}
+
 // Error implements the error interface.
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("wise: API error (status %d): %s", e.StatusCode, e.Message)
 	if len(e.Errors) > 0 {
-		return fmt.Sprintf("wise: API error (status %d): %s - %v", e.StatusCode, e.Message, e.Errors)
+		msg += fmt.Sprintf(" - %v", e.Errors)
 	}
-	return fmt.Sprintf("wise: API error (status %d): %s", e.StatusCode, e.Message)
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" (request %s)", e.RequestID)
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the business failure sentinels,
+// so that errors.Is works on API errors without string matching.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrSCARequired:
+		return e.StatusCode == http.StatusForbidden && e.ApprovalToken != ""
+	}
+	if e.StatusCode < 400 || e.StatusCode >= 500 {
+		return false
+	}
+
+	switch target {
+	case ErrQuoteExpired:
+		return e.hasCode(quoteExpiredCodes)
+	case ErrInsufficientFunds:
+		return e.hasCode(insufficientFundsCodes)
+	case ErrRecipientInvalid:
+		return e.hasCode(recipientInvalidCodes) || e.hasPath(recipientPaths)
+	}
+	return false
+}
+
+// Error codes, lower-cased, that the API returns as the type or a
+// validation error code for each business failure.
+var (
+	quoteExpiredCodes = []string{
+		"quote.expired", "error.quote.expired", "quote_expired", "quote.status.expired",
+	}
+	insufficientFundsCodes = []string{
+		"balance.insufficient", "insufficient.funds", "error.insufficient.funds",
+		"insufficient_funds", "transfer.insufficient_funds",
+	}
+	recipientInvalidCodes = []string{
+		"recipient.invalid", "error.recipient.invalid", "recipient_invalid",
+		"targetaccount.invalid", "error.targetaccount.invalid",
+	}
+	balanceNotEmptyCodes = []string{
+		"balance.not.empty", "balance.not_empty", "error.balance.not.empty",
+		"balance_not_empty", "balance.has.funds",
+	}
+	transferPaidOutCodes = []string{
+		"transfer.paid_out", "transfer.paid.out", "error.transfer.paid.out",
+		"transfer.already.paid.out", "transfer.outgoing_payment_sent",
+	}
+)
+
+// recipientPaths are the request fields, lower-cased, whose validation
+// errors mean the recipient account is invalid.
+var recipientPaths = []string{"targetaccount", "accountholdername"}
+
+// hasCode reports whether the type or any validation error code is one of
+// codes, ignoring case.
+func (e *APIError) hasCode(codes []string) bool {
+	if slices.Contains(codes, strings.ToLower(e.Type)) {
+		return true
+	}
+	for _, v := range e.Errors {
+		if slices.Contains(codes, strings.ToLower(v.Code)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPath reports whether any validation error is about one of paths,
+// ignoring case.
+func (e *APIError) hasPath(paths []string) bool {
+	for _, v := range e.Errors {
+		if slices.Contains(paths, strings.ToLower(v.Path)) {
+			return true
+		}
+	}
+	return false
 }
 
 // IsNotFound returns true if the error is a 404 Not Found error.
@@ -44,3 +166,57 @@ func (e *APIError) IsForbidden() bool {
 func (e *APIError) IsRateLimited() bool {
 	return e.StatusCode == 429
 }
+
+// IsServerError returns true if the error is a 5xx error.
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500
+}
+
+// AsAPIError returns the APIError in err's chain, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsNotFound returns true if err wraps a 404 Not Found APIError.
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.IsNotFound()
+}
+
+// IsUnauthorized returns true if err wraps a 401 Unauthorized APIError.
+func IsUnauthorized(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.IsUnauthorized()
+}
+
+// IsForbidden returns true if err wraps a 403 Forbidden APIError.
+func IsForbidden(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.IsForbidden()
+}
+
+// IsRateLimited returns true if err wraps a 429 Too Many Requests APIError.
+func IsRateLimited(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.IsRateLimited()
+}
+
+// IsServerError returns true if err wraps a 5xx APIError.
+func IsServerError(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.IsServerError()
+}
+
+// RetryAfter returns how long the API asked the caller to wait before
+// retrying, if err wraps an APIError with a Retry-After header.
+func RetryAfter(err error) (time.Duration, bool) {
+	apiErr, ok := AsAPIError(err)
+	if !ok || apiErr.RetryAfter == 0 {
+		return 0, false
+	}
+	return apiErr.RetryAfter, true
+}
@@ -0,0 +1,69 @@
+package wise
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    *APIError
+		target error
+	}{
+		{"quote expired", &APIError{StatusCode: 422, Errors: []ValidationError{{Code: "error.quote.expired"}}}, ErrQuoteExpired},
+		{"insufficient funds", &APIError{StatusCode: 422, Errors: []ValidationError{{Code: "balance.insufficient", Message: "Insufficient funds"}}}, ErrInsufficientFunds},
+		{"recipient not found", &APIError{StatusCode: 422, Errors: []ValidationError{{Code: "targetAccount.invalid"}}}, ErrRecipientInvalid},
+		{"recipient invalid", &APIError{StatusCode: 400, Errors: []ValidationError{{Code: "NOT_VALID", Path: "targetAccount"}}}, ErrRecipientInvalid},
+		{"sca required", &APIError{StatusCode: 403, ApprovalToken: "ott"}, ErrSCARequired},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("creating transfer: %w", tt.err)
+		if !errors.Is(err, tt.target) {
+			t.Errorf("%s: expected errors.Is(%v) to match %v", tt.name, err, tt.target)
+		}
+		for _, other := range []error{ErrQuoteExpired, ErrInsufficientFunds, ErrRecipientInvalid, ErrSCARequired} {
+			if other != tt.target && errors.Is(err, other) {
+				t.Errorf("%s: unexpectedly matched %v", tt.name, other)
+			}
+		}
+	}
+
+	if errors.Is(&APIError{StatusCode: 500, Message: "insufficient capacity"}, ErrInsufficientFunds) {
+		t.Error("Server errors should not match business failures")
+	}
+	if errors.Is(&APIError{StatusCode: 403, Type: "insufficient_scope", Message: "Insufficient scope"}, ErrInsufficientFunds) {
+		t.Error("insufficient_scope should not match ErrInsufficientFunds")
+	}
+	if errors.Is(&APIError{StatusCode: 400, Message: "Source account is invalid"}, ErrRecipientInvalid) {
+		t.Error("Messages alone should not match ErrRecipientInvalid")
+	}
+}
+
+func TestCancelError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *APIError
+		want error
+	}{
+		{"paid out", &APIError{StatusCode: 409, Errors: []ValidationError{{Code: "transfer.paid_out"}}}, ErrTransferAlreadyPaidOut},
+		{"paid out type", &APIError{StatusCode: 422, Type: "TRANSFER.PAID_OUT"}, ErrTransferAlreadyPaidOut},
+		{"not cancellable", &APIError{StatusCode: 409, Errors: []ValidationError{{Code: "transfer.not.cancellable"}}}, ErrTransferNotCancellable},
+		{"message only", &APIError{StatusCode: 422, Message: "Transfer has already been paid out"}, ErrTransferNotCancellable},
+	}
+	for _, tt := range tests {
+		err := cancelError(tt.err)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, err)
+		}
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: expected the APIError to be wrapped, got %v", tt.name, err)
+		}
+	}
+
+	serverErr := &APIError{StatusCode: 502, Errors: []ValidationError{{Code: "transfer.paid_out"}}}
+	if err := cancelError(serverErr); errors.Is(err, ErrTransferNotCancellable) || errors.Is(err, ErrTransferAlreadyPaidOut) {
+		t.Errorf("expected a server error to be returned unchanged, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package wise
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WebhookEvent is the envelope Wise sends to webhook subscribers.
+// Payload holds the typed event data for known event types
+// (*TransferStateChangeEvent, *BalanceCreditEvent, *BalanceUpdateEvent);
+// for other event types it is nil and Data can be decoded by the caller.
+type WebhookEvent struct {
+	Data           json.RawMessage `json:"data"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventType      string          `json:"event_type"`
+	SchemaVersion  string          `json:"schema_version"`
+	SentAt         Timestamp       `json:"sent_at"`
+	Payload        interface{}     `json:"-"`
+}
+
+// WebhookResource identifies the resource an event refers to.
+type WebhookResource struct {
+	Type      string `json:"type"`
+	ID        int64  `json:"id"`
+	ProfileID int64  `json:"profile_id"`
+	AccountID int64  `json:"account_id,omitempty"`
+}
+
+// TransferStateChangeEvent is the payload of a transfers#state-change event.
+type TransferStateChangeEvent struct {
+	Resource      WebhookResource `json:"resource"`
+	CurrentState  TransferStatus  `json:"current_state"`
+	PreviousState TransferStatus  `json:"previous_state"`
+	OccurredAt    Timestamp       `json:"occurred_at"`
+}
+
+// BalanceCreditEvent is the payload of a balances#credit event.
+type BalanceCreditEvent struct {
+	Resource                     WebhookResource `json:"resource"`
+	TransactionType              string          `json:"transaction_type"`
+	Amount                       float64         `json:"amount"`
+	Currency                     Currency        `json:"currency"`
+	PostTransactionBalanceAmount float64         `json:"post_transaction_balance_amount"`
+	OccurredAt                   Timestamp       `json:"occurred_at"`
+}
+
+// BalanceUpdateEvent is the payload of a balances#update event.
+type BalanceUpdateEvent struct {
+	Resource                     WebhookResource `json:"resource"`
+	Amount                       float64         `json:"amount"`
+	BalanceID                    int64           `json:"balance_id"`
+	ChannelName                  string          `json:"channel_name,omitempty"`
+	Currency                     Currency        `json:"currency"`
+	TransactionType              string          `json:"transaction_type"` // credit or debit
+	OccurredAt                   Timestamp       `json:"occurred_at"`
+	TransferReference            string          `json:"transfer_reference,omitempty"`
+	StepID                       int64           `json:"step_id,omitempty"`
+	PostTransactionBalanceAmount float64         `json:"post_transaction_balance_amount"`
+}
+
+// ParseWebhookEvent decodes a webhook request body into a WebhookEvent
+// with a typed Payload for known event types.
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("parsing webhook envelope: %w", err)
+	}
+
+	var payload interface{}
+	switch event.EventType {
+	case EventTransferStateChange:
+		payload = &TransferStateChangeEvent{}
+	case EventBalanceCredit:
+		payload = &BalanceCreditEvent{}
+	case EventBalanceUpdate:
+		payload = &BalanceUpdateEvent{}
+	default:
+		return &event, nil
+	}
+
+	if err := json.Unmarshal(event.Data, payload); err != nil {
+		return nil, fmt.Errorf("parsing %s payload: %w", event.EventType, err)
+	}
+	event.Payload = payload
+
+	return &event, nil
+}
@@ -0,0 +1,77 @@
+package wise
+
+import "testing"
+
+func TestParseWebhookEvent_TransferStateChange(t *testing.T) {
+	body := []byte(`{
+		"data": {
+			"resource": {"type": "transfer", "id": 111, "profile_id": 222, "account_id": 333},
+			"current_state": "outgoing_payment_sent",
+			"previous_state": "processing",
+			"occurred_at": "2024-01-02T10:00:00Z"
+		},
+		"subscription_id": "sub-1",
+		"event_type": "transfers#state-change",
+		"schema_version": "2.0.0",
+		"sent_at": "2024-01-02T10:00:01Z"
+	}`)
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent failed: %v", err)
+	}
+
+	payload, ok := event.Payload.(*TransferStateChangeEvent)
+	if !ok {
+		t.Fatalf("Expected *TransferStateChangeEvent, got %T", event.Payload)
+	}
+	if payload.Resource.ID != 111 {
+		t.Errorf("Wrong resource id: %d", payload.Resource.ID)
+	}
+	if payload.CurrentState != TransferStatusOutgoingPaymentSent {
+		t.Errorf("Wrong current state: %s", payload.CurrentState)
+	}
+	if payload.PreviousState != TransferStatusProcessing {
+		t.Errorf("Wrong previous state: %s", payload.PreviousState)
+	}
+}
+
+func TestParseWebhookEvent_BalanceCredit(t *testing.T) {
+	body := []byte(`{
+		"data": {
+			"resource": {"type": "balance-account", "id": 1, "profile_id": 2},
+			"transaction_type": "credit",
+			"amount": 12.5,
+			"currency": "EUR",
+			"post_transaction_balance_amount": 100.25,
+			"occurred_at": "2024-01-02T10:00:00Z"
+		},
+		"event_type": "balances#credit"
+	}`)
+
+	event, err := ParseWebhookEvent(body)
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent failed: %v", err)
+	}
+
+	payload, ok := event.Payload.(*BalanceCreditEvent)
+	if !ok {
+		t.Fatalf("Expected *BalanceCreditEvent, got %T", event.Payload)
+	}
+	if payload.Amount != 12.5 || payload.Currency != EUR {
+		t.Errorf("Wrong amount: %f %s", payload.Amount, payload.Currency)
+	}
+}
+
+func TestParseWebhookEvent_Unknown(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(`{"data": {"foo": 1}, "event_type": "cards#something"}`))
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent failed: %v", err)
+	}
+	if event.Payload != nil {
+		t.Errorf("Expected nil payload for unknown event, got %T", event.Payload)
+	}
+	if len(event.Data) == 0 {
+		t.Error("Expected raw data to be preserved")
+	}
+}
@@ -0,0 +1,79 @@
+// Package export converts Wise balance statements to the OFX and QIF files
+// read by personal finance and accounting tools such as GnuCash and
+// Banktivity.
+//
+// The writers take one statement entry at a time, so a statement can be
+// streamed from BalancesService.StreamStatement without holding it in
+// memory:
+//
+//	stream, err := client.Balances.StreamStatement(ctx, profileID, balanceID, wise.EUR, start, end)
+//	...
+//	w := export.NewOFXWriter(file, export.Account{ID: "12345", Currency: wise.EUR, Start: t0, End: t1})
+//	for entry, err := range stream.All() {
+//		...
+//		w.Write(entry)
+//	}
+//	err = w.Close()
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// Account identifies the balance a statement belongs to.
+type Account struct {
+	ID       string // e.g. the balance ID
+	Currency wise.Currency
+	Start    time.Time // Statement period
+	End      time.Time
+}
+
+// Writer writes statement entries in a file format. Close must be called
+// to finish the file; it does not close the underlying io.Writer. Errors
+// writing to the io.Writer are returned by Close.
+type Writer interface {
+	Write(entry wise.BalanceStatement) error
+	Close() error
+}
+
+// Formats supported by NewWriter.
+const (
+	FormatOFX = "ofx"
+	FormatQIF = "qif"
+)
+
+// NewWriter returns a Writer for format, FormatOFX or FormatQIF.
+func NewWriter(format string, w io.Writer, account Account) (Writer, error) {
+	switch format {
+	case FormatOFX:
+		return NewOFXWriter(w, account), nil
+	case FormatQIF:
+		return NewQIFWriter(w, account), nil
+	}
+	return nil, fmt.Errorf("export: unknown format %q", format)
+}
+
+// FormatAmount formats v with the minor units of currency, e.g. 2 for EUR.
+func FormatAmount(v float64, currency wise.Currency) string {
+	return strconv.FormatFloat(v, 'f', currency.MinorUnits(), 64)
+}
+
+// description returns the best available description of an entry.
+func description(entry wise.BalanceStatement) string {
+	switch {
+	case entry.Details.Description != "":
+		return entry.Details.Description
+	case entry.Details.SenderName != "":
+		return entry.Details.SenderName
+	case entry.Details.Recipient != nil && entry.Details.Recipient.Name != "":
+		return entry.Details.Recipient.Name
+	case entry.Details.Merchant != nil && entry.Details.Merchant.Name != "":
+		return entry.Details.Merchant.Name
+	}
+	return entry.Details.Type
+}
@@ -0,0 +1,112 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+var (
+	start   = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end     = time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	entries = []wise.BalanceStatement{
+		{
+			Type:            "CREDIT",
+			Date:            wise.Timestamp{Time: time.Date(2024, 3, 2, 9, 30, 0, 0, time.UTC)},
+			Amount:          wise.Money{Value: 250, Currency: wise.EUR},
+			Details:         wise.StatementDetails{Type: "DEPOSIT", SenderName: "ACME <Payroll> & Co", PaymentReference: "March\nsalary"},
+			RunningBalance:  wise.Money{Value: 1250, Currency: wise.EUR},
+			ReferenceNumber: "TRANSFER-1",
+		},
+		{
+			Type:            "DEBIT",
+			Date:            wise.Timestamp{Time: time.Date(2024, 3, 5, 18, 0, 0, 0, time.UTC)},
+			Amount:          wise.Money{Value: -12.5, Currency: wise.EUR},
+			TotalFees:       wise.Money{Value: 0.5, Currency: wise.EUR},
+			Details:         wise.StatementDetails{Type: "CARD", Description: "Card transaction of 12.50 EUR issued by Cafe Central Vienna"},
+			RunningBalance:  wise.Money{Value: 1237.5, Currency: wise.EUR},
+			ReferenceNumber: "CARD-2",
+		},
+	}
+	account = Account{ID: "42", Currency: wise.EUR, Start: start, End: end}
+)
+
+func TestOFXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewOFXWriter(&buf, account)
+	for _, e := range entries {
+		if err := w.Write(e); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	var ofx struct {
+		Statement struct {
+			Currency     string `xml:"CURDEF"`
+			AccountID    string `xml:"BANKACCTFROM>ACCTID"`
+			Start        string `xml:"BANKTRANLIST>DTSTART"`
+			Transactions []struct {
+				Type   string `xml:"TRNTYPE"`
+				Posted string `xml:"DTPOSTED"`
+				Amount string `xml:"TRNAMT"`
+				FITID  string `xml:"FITID"`
+				Name   string `xml:"NAME"`
+				Memo   string `xml:"MEMO"`
+			} `xml:"BANKTRANLIST>STMTTRN"`
+			Balance string `xml:"LEDGERBAL>BALAMT"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &ofx); err != nil {
+		t.Fatalf("OFX is not valid XML: %v\n%s", err, buf.String())
+	}
+	st := ofx.Statement
+	if st.Currency != "EUR" || st.AccountID != "42" || st.Start != "20240301000000[0:GMT]" || st.Balance != "1237.50" {
+		t.Errorf("unexpected statement: %+v", st)
+	}
+	if len(st.Transactions) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(st.Transactions))
+	}
+	credit, debit := st.Transactions[0], st.Transactions[1]
+	if credit.Type != "CREDIT" || credit.Amount != "250.00" || credit.Name != "ACME <Payroll> & Co" || credit.FITID != "TRANSFER-1" || credit.Memo != "March\nsalary" {
+		t.Errorf("unexpected credit: %+v", credit)
+	}
+	if debit.Type != "DEBIT" || debit.Amount != "-12.50" || len(debit.Name) != ofxNameMax || debit.Posted != "20240305180000[0:GMT]" {
+		t.Errorf("unexpected debit: %+v", debit)
+	}
+}
+
+func TestQIFWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatQIF, &buf, account)
+	if err != nil {
+		t.Fatalf("NewWriter failed: %v", err)
+	}
+	for _, e := range entries {
+		w.Write(e)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"!Type:Bank",
+		"D03/02/2024", "T250.00", "PACME <Payroll> & Co", "MMarch salary", "NTRANSFER-1", "^",
+		"D03/05/2024", "T-12.50", "PCard transaction of 12.50 EUR issued by Cafe Central Vienna", "NCARD-2", "^",
+	}, "\n") + "\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	if _, err := NewWriter("xls", &bytes.Buffer{}, account); err == nil {
+		t.Error("expected an error")
+	}
+}
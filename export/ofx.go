@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// ofxTime is the OFX date format, in UTC.
+const ofxTime = "20060102150405[0:GMT]"
+
+// ofxNameMax is the maximum length of the OFX NAME element.
+const ofxNameMax = 32
+
+// OFXWriter writes an OFX 2.2 bank statement.
+type OFXWriter struct {
+	w       *bufio.Writer
+	account Account
+	started bool
+	n       int
+	balance wise.Money
+	last    time.Time
+}
+
+// NewOFXWriter returns a writer of an OFX statement for account.
+func NewOFXWriter(w io.Writer, account Account) *OFXWriter {
+	return &OFXWriter{w: bufio.NewWriter(w), account: account}
+}
+
+// Write adds a statement entry as a transaction.
+func (o *OFXWriter) Write(entry wise.BalanceStatement) error {
+	o.start()
+	o.n++
+
+	typ := "CREDIT"
+	if entry.Amount.Value < 0 {
+		typ = "DEBIT"
+	}
+	fitID := entry.ReferenceNumber
+	if fitID == "" {
+		fitID = fmt.Sprintf("%s-%d", entry.Date.UTC().Format("20060102150405"), o.n)
+	}
+	name := description(entry)
+	if r := []rune(name); len(r) > ofxNameMax {
+		name = string(r[:ofxNameMax])
+	}
+
+	fmt.Fprintf(o.w, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME>",
+		typ, entry.Date.UTC().Format(ofxTime), FormatAmount(entry.Amount.Value, o.currency(entry)), escape(fitID), escape(name))
+	if memo := entry.Details.PaymentReference; memo != "" {
+		fmt.Fprintf(o.w, "<MEMO>%s</MEMO>", escape(memo))
+	}
+	o.w.WriteString("</STMTTRN>\n")
+
+	if entry.RunningBalance.Currency != "" || entry.RunningBalance.Value != 0 {
+		o.balance = entry.RunningBalance
+		o.last = entry.Date.Time
+	}
+	return nil
+}
+
+// Close writes the ledger balance, taken from the running balance of the
+// last entry, and the end of the file.
+func (o *OFXWriter) Close() error {
+	o.start()
+	asOf := o.last
+	if asOf.IsZero() {
+		asOf = o.account.End
+	}
+	fmt.Fprintf(o.w, "</BANKTRANLIST>\n<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n",
+		FormatAmount(o.balance.Value, o.account.Currency), asOf.UTC().Format(ofxTime))
+	o.w.WriteString("</STMTRS>\n</STMTTRNRS>\n</BANKMSGSRSV1>\n</OFX>\n")
+	return o.w.Flush()
+}
+
+// start writes the header once.
+func (o *OFXWriter) start() {
+	if o.started {
+		return
+	}
+	o.started = true
+
+	o.w.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	o.w.WriteString(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n")
+	o.w.WriteString("<OFX>\n")
+	fmt.Fprintf(o.w, "<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE></SONRS></SIGNONMSGSRSV1>\n",
+		time.Now().UTC().Format(ofxTime))
+	o.w.WriteString("<BANKMSGSRSV1>\n<STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(o.w, "<STMTRS><CURDEF>%s</CURDEF>\n", escape(string(o.account.Currency)))
+	fmt.Fprintf(o.w, "<BANKACCTFROM><BANKID>WISE</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", escape(o.account.ID))
+	fmt.Fprintf(o.w, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n",
+		o.account.Start.UTC().Format(ofxTime), o.account.End.UTC().Format(ofxTime))
+}
+
+func (o *OFXWriter) currency(entry wise.BalanceStatement) wise.Currency {
+	if entry.Amount.Currency != "" {
+		return entry.Amount.Currency
+	}
+	return o.account.Currency
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,67 @@
+package export
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// qifDate is the QIF date format. Quicken's US format is the one most
+// importers expect.
+const qifDate = "01/02/2006"
+
+// QIFWriter writes a QIF bank account file.
+type QIFWriter struct {
+	w       *bufio.Writer
+	account Account
+	started bool
+}
+
+// NewQIFWriter returns a writer of a QIF file for account.
+func NewQIFWriter(w io.Writer, account Account) *QIFWriter {
+	return &QIFWriter{w: bufio.NewWriter(w), account: account}
+}
+
+// Write adds a statement entry as a transaction.
+func (q *QIFWriter) Write(entry wise.BalanceStatement) error {
+	q.start()
+
+	currency := entry.Amount.Currency
+	if currency == "" {
+		currency = q.account.Currency
+	}
+	q.line('D', entry.Date.UTC().Format(qifDate))
+	q.line('T', FormatAmount(entry.Amount.Value, currency))
+	q.line('P', description(entry))
+	if memo := entry.Details.PaymentReference; memo != "" {
+		q.line('M', memo)
+	}
+	if entry.ReferenceNumber != "" {
+		q.line('N', entry.ReferenceNumber)
+	}
+	q.w.WriteString("^\n")
+	return nil
+}
+
+// Close flushes the file.
+func (q *QIFWriter) Close() error {
+	q.start()
+	return q.w.Flush()
+}
+
+func (q *QIFWriter) start() {
+	if !q.started {
+		q.started = true
+		q.w.WriteString("!Type:Bank\n")
+	}
+}
+
+// line writes a QIF field. Line breaks would start a new field, so they
+// are replaced with spaces.
+func (q *QIFWriter) line(code byte, value string) {
+	q.w.WriteByte(code)
+	q.w.WriteString(strings.NewReplacer("\r", " ", "\n", " ").Replace(value))
+	q.w.WriteByte('\n')
+}
@@ -0,0 +1,107 @@
+package wise
+
+import (
+	"context"
+	"io"
+)
+
+// The service interfaces let code depend on a subset of the API and be
+// tested with fakes, see the wisemock package. The concrete services on
+// Client implement them.
+
+// ProfilesAPI is the interface implemented by ProfilesService.
+type ProfilesAPI interface {
+	List(ctx context.Context) ([]Profile, error)
+	Get(ctx context.Context, profileID int64) (*Profile, error)
+	CreatePersonal(ctx context.Context, details *PersonalProfile) (*Profile, error)
+	CreateBusiness(ctx context.Context, details *BusinessProfile) (*Profile, error)
+	UpdatePersonal(ctx context.Context, profileID int64, details *PersonalProfile) (*Profile, error)
+	UpdateBusiness(ctx context.Context, profileID int64, details *BusinessProfile) (*Profile, error)
+	GetVerificationStatus(ctx context.Context, profileID int64) (*VerificationStatus, error)
+	GetRequiredEvidences(ctx context.Context, profileID int64) ([]string, error)
+	ListKYCReviews(ctx context.Context, profileID int64) ([]KYCReview, error)
+	AddIdentificationDocument(ctx context.Context, profileID int64, doc *IdentificationDocument) error
+	UploadVerificationDocument(ctx context.Context, profileID int64, documentType, fileName string, content io.Reader) (*VerificationDocument, error)
+	GetExtension(ctx context.Context, profileID int64) (ProfileExtension, error)
+	UpdateExtension(ctx context.Context, profileID int64, ext ProfileExtension) error
+}
+
+var _ ProfilesAPI = (*ProfilesService)(nil)
+
+// QuotesAPI is the interface implemented by QuotesService.
+type QuotesAPI interface {
+	Create(ctx context.Context, profileID int64, req *CreateQuoteRequest) (*Quote, error)
+	CreateV2(ctx context.Context, req *CreateQuoteRequest) (*Quote, error)
+	Get(ctx context.Context, profileID int64, quoteID string) (*Quote, error)
+	GetV2(ctx context.Context, quoteID string) (*Quote, error)
+	Update(ctx context.Context, profileID int64, quoteID string, req *UpdateQuoteRequest) (*Quote, error)
+}
+
+var _ QuotesAPI = (*QuotesService)(nil)
+
+// RecipientsAPI is the interface implemented by RecipientsService.
+type RecipientsAPI interface {
+	Create(ctx context.Context, req *CreateRecipientRequest) (*Recipient, error)
+	CreateEmail(ctx context.Context, profileID int64, accountHolderName string, currency Currency, email string) (*Recipient, error)
+	Get(ctx context.Context, accountID int64) (*Recipient, error)
+	List(ctx context.Context, params *ListRecipientsParams) ([]Recipient, error)
+	ListAll(ctx context.Context, params *ListRecipientsParams) ([]Recipient, error)
+	Update(ctx context.Context, accountID int64, req *UpdateRecipientRequest) (*Recipient, error)
+	Delete(ctx context.Context, accountID int64) error
+	GetRequirements(ctx context.Context, quoteID string, currency Currency) ([]RecipientRequirements, error)
+	RefreshRequirements(ctx context.Context, quoteID string, req *CreateRecipientRequest) ([]RecipientRequirements, error)
+	VerifyAccount(ctx context.Context, profileID int64, req *CreateRecipientRequest) (*AccountVerification, error)
+	VerifyRecipient(ctx context.Context, profileID, recipientID int64) (*AccountVerification, error)
+}
+
+var _ RecipientsAPI = (*RecipientsService)(nil)
+
+// TransfersAPI is the interface implemented by TransfersService.
+type TransfersAPI interface {
+	Create(ctx context.Context, req *CreateTransferRequest) (*Transfer, error)
+	GetRequirements(ctx context.Context, req *CreateTransferRequest) ([]RecipientRequirements, error)
+	Get(ctx context.Context, transferID int64) (*Transfer, error)
+	List(ctx context.Context, params *ListTransfersParams) ([]Transfer, error)
+	ListAll(ctx context.Context, params *ListTransfersParams) ([]Transfer, error)
+	Cancel(ctx context.Context, transferID int64) (*Transfer, error)
+	CanCancel(ctx context.Context, transferID int64) (bool, error)
+	Fund(ctx context.Context, profileID, transferID int64) (*Transfer, error)
+	GetPayInDetails(ctx context.Context, profileID, transferID int64) (*PayInDetails, error)
+	GetIssues(ctx context.Context, transferID int64) ([]TransferIssue, error)
+	GetDeliveryTime(ctx context.Context, transferID int64) (*Timestamp, error)
+	UploadDocument(ctx context.Context, profileID, transferID int64, documentType, fileName string, content io.Reader) (*TransferDocument, error)
+	GetTracking(ctx context.Context, transferID int64) (*TransferTracking, error)
+	DownloadReceipt(ctx context.Context, transferID int64) (io.ReadCloser, error)
+}
+
+var _ TransfersAPI = (*TransfersService)(nil)
+
+// ExchangeRatesAPI is the interface implemented by ExchangeRatesService.
+type ExchangeRatesAPI interface {
+	Get(ctx context.Context, source, target Currency) (*ExchangeRate, error)
+	List(ctx context.Context, params *GetRateParams) ([]ExchangeRate, error)
+	GetHistorical(ctx context.Context, source, target Currency, time string) (*ExchangeRate, error)
+	GetHistory(ctx context.Context, params *HistoryParams) ([]ExchangeRate, error)
+	GetMultiple(ctx context.Context, pairs [][2]Currency) (map[string]float64, error)
+}
+
+var _ ExchangeRatesAPI = (*ExchangeRatesService)(nil)
+
+// BalancesAPI is the interface implemented by BalancesService.
+type BalancesAPI interface {
+	List(ctx context.Context, profileID int64, params *ListBalancesParams) ([]Balance, error)
+	ListAll(ctx context.Context, profileID int64) ([]Balance, error)
+	Get(ctx context.Context, profileID, balanceID int64) (*Balance, error)
+	Open(ctx context.Context, profileID int64, req *OpenBalanceRequest) (*Balance, error)
+	CreateJar(ctx context.Context, profileID int64, currency Currency, name string) (*Balance, error)
+	Move(ctx context.Context, profileID int64, req *MoveBalanceRequest) (*BalanceMovement, error)
+	Close(ctx context.Context, profileID, balanceID int64) error
+	GetByCurrency(ctx context.Context, profileID int64, currency Currency) (*Balance, error)
+	Convert(ctx context.Context, profileID int64, quoteID string) error
+	GetStatement(ctx context.Context, profileID, balanceID int64, currency Currency, intervalStart, intervalEnd string) ([]BalanceStatement, error)
+	GetFundingInstructions(ctx context.Context, profileID, balanceID int64, amount float64) (*FundingInstructions, error)
+	DownloadStatement(ctx context.Context, profileID, balanceID int64, currency Currency, intervalStart, intervalEnd string, format StatementFormat) (io.ReadCloser, error)
+	StreamStatement(ctx context.Context, profileID, balanceID int64, currency Currency, intervalStart, intervalEnd string) (*StatementStream, error)
+}
+
+var _ BalancesAPI = (*BalancesService)(nil)
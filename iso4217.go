@@ -0,0 +1,276 @@
+package wise
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ISOCurrency describes an ISO 4217 currency.
+type ISOCurrency struct {
+	Code       Currency
+	Name       string
+	Symbol     string
+	MinorUnits int // Digits after the decimal point, e.g. 2 for USD, 0 for JPY, 3 for KWD
+}
+
+// LookupCurrency returns the ISO 4217 details for a currency code.
+// The code is matched case-insensitively.
+func LookupCurrency(code string) (ISOCurrency, bool) {
+	c, ok := iso4217[Currency(strings.ToUpper(strings.TrimSpace(code)))]
+	return c, ok
+}
+
+// AllCurrencies returns every active ISO 4217 currency, sorted by code.
+func AllCurrencies() []ISOCurrency {
+	list := make([]ISOCurrency, 0, len(iso4217))
+	for _, c := range iso4217 {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// IsValid returns true if c is an active ISO 4217 currency code.
+func (c Currency) IsValid() bool {
+	_, ok := iso4217[c]
+	return ok
+}
+
+// Name returns the currency name, or the code if it is unknown.
+func (c Currency) Name() string {
+	if info, ok := iso4217[c]; ok {
+		return info.Name
+	}
+	return string(c)
+}
+
+// Symbol returns the currency symbol, or the code if it is unknown.
+func (c Currency) Symbol() string {
+	if info, ok := iso4217[c]; ok {
+		return info.Symbol
+	}
+	return string(c)
+}
+
+// MinorUnits returns the number of decimal places used for amounts.
+// Unknown currencies default to 2.
+func (c Currency) MinorUnits() int {
+	if info, ok := iso4217[c]; ok {
+		return info.MinorUnits
+	}
+	return 2
+}
+
+// Round rounds amount to the currency's minor units.
+func (c Currency) Round(amount float64) float64 {
+	scale := math.Pow10(c.MinorUnits())
+	return math.Round(amount*scale) / scale
+}
+
+// Format formats amount with the currency symbol, thousands separators and
+// the currency's minor units, e.g. "$1,234.50", "¥1,235" or "CHF 12.00".
+// Unknown currencies are formatted as "1,234.50 XYZ".
+func (c Currency) Format(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	number := groupThousands(strconv.FormatFloat(amount, 'f', c.MinorUnits(), 64))
+
+	info, ok := iso4217[c]
+	if !ok {
+		return sign + number + " " + string(c)
+	}
+	// Alphabetic symbols such as "CHF" or "kr" read better with a space.
+	if r, _ := utf8.DecodeLastRuneInString(info.Symbol); unicode.IsLetter(r) {
+		return sign + info.Symbol + " " + number
+	}
+	return sign + info.Symbol + number
+}
+
+// groupThousands inserts commas into the integer part of a formatted number.
+func groupThousands(s string) string {
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+	if len(intPart) <= 3 {
+		return s
+	}
+	var b strings.Builder
+	lead := len(intPart) % 3
+	if lead > 0 {
+		b.WriteString(intPart[:lead])
+	}
+	for i := lead; i < len(intPart); i += 3 {
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(intPart[i : i+3])
+	}
+	return b.String() + frac
+}
+
+// iso4217 lists the active ISO 4217 currencies, excluding funds and precious metals.
+var iso4217 = map[Currency]ISOCurrency{
+	"AED": {"AED", "UAE Dirham", "د.إ", 2},
+	"AFN": {"AFN", "Afghani", "؋", 2},
+	"ALL": {"ALL", "Lek", "L", 2},
+	"AMD": {"AMD", "Armenian Dram", "֏", 2},
+	"ANG": {"ANG", "Netherlands Antillean Guilder", "ƒ", 2},
+	"AOA": {"AOA", "Kwanza", "Kz", 2},
+	"ARS": {"ARS", "Argentine Peso", "$", 2},
+	"AUD": {"AUD", "Australian Dollar", "A$", 2},
+	"AWG": {"AWG", "Aruban Florin", "ƒ", 2},
+	"AZN": {"AZN", "Azerbaijan Manat", "₼", 2},
+	"BAM": {"BAM", "Convertible Mark", "KM", 2},
+	"BBD": {"BBD", "Barbados Dollar", "$", 2},
+	"BDT": {"BDT", "Taka", "৳", 2},
+	"BGN": {"BGN", "Bulgarian Lev", "лв", 2},
+	"BHD": {"BHD", "Bahraini Dinar", "BD", 3},
+	"BIF": {"BIF", "Burundi Franc", "FBu", 0},
+	"BMD": {"BMD", "Bermudian Dollar", "$", 2},
+	"BND": {"BND", "Brunei Dollar", "$", 2},
+	"BOB": {"BOB", "Boliviano", "Bs.", 2},
+	"BRL": {"BRL", "Brazilian Real", "R$", 2},
+	"BSD": {"BSD", "Bahamian Dollar", "$", 2},
+	"BTN": {"BTN", "Ngultrum", "Nu.", 2},
+	"BWP": {"BWP", "Pula", "P", 2},
+	"BYN": {"BYN", "Belarusian Ruble", "Br", 2},
+	"BZD": {"BZD", "Belize Dollar", "$", 2},
+	"CAD": {"CAD", "Canadian Dollar", "C$", 2},
+	"CDF": {"CDF", "Congolese Franc", "FC", 2},
+	"CHF": {"CHF", "Swiss Franc", "CHF", 2},
+	"CLP": {"CLP", "Chilean Peso", "$", 0},
+	"CNY": {"CNY", "Yuan Renminbi", "¥", 2},
+	"COP": {"COP", "Colombian Peso", "$", 2},
+	"CRC": {"CRC", "Costa Rican Colon", "₡", 2},
+	"CUP": {"CUP", "Cuban Peso", "$", 2},
+	"CVE": {"CVE", "Cabo Verde Escudo", "$", 2},
+	"CZK": {"CZK", "Czech Koruna", "Kč", 2},
+	"DJF": {"DJF", "Djibouti Franc", "Fdj", 0},
+	"DKK": {"DKK", "Danish Krone", "kr", 2},
+	"DOP": {"DOP", "Dominican Peso", "$", 2},
+	"DZD": {"DZD", "Algerian Dinar", "DA", 2},
+	"EGP": {"EGP", "Egyptian Pound", "E£", 2},
+	"ERN": {"ERN", "Nakfa", "Nfk", 2},
+	"ETB": {"ETB", "Ethiopian Birr", "Br", 2},
+	"EUR": {"EUR", "Euro", "€", 2},
+	"FJD": {"FJD", "Fiji Dollar", "$", 2},
+	"FKP": {"FKP", "Falkland Islands Pound", "£", 2},
+	"GBP": {"GBP", "Pound Sterling", "£", 2},
+	"GEL": {"GEL", "Lari", "₾", 2},
+	"GHS": {"GHS", "Ghana Cedi", "₵", 2},
+	"GIP": {"GIP", "Gibraltar Pound", "£", 2},
+	"GMD": {"GMD", "Dalasi", "D", 2},
+	"GNF": {"GNF", "Guinean Franc", "FG", 0},
+	"GTQ": {"GTQ", "Quetzal", "Q", 2},
+	"GYD": {"GYD", "Guyana Dollar", "$", 2},
+	"HKD": {"HKD", "Hong Kong Dollar", "HK$", 2},
+	"HNL": {"HNL", "Lempira", "L", 2},
+	"HTG": {"HTG", "Gourde", "G", 2},
+	"HUF": {"HUF", "Forint", "Ft", 2},
+	"IDR": {"IDR", "Rupiah", "Rp", 2},
+	"ILS": {"ILS", "New Israeli Sheqel", "₪", 2},
+	"INR": {"INR", "Indian Rupee", "₹", 2},
+	"IQD": {"IQD", "Iraqi Dinar", "IQD", 3},
+	"IRR": {"IRR", "Iranian Rial", "IRR", 2},
+	"ISK": {"ISK", "Iceland Krona", "kr", 0},
+	"JMD": {"JMD", "Jamaican Dollar", "$", 2},
+	"JOD": {"JOD", "Jordanian Dinar", "JD", 3},
+	"JPY": {"JPY", "Yen", "¥", 0},
+	"KES": {"KES", "Kenyan Shilling", "KSh", 2},
+	"KGS": {"KGS", "Som", "KGS", 2},
+	"KHR": {"KHR", "Riel", "៛", 2},
+	"KMF": {"KMF", "Comorian Franc", "CF", 0},
+	"KPW": {"KPW", "North Korean Won", "₩", 2},
+	"KRW": {"KRW", "Won", "₩", 0},
+	"KWD": {"KWD", "Kuwaiti Dinar", "KD", 3},
+	"KYD": {"KYD", "Cayman Islands Dollar", "$", 2},
+	"KZT": {"KZT", "Tenge", "₸", 2},
+	"LAK": {"LAK", "Lao Kip", "₭", 2},
+	"LBP": {"LBP", "Lebanese Pound", "LBP", 2},
+	"LKR": {"LKR", "Sri Lanka Rupee", "Rs", 2},
+	"LRD": {"LRD", "Liberian Dollar", "$", 2},
+	"LSL": {"LSL", "Loti", "L", 2},
+	"LYD": {"LYD", "Libyan Dinar", "LD", 3},
+	"MAD": {"MAD", "Moroccan Dirham", "DH", 2},
+	"MDL": {"MDL", "Moldovan Leu", "L", 2},
+	"MGA": {"MGA", "Malagasy Ariary", "Ar", 2},
+	"MKD": {"MKD", "Denar", "ден", 2},
+	"MMK": {"MMK", "Kyat", "K", 2},
+	"MNT": {"MNT", "Tugrik", "₮", 2},
+	"MOP": {"MOP", "Pataca", "MOP$", 2},
+	"MRU": {"MRU", "Ouguiya", "UM", 2},
+	"MUR": {"MUR", "Mauritius Rupee", "Rs", 2},
+	"MVR": {"MVR", "Rufiyaa", "Rf", 2},
+	"MWK": {"MWK", "Malawi Kwacha", "MK", 2},
+	"MXN": {"MXN", "Mexican Peso", "$", 2},
+	"MYR": {"MYR", "Malaysian Ringgit", "RM", 2},
+	"MZN": {"MZN", "Mozambique Metical", "MT", 2},
+	"NAD": {"NAD", "Namibia Dollar", "$", 2},
+	"NGN": {"NGN", "Naira", "₦", 2},
+	"NIO": {"NIO", "Cordoba Oro", "C$", 2},
+	"NOK": {"NOK", "Norwegian Krone", "kr", 2},
+	"NPR": {"NPR", "Nepalese Rupee", "Rs", 2},
+	"NZD": {"NZD", "New Zealand Dollar", "NZ$", 2},
+	"OMR": {"OMR", "Rial Omani", "OMR", 3},
+	"PAB": {"PAB", "Balboa", "B/.", 2},
+	"PEN": {"PEN", "Sol", "S/", 2},
+	"PGK": {"PGK", "Kina", "K", 2},
+	"PHP": {"PHP", "Philippine Peso", "₱", 2},
+	"PKR": {"PKR", "Pakistan Rupee", "Rs", 2},
+	"PLN": {"PLN", "Zloty", "zł", 2},
+	"PYG": {"PYG", "Guarani", "₲", 0},
+	"QAR": {"QAR", "Qatari Rial", "QR", 2},
+	"RON": {"RON", "Romanian Leu", "lei", 2},
+	"RSD": {"RSD", "Serbian Dinar", "RSD", 2},
+	"RUB": {"RUB", "Russian Ruble", "₽", 2},
+	"RWF": {"RWF", "Rwanda Franc", "FRw", 0},
+	"SAR": {"SAR", "Saudi Riyal", "SR", 2},
+	"SBD": {"SBD", "Solomon Islands Dollar", "$", 2},
+	"SCR": {"SCR", "Seychelles Rupee", "Rs", 2},
+	"SDG": {"SDG", "Sudanese Pound", "SDG", 2},
+	"SEK": {"SEK", "Swedish Krona", "kr", 2},
+	"SGD": {"SGD", "Singapore Dollar", "S$", 2},
+	"SHP": {"SHP", "Saint Helena Pound", "£", 2},
+	"SLE": {"SLE", "Leone", "Le", 2},
+	"SOS": {"SOS", "Somali Shilling", "Sh", 2},
+	"SRD": {"SRD", "Surinam Dollar", "$", 2},
+	"SSP": {"SSP", "South Sudanese Pound", "£", 2},
+	"STN": {"STN", "Dobra", "Db", 2},
+	"SVC": {"SVC", "El Salvador Colon", "₡", 2},
+	"SYP": {"SYP", "Syrian Pound", "£", 2},
+	"SZL": {"SZL", "Lilangeni", "E", 2},
+	"THB": {"THB", "Baht", "฿", 2},
+	"TJS": {"TJS", "Somoni", "SM", 2},
+	"TMT": {"TMT", "Turkmenistan New Manat", "m", 2},
+	"TND": {"TND", "Tunisian Dinar", "DT", 3},
+	"TOP": {"TOP", "Pa'anga", "T$", 2},
+	"TRY": {"TRY", "Turkish Lira", "₺", 2},
+	"TTD": {"TTD", "Trinidad and Tobago Dollar", "$", 2},
+	"TWD": {"TWD", "New Taiwan Dollar", "NT$", 2},
+	"TZS": {"TZS", "Tanzanian Shilling", "TSh", 2},
+	"UAH": {"UAH", "Hryvnia", "₴", 2},
+	"UGX": {"UGX", "Uganda Shilling", "USh", 0},
+	"USD": {"USD", "US Dollar", "$", 2},
+	"UYU": {"UYU", "Peso Uruguayo", "$", 2},
+	"UZS": {"UZS", "Uzbekistan Sum", "UZS", 2},
+	"VES": {"VES", "Bolívar Soberano", "Bs.S", 2},
+	"VND": {"VND", "Dong", "₫", 0},
+	"VUV": {"VUV", "Vatu", "VT", 0},
+	"WST": {"WST", "Tala", "WS$", 2},
+	"XAF": {"XAF", "CFA Franc BEAC", "FCFA", 0},
+	"XCD": {"XCD", "East Caribbean Dollar", "$", 2},
+	"XOF": {"XOF", "CFA Franc BCEAO", "CFA", 0},
+	"XPF": {"XPF", "CFP Franc", "₣", 0},
+	"YER": {"YER", "Yemeni Rial", "YER", 2},
+	"ZAR": {"ZAR", "Rand", "R", 2},
+	"ZMW": {"ZMW", "Zambian Kwacha", "ZK", 2},
+	"ZWG": {"ZWG", "Zimbabwe Gold", "ZiG", 2},
+}
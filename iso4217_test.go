@@ -0,0 +1,43 @@
+package wise
+
+import "testing"
+
+func TestCurrency_Format(t *testing.T) {
+	tests := []struct {
+		currency Currency
+		amount   float64
+		want     string
+	}{
+		{USD, 1234.5, "$1,234.50"},
+		{EUR, -0.5, "-€0.50"},
+		{JPY, 1234567.6, "¥1,234,568"},
+		{Currency("KWD"), 12.3456, "KD 12.346"},
+		{CHF, 100, "CHF 100.00"},
+		{Currency("XYZ"), 1000, "1,000.00 XYZ"},
+	}
+	for _, tt := range tests {
+		if got := tt.currency.Format(tt.amount); got != tt.want {
+			t.Errorf("%s.Format(%v) = %q, want %q", tt.currency, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestLookupCurrency(t *testing.T) {
+	c, ok := LookupCurrency("bhd")
+	if !ok || c.Code != "BHD" || c.MinorUnits != 3 {
+		t.Errorf("LookupCurrency(bhd) = %+v, %v", c, ok)
+	}
+	if Currency("EURO").IsValid() {
+		t.Error("EURO should not be a valid currency")
+	}
+	if !GBP.IsValid() || JPY.MinorUnits() != 0 {
+		t.Error("GBP should be valid and JPY should have no minor units")
+	}
+
+	all := AllCurrencies()
+	for i := 1; i < len(all); i++ {
+		if all[i-1].Code >= all[i].Code {
+			t.Fatalf("AllCurrencies not sorted at %s", all[i].Code)
+		}
+	}
+}
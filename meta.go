@@ -0,0 +1,73 @@
+package wise
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta describes the HTTP response to an API call. Quote the
+// RequestID when contacting Wise support about a call.
+type ResponseMeta struct {
+	StatusCode int
+	RequestID  string // X-Request-Id header
+	Header     http.Header
+
+	// Rate limit headers; zero if the response had none.
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     time.Time
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta returns a context that records the response metadata of
+// calls made with it into meta. When a call is retried, meta describes the
+// last attempt; calls answered from the cache (see WithCache) leave it
+// unchanged. Use a separate ResponseMeta for concurrent calls.
+//
+//	var meta wise.ResponseMeta
+//	_, err := client.Transfers.Create(wise.WithResponseMeta(ctx, &meta), req)
+//	log.Printf("request %s: %v", meta.RequestID, err)
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// recordResponseMeta fills the ResponseMeta attached to ctx, if any.
+func recordResponseMeta(ctx context.Context, resp *http.Response) {
+	meta, ok := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	if !ok || meta == nil {
+		return
+	}
+	*meta = ResponseMeta{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		Header:     resp.Header,
+	}
+	meta.RateLimitLimit, _ = rateLimitHeader(resp.Header, "Limit")
+	meta.RateLimitRemaining, _ = rateLimitHeader(resp.Header, "Remaining")
+	if reset, ok := rateLimitHeader(resp.Header, "Reset"); ok {
+		meta.RateLimitReset = rateLimitResetTime(reset)
+	}
+}
+
+// rateLimitHeader reads an integer X-RateLimit-* header, also accepting the
+// X-Rate-Limit-* spelling.
+func rateLimitHeader(header http.Header, name string) (int, bool) {
+	v := header.Get("X-RateLimit-" + name)
+	if v == "" {
+		v = header.Get("X-Rate-Limit-" + name)
+	}
+	n, err := strconv.Atoi(v)
+	return n, err == nil
+}
+
+// rateLimitResetTime interprets a reset header given either as seconds from
+// now or as a Unix timestamp.
+func rateLimitResetTime(reset int) time.Time {
+	if reset > 1_000_000_000 {
+		return time.Unix(int64(reset), 0)
+	}
+	return time.Now().Add(time.Duration(reset) * time.Second)
+}
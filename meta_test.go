@@ -0,0 +1,32 @@
+package wise
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithResponseMeta(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "99")
+		w.Header().Set("X-RateLimit-Reset", "30")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	var meta ResponseMeta
+	if _, err := client.Profiles.List(WithResponseMeta(context.Background(), &meta)); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-42" {
+		t.Errorf("Unexpected metadata: %+v", meta)
+	}
+	if meta.RateLimitLimit != 100 || meta.RateLimitRemaining != 99 || meta.RateLimitReset.IsZero() {
+		t.Errorf("Unexpected rate limit metadata: %+v", meta)
+	}
+}
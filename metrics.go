@@ -0,0 +1,79 @@
+package wise
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestMetrics describes a single HTTP round trip to the Wise API.
+type RequestMetrics struct {
+	Method     string
+	Endpoint   string // Path with IDs replaced by {id}, e.g. /v1/transfers/{id}
+	StatusCode int    // Zero if no response was received
+	Duration   time.Duration
+	Err        error // Transport error, if any; API errors are reported by StatusCode
+}
+
+// Recorder receives metrics for every request the client sends, including
+// retries. Implementations typically increment a request counter by method,
+// endpoint and status code and observe the duration in a histogram:
+//
+//	type promRecorder struct {
+//		requests *prometheus.CounterVec
+//		latency  *prometheus.HistogramVec
+//	}
+//
+//	func (r promRecorder) RecordRequest(m wise.RequestMetrics) {
+//		status := strconv.Itoa(m.StatusCode)
+//		r.requests.WithLabelValues(m.Method, m.Endpoint, status).Inc()
+//		r.latency.WithLabelValues(m.Method, m.Endpoint).Observe(m.Duration.Seconds())
+//	}
+//
+// RecordRequest is called synchronously and must be safe for concurrent use.
+type Recorder interface {
+	RecordRequest(m RequestMetrics)
+}
+
+// RecorderFunc adapts a function to the Recorder interface.
+type RecorderFunc func(m RequestMetrics)
+
+// RecordRequest calls f(m).
+func (f RecorderFunc) RecordRequest(m RequestMetrics) {
+	f(m)
+}
+
+// WithMetrics reports every request to r.
+func WithMetrics(r Recorder) ClientOption {
+	return func(c *Client) {
+		c.metrics = r
+	}
+}
+
+// endpointName returns the path of rawURL with numeric IDs, UUIDs and other
+// variable segments replaced by {id}, to keep metric cardinality low.
+func endpointName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "unknown"
+	}
+	segments := strings.Split(u.Path, "/")
+	for i, seg := range segments {
+		if isVariableSegment(seg) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isVariableSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	if _, err := strconv.ParseInt(seg, 10, 64); err == nil {
+		return true
+	}
+	// UUIDs and card tokens
+	return len(seg) >= 32 && strings.Count(seg, "-") >= 4
+}
@@ -0,0 +1,42 @@
+package wise
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEndpointName(t *testing.T) {
+	tests := map[string]string{
+		"https://api.wise.com/v1/transfers/12345":                                "/v1/transfers/{id}",
+		"https://api.wise.com/v4/profiles/1/balances?types=STANDARD":             "/v4/profiles/{id}/balances",
+		"https://api.wise.com/v2/quotes/2c1b4f3a-6d2e-4a5b-9c8d-0e1f2a3b4c5d":    "/v2/quotes/{id}",
+		"https://api.wise.com/v1/profiles/1/balance-statements/2/statement.json": "/v1/profiles/{id}/balance-statements/{id}/statement.json",
+	}
+	for in, want := range tests {
+		if got := endpointName(in); got != want {
+			t.Errorf("endpointName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClient_Metrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var got []RequestMetrics
+	client := NewClient("test-token", WithBaseURL(server.URL), WithMetrics(RecorderFunc(func(m RequestMetrics) {
+		got = append(got, m)
+	})))
+	client.Transfers.Get(context.Background(), 42)
+
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 recorded request, got %d", len(got))
+	}
+	if got[0].Method != "GET" || got[0].Endpoint != "/v1/transfers/{id}" || got[0].StatusCode != 404 {
+		t.Errorf("Unexpected metrics: %+v", got[0])
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,15 +21,51 @@ const (
 	ProductionTokenURL = "https://api.wise.com/oauth/token"
 	SandboxAuthURL     = "https://sandbox.transferwise.tech/oauth/authorize"
 	SandboxTokenURL    = "https://api.sandbox.transferwise.tech/oauth/token"
+
+	ProductionRevokeURL = "https://api.wise.com/oauth/revoke"
+	SandboxRevokeURL    = "https://api.sandbox.transferwise.tech/oauth/revoke"
+)
+
+// OAuth scopes. Wise grants the scopes configured for the client when it
+// is issued; requesting a subset narrows what a token can do.
+const (
+	ScopeRead      = "read"      // Profiles, rates, recipients and history
+	ScopeTransfers = "transfers" // Create and fund transfers
+	ScopeBalances  = "balances"  // Balance accounts, conversions and statements
+	ScopeCards     = "cards"     // Card issuing and spend controls
+	ScopeWebhooks  = "webhooks"  // Webhook subscriptions
 )
 
+// knownScopes lists the scopes accepted by OAuthConfig.Validate.
+var knownScopes = map[string]bool{
+	ScopeRead:      true,
+	ScopeTransfers: true,
+	ScopeBalances:  true,
+	ScopeCards:     true,
+	ScopeWebhooks:  true,
+}
+
 // OAuthConfig holds OAuth client credentials.
 type OAuthConfig struct {
 	ClientID     string
 	ClientSecret string
 	RedirectURL  string
 	Sandbox      bool
-	Scopes       []string
+	Scopes       []string // Scope* constants; empty requests the client's default scopes
+	Clock        Clock    // Used for token expiry; defaults to SystemClock
+}
+
+// Validate checks that the config has credentials and only known scopes.
+func (c OAuthConfig) Validate() error {
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return fmt.Errorf("wise: OAuth client ID and secret are required")
+	}
+	for _, scope := range c.Scopes {
+		if !knownScopes[scope] {
+			return fmt.Errorf("wise: unknown OAuth scope %q", scope)
+		}
+	}
+	return nil
 }
 
 // Token represents an OAuth access token response.
@@ -36,19 +75,41 @@ type Token struct {
 	RefreshToken string    `json:"refresh_token,omitempty"`
 	ExpiresIn    int       `json:"expires_in"`
 	Scope        string    `json:"scope,omitempty"`
-	ExpiresAt    time.Time `json:"-"`
+	ExpiresAt    time.Time `json:"expires_at,omitzero"` // Set from ExpiresIn; persisted by TokenStores
 }
 
 // IsExpired returns true if the token is expired or about to expire.
 func (t *Token) IsExpired() bool {
+	return t.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt is IsExpired at the given time.
+func (t *Token) IsExpiredAt(now time.Time) bool {
 	// Consider expired if less than 5 minutes remaining
-	return time.Now().Add(5 * time.Minute).After(t.ExpiresAt)
+	return now.Add(5 * time.Minute).After(t.ExpiresAt)
+}
+
+// Scopes returns the scopes granted to the token.
+func (t *Token) Scopes() []string {
+	return strings.Fields(t.Scope)
+}
+
+// HasScope returns true if the token was granted scope. Check it before
+// calling an endpoint, to fail with a clear message rather than a 403.
+func (t *Token) HasScope(scope string) bool {
+	for _, s := range t.Scopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
 }
 
 // OAuthClient handles OAuth authentication with Wise.
 type OAuthClient struct {
 	config     OAuthConfig
 	httpClient *http.Client
+	endpoint   string // Overrides the OAuth API host, for tests
 }
 
 // NewOAuthClient creates a new OAuth client.
@@ -80,44 +141,116 @@ func (c *OAuthClient) AuthURL(state string) string {
 
 // ExchangeCode exchanges an authorization code for tokens.
 func (c *OAuthClient) ExchangeCode(ctx context.Context, code string) (*Token, error) {
-	tokenURL := ProductionTokenURL
-	if c.config.Sandbox {
-		tokenURL = SandboxTokenURL
-	}
-
 	data := url.Values{}
 	data.Set("grant_type", "authorization_code")
 	data.Set("code", code)
 	data.Set("redirect_uri", c.config.RedirectURL)
 
-	return c.tokenRequest(ctx, tokenURL, data)
+	return c.tokenRequest(ctx, c.tokenURL(), data)
 }
 
 // RefreshToken refreshes an expired access token.
 func (c *OAuthClient) RefreshToken(ctx context.Context, refreshToken string) (*Token, error) {
-	tokenURL := ProductionTokenURL
-	if c.config.Sandbox {
-		tokenURL = SandboxTokenURL
-	}
-
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", refreshToken)
 
-	return c.tokenRequest(ctx, tokenURL, data)
+	return c.tokenRequest(ctx, c.tokenURL(), data)
 }
 
 // ClientCredentials gets a token using client credentials (for server-to-server).
 func (c *OAuthClient) ClientCredentials(ctx context.Context) (*Token, error) {
-	tokenURL := ProductionTokenURL
+	data := url.Values{}
+	data.Set("grant_type", "client_credentials")
+
+	return c.tokenRequest(ctx, c.tokenURL(), data)
+}
+
+// RegistrationCodeToken gets tokens for a user created with
+// PartnerService.CreateUser, using the email and registration code it was
+// created with.
+func (c *OAuthClient) RegistrationCodeToken(ctx context.Context, email, registrationCode string) (*Token, error) {
+	data := url.Values{}
+	data.Set("grant_type", "registration_code")
+	data.Set("client_id", c.config.ClientID)
+	data.Set("email", email)
+	data.Set("registration_code", registrationCode)
+
+	return c.tokenRequest(ctx, c.tokenURL(), data)
+}
+
+// OnboardingURL returns the authorization URL for onboarding a user to the
+// partner's client. The email pre-fills the Wise login or sign-up form, so
+// existing users can consent and new users can register in one flow.
+func (c *OAuthClient) OnboardingURL(state, email string) string {
+	authURL := c.AuthURL(state)
+	if email == "" {
+		return authURL
+	}
+	return authURL + "&" + url.Values{"email": {email}}.Encode()
+}
+
+// now returns the current time from the configured clock.
+func (c *OAuthClient) now() time.Time {
+	if c.config.Clock != nil {
+		return c.config.Clock.Now()
+	}
+	return time.Now()
+}
+
+// tokenURL returns the token endpoint for the configured environment.
+func (c *OAuthClient) tokenURL() string {
+	if c.endpoint != "" {
+		return c.endpoint + "/oauth/token"
+	}
+	if c.config.Sandbox {
+		return SandboxTokenURL
+	}
+	return ProductionTokenURL
+}
+
+// revokeURL returns the revocation endpoint for the configured environment.
+func (c *OAuthClient) revokeURL() string {
+	if c.endpoint != "" {
+		return c.endpoint + "/oauth/revoke"
+	}
 	if c.config.Sandbox {
-		tokenURL = SandboxTokenURL
+		return SandboxRevokeURL
 	}
+	return ProductionRevokeURL
+}
 
+// Revoke invalidates an access or refresh token on the Wise side (RFC 7009).
+// Revoking a refresh token also invalidates the access tokens issued from it.
+func (c *OAuthClient) Revoke(ctx context.Context, token string) error {
 	data := url.Values{}
-	data.Set("grant_type", "client_credentials")
+	data.Set("token", token)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.revokeURL(), strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	c.setClientAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("revoke request failed: %s - %s", resp.Status, string(body))
+	}
+	return nil
+}
 
-	return c.tokenRequest(ctx, tokenURL, data)
+// setClientAuth sets the form content type and the client credentials as
+// basic auth.
+func (c *OAuthClient) setClientAuth(req *http.Request) {
+	auth := base64.StdEncoding.EncodeToString([]byte(c.config.ClientID + ":" + c.config.ClientSecret))
+	req.Header.Set("Authorization", "Basic "+auth)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 }
 
 func (c *OAuthClient) tokenRequest(ctx context.Context, tokenURL string, data url.Values) (*Token, error) {
@@ -127,9 +260,7 @@ func (c *OAuthClient) tokenRequest(ctx context.Context, tokenURL string, data ur
 	}
 
 	// Basic auth with client credentials
-	auth := base64.StdEncoding.EncodeToString([]byte(c.config.ClientID + ":" + c.config.ClientSecret))
-	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.setClientAuth(req)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -152,7 +283,7 @@ func (c *OAuthClient) tokenRequest(ctx context.Context, tokenURL string, data ur
 	}
 
 	// Calculate expiration time
-	token.ExpiresAt = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	token.ExpiresAt = c.now().Add(time.Duration(token.ExpiresIn) * time.Second)
 
 	return &token, nil
 }
@@ -166,11 +297,40 @@ func NewClientWithOAuth(token *Token, sandbox bool) *Client {
 	return NewClient(token.AccessToken, opts...)
 }
 
-// TokenManager handles automatic token refresh.
+// TokenSource supplies access tokens to a Client. The Client asks for a
+// token on every request, so implementations should cache tokens and only
+// refresh them when needed.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// NewClientWithTokenSource creates a new Wise API client that authenticates
+// each request with a token from ts, e.g. a TokenManager. Token refreshes are
+// picked up without creating a new Client.
+func NewClientWithTokenSource(ts TokenSource, opts ...ClientOption) *Client {
+	c := NewClient("", opts...)
+	c.tokenSource = ts
+	return c
+}
+
+// Background refresh timing. Tokens are refreshed ahead of the 5 minute
+// IsExpired window, so requests never have to wait for a refresh.
+const (
+	refreshAhead         = 10 * time.Minute
+	refreshJitter        = 2 * time.Minute
+	refreshRetryInterval = 30 * time.Second
+)
+
+// TokenManager handles automatic token refresh. It is safe for concurrent
+// use; concurrent callers share a single refresh.
 type TokenManager struct {
-	oauth        *OAuthClient
-	token        *Token
+	mu             sync.Mutex
+	oauth          *OAuthClient
+	token          *Token
 	onTokenRefresh func(*Token)
+	onRefreshError func(error)
+	store          TokenStore
+	client         *Client
 }
 
 // NewTokenManager creates a token manager that auto-refreshes tokens.
@@ -181,21 +341,106 @@ func NewTokenManager(oauth *OAuthClient, initialToken *Token) *TokenManager {
 	}
 }
 
-// SetRefreshCallback sets a callback for when token is refreshed.
+// NewTokenManagerFromStore creates a token manager with the token saved in
+// store, and saves refreshed tokens back to it. If nothing has been saved
+// yet the manager starts without a token; call SetToken after logging in.
+func NewTokenManagerFromStore(ctx context.Context, oauth *OAuthClient, store TokenStore) (*TokenManager, error) {
+	token, err := store.Load(ctx)
+	if err != nil && !errors.Is(err, ErrNoStoredToken) {
+		return nil, fmt.Errorf("loading token: %w", err)
+	}
+	m := NewTokenManager(oauth, token)
+	m.store = store
+	return m, nil
+}
+
+// SetToken replaces the managed token, e.g. after ExchangeCode, and saves it
+// to the token store if there is one.
+func (m *TokenManager) SetToken(ctx context.Context, token *Token) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.token = token
+	if m.store != nil {
+		if err := m.store.Save(ctx, token); err != nil {
+			return fmt.Errorf("saving token: %w", err)
+		}
+	}
+	return nil
+}
+
+// Revoke logs out: it revokes the token with Wise, then forgets it and
+// deletes it from the token store. The token is forgotten even if Wise
+// could not be reached.
+func (m *TokenManager) Revoke(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var err error
+	if m.token != nil {
+		revoke := m.token.RefreshToken
+		if revoke == "" {
+			revoke = m.token.AccessToken
+		}
+		err = m.oauth.Revoke(ctx, revoke)
+	}
+
+	m.token = nil
+	if m.store != nil {
+		if deleteErr := m.store.Delete(ctx); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("deleting token: %w", deleteErr))
+		}
+	}
+	return err
+}
+
+// SetRefreshCallback sets a callback for when token is refreshed. The
+// callback runs while the manager is locked, so it must not call back into it.
 func (m *TokenManager) SetRefreshCallback(cb func(*Token)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.onTokenRefresh = cb
 }
 
+// SetRefreshErrorCallback sets a callback for when a background refresh
+// started by StartAutoRefresh fails, which is retried, or when a refreshed
+// token cannot be saved to the token store. Like the refresh callback, it
+// must not call back into the manager.
+func (m *TokenManager) SetRefreshErrorCallback(cb func(error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onRefreshError = cb
+}
+
 // GetToken returns a valid token, refreshing if needed.
 func (m *TokenManager) GetToken(ctx context.Context) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.token == nil {
 		return nil, fmt.Errorf("no token available")
 	}
 
-	if !m.token.IsExpired() {
+	if !m.token.IsExpiredAt(m.oauth.now()) {
 		return m.token, nil
 	}
 
+	return m.refresh(ctx)
+}
+
+// Refresh refreshes the token now, whether or not it has expired.
+func (m *TokenManager) Refresh(ctx context.Context) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token == nil {
+		return nil, fmt.Errorf("no token available")
+	}
+	return m.refresh(ctx)
+}
+
+// refresh exchanges the refresh token for a new token. m.mu must be held.
+func (m *TokenManager) refresh(ctx context.Context) (*Token, error) {
 	if m.token.RefreshToken == "" {
 		return nil, fmt.Errorf("token expired and no refresh token available")
 	}
@@ -206,6 +451,12 @@ func (m *TokenManager) GetToken(ctx context.Context) (*Token, error) {
 	}
 
 	m.token = newToken
+	if m.store != nil {
+		// The new token is usable even if it could not be saved.
+		if err := m.store.Save(ctx, newToken); err != nil && m.onRefreshError != nil {
+			m.onRefreshError(fmt.Errorf("saving token: %w", err))
+		}
+	}
 	if m.onTokenRefresh != nil {
 		m.onTokenRefresh(newToken)
 	}
@@ -213,11 +464,87 @@ func (m *TokenManager) GetToken(ctx context.Context) (*Token, error) {
 	return m.token, nil
 }
 
-// GetClient returns a Wise client with a valid token.
+// StartAutoRefresh refreshes the token in the background shortly before it
+// expires, with jitter so that many processes sharing a client don't refresh
+// at once. Failures are reported to the SetRefreshErrorCallback callback and
+// retried. The goroutine stops when ctx is cancelled.
+func (m *TokenManager) StartAutoRefresh(ctx context.Context) {
+	go m.autoRefresh(ctx)
+}
+
+func (m *TokenManager) autoRefresh(ctx context.Context) {
+	timer := time.NewTimer(m.nextRefresh())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		wait := m.nextRefresh()
+		if wait <= 0 {
+			if _, err := m.Refresh(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				m.refreshFailed(err)
+				wait = refreshRetryInterval
+			} else {
+				wait = m.nextRefresh()
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// nextRefresh returns how long to wait before the next background refresh.
+func (m *TokenManager) nextRefresh() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token == nil || m.token.RefreshToken == "" {
+		return refreshRetryInterval
+	}
+	ahead := refreshAhead + time.Duration(rand.Int64N(int64(refreshJitter)))
+	remaining := m.token.ExpiresAt.Sub(m.oauth.now())
+	if remaining <= ahead {
+		return 0
+	}
+	return remaining - ahead
+}
+
+func (m *TokenManager) refreshFailed(err error) {
+	m.mu.Lock()
+	cb := m.onRefreshError
+	m.mu.Unlock()
+	if cb != nil {
+		cb(err)
+	}
+}
+
+// Token implements TokenSource.
+func (m *TokenManager) Token(ctx context.Context) (*Token, error) {
+	return m.GetToken(ctx)
+}
+
+// GetClient returns a Wise client backed by the manager, after checking that
+// a valid token is available. The same Client is returned on every call and
+// uses refreshed tokens automatically.
 func (m *TokenManager) GetClient(ctx context.Context) (*Client, error) {
-	token, err := m.GetToken(ctx)
-	if err != nil {
+	if _, err := m.GetToken(ctx); err != nil {
 		return nil, err
 	}
-	return NewClientWithOAuth(token, m.oauth.config.Sandbox), nil
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.client == nil {
+		var opts []ClientOption
+		if m.oauth.config.Sandbox {
+			opts = append(opts, WithSandbox())
+		}
+		m.client = NewClientWithTokenSource(m, opts...)
+	}
+	return m.client, nil
 }
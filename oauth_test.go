@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -54,6 +56,22 @@ func TestOAuthClient_AuthURL_Sandbox(t *testing.T) {
 	}
 }
 
+func TestOAuthClient_OnboardingURL(t *testing.T) {
+	client := NewOAuthClient(OAuthConfig{
+		ClientID:    "test-client-id",
+		RedirectURL: "http://localhost:8080/callback",
+	})
+
+	url := client.OnboardingURL("state", "seller@example.com")
+
+	if !contains(url, "email=seller%40example.com") {
+		t.Errorf("OnboardingURL missing email, got: %s", url)
+	}
+	if !contains(url, "state=state") {
+		t.Errorf("OnboardingURL missing state, got: %s", url)
+	}
+}
+
 func TestOAuthClient_ExchangeCode(t *testing.T) {
 	// Mock token server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -152,6 +170,168 @@ func TestNewClientWithOAuth(t *testing.T) {
 	}
 }
 
+type testTokenSource struct{ tokens []string }
+
+func (ts *testTokenSource) Token(ctx context.Context) (*Token, error) {
+	t := &Token{AccessToken: ts.tokens[0]}
+	if len(ts.tokens) > 1 {
+		ts.tokens = ts.tokens[1:]
+	}
+	return t, nil
+}
+
+func TestNewClientWithTokenSource(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	ts := &testTokenSource{tokens: []string{"first", "refreshed"}}
+	client := NewClientWithTokenSource(ts, WithBaseURL(server.URL))
+	client.Profiles.List(context.Background())
+	client.Profiles.List(context.Background())
+
+	if len(auth) != 2 || auth[0] != "Bearer first" || auth[1] != "Bearer refreshed" {
+		t.Errorf("Expected the token to be fetched per request, got %q", auth)
+	}
+}
+
+func TestToken_HasScope(t *testing.T) {
+	token := &Token{Scope: "read transfers"}
+	if !token.HasScope(ScopeTransfers) || token.HasScope(ScopeBalances) {
+		t.Errorf("Unexpected scopes: %v", token.Scopes())
+	}
+}
+
+func TestOAuthConfig_Validate(t *testing.T) {
+	config := OAuthConfig{ClientID: "id", ClientSecret: "secret", Scopes: []string{ScopeRead, ScopeBalances}}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected a valid config, got %v", err)
+	}
+	config.Scopes = append(config.Scopes, "admin")
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an unknown scope to be rejected")
+	}
+}
+
+func TestTokenManager_Clock(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes, http.StatusOK)
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	oauth := NewOAuthClient(OAuthConfig{ClientID: "id", Clock: ClockFunc(func() time.Time { return now })})
+	oauth.endpoint = server.URL
+	m := NewTokenManager(oauth, &Token{AccessToken: "old", RefreshToken: "r1", ExpiresAt: now.Add(time.Hour)})
+
+	if token, _ := m.GetToken(context.Background()); token.AccessToken != "old" {
+		t.Fatalf("Expected the token to be valid, got %s", token.AccessToken)
+	}
+
+	now = now.Add(time.Hour)
+	token, err := m.GetToken(context.Background())
+	if err != nil || token.AccessToken != "refreshed" {
+		t.Fatalf("Expected the token to be refreshed, got %v, %v", token, err)
+	}
+	if want := now.Add(12 * time.Hour); !token.ExpiresAt.Equal(want) {
+		t.Errorf("Expected expiry %v from the clock, got %v", want, token.ExpiresAt)
+	}
+}
+
+func newRefreshServer(t *testing.T, refreshes *atomic.Int32, status int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"access_token":"refreshed","refresh_token":"r2","expires_in":43200}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTokenManager_ConcurrentRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes, http.StatusOK)
+	oauth := NewOAuthClient(OAuthConfig{ClientID: "id"})
+	oauth.endpoint = server.URL
+
+	m := NewTokenManager(oauth, &Token{AccessToken: "old", RefreshToken: "r1"})
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := m.GetToken(context.Background())
+			if err != nil || token.AccessToken != "refreshed" {
+				t.Errorf("GetToken = %v, %v", token, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("Expected a single refresh, got %d", n)
+	}
+}
+
+func TestTokenManager_AutoRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes, http.StatusOK)
+	oauth := NewOAuthClient(OAuthConfig{ClientID: "id"})
+	oauth.endpoint = server.URL
+
+	// Inside the refresh-ahead window, but not yet expired.
+	m := NewTokenManager(oauth, &Token{
+		AccessToken:  "old",
+		RefreshToken: "r1",
+		ExpiresAt:    time.Now().Add(6 * time.Minute),
+	})
+	refreshed := make(chan *Token, 1)
+	m.SetRefreshCallback(func(token *Token) { refreshed <- token })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.StartAutoRefresh(ctx)
+
+	select {
+	case token := <-refreshed:
+		if token.AccessToken != "refreshed" {
+			t.Errorf("Unexpected token: %s", token.AccessToken)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Token was not refreshed in the background")
+	}
+}
+
+func TestTokenManager_AutoRefreshError(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes, http.StatusBadRequest)
+	oauth := NewOAuthClient(OAuthConfig{ClientID: "id"})
+	oauth.endpoint = server.URL
+
+	m := NewTokenManager(oauth, &Token{AccessToken: "old", RefreshToken: "r1"})
+	failed := make(chan error, 1)
+	m.SetRefreshErrorCallback(func(err error) { failed <- err })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.StartAutoRefresh(ctx)
+
+	select {
+	case err := <-failed:
+		if err == nil {
+			t.Error("Expected a refresh error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Refresh failure was not reported")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
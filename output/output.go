@@ -0,0 +1,322 @@
+// Package output renders command results as JSON, CSV or an aligned text
+// table, so that CLI output can be piped to jq or opened in a spreadsheet.
+//
+// Values are structs or slices of structs, such as the results of the
+// commands package. JSON uses the structs' json tags. CSV and table columns
+// come from their csv tags: fields tagged "-" and lists are left out, nested
+// structs are flattened with their field name as prefix (fee_value,
+// fee_currency) and embedded structs are flattened without one. Times are
+// written in RFC 3339; other values implementing fmt.Stringer use String.
+//
+//	results, err := commands.GetRates(ctx, client, nil)
+//	...
+//	err = output.Write(os.Stdout, output.CSV, results)
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Format is an output format.
+type Format string
+
+const (
+	Text  Format = "text" // The command's own plain text, not rendered by this package
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	Table Format = "table"
+)
+
+// ParseFormat parses a format name. An empty name is Text.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return Text, nil
+	case Text, JSON, CSV, Table:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown output format %q: want text, json, csv or table", s)
+}
+
+// Write renders v to w in format f. v is a struct, a pointer to one or a
+// slice of them; for CSV and Table a single struct is one row.
+func Write(w io.Writer, f Format, v any) error {
+	switch f {
+	case JSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case CSV:
+		header, rows, err := Rows(v)
+		if err != nil {
+			return err
+		}
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+		return cw.WriteAll(rows)
+	case Table:
+		header, rows, err := Rows(v)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		upper := make([]string, len(header))
+		for i, h := range header {
+			upper[i] = strings.ToUpper(h)
+		}
+		fmt.Fprintln(tw, strings.Join(upper, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("output format %q cannot be written", f)
+}
+
+// Stream writes values one at a time as they arrive, e.g. from a watch
+// loop: JSON as one object per line, CSV and Table with the header before
+// the first value only. Table columns are as wide as the header and the
+// first value need; later, longer cells push the rest of their row right.
+type Stream struct {
+	w      io.Writer
+	f      Format
+	cw     *csv.Writer
+	widths []int
+}
+
+// NewStream returns a Stream writing to w in format f.
+func NewStream(w io.Writer, f Format) *Stream {
+	return &Stream{w: w, f: f}
+}
+
+// Write writes v, a struct or a slice of them.
+func (s *Stream) Write(v any) error {
+	switch s.f {
+	case JSON:
+		return json.NewEncoder(s.w).Encode(v)
+	case CSV:
+		header, rows, err := Rows(v)
+		if err != nil {
+			return err
+		}
+		if s.cw == nil {
+			s.cw = csv.NewWriter(s.w)
+			if err := s.cw.Write(header); err != nil {
+				return err
+			}
+		}
+		return s.cw.WriteAll(rows) // Flushes
+	case Table:
+		header, rows, err := Rows(v)
+		if err != nil {
+			return err
+		}
+		if s.widths == nil {
+			s.widths = make([]int, len(header))
+			for i, h := range header {
+				header[i] = strings.ToUpper(h)
+				s.widths[i] = len(h)
+				for _, row := range rows {
+					s.widths[i] = max(s.widths[i], len(row[i]))
+				}
+			}
+			rows = append([][]string{header}, rows...)
+		}
+		var b strings.Builder
+		for _, row := range rows {
+			for i, cell := range row {
+				if i == len(row)-1 {
+					b.WriteString(cell)
+					break
+				}
+				fmt.Fprintf(&b, "%-*s  ", s.widths[i], cell)
+			}
+			b.WriteByte('\n')
+		}
+		_, err = io.WriteString(s.w, b.String())
+		return err
+	}
+	return fmt.Errorf("output format %q cannot be streamed", s.f)
+}
+
+// Rows returns the column names and rows of v as used for CSV and Table.
+func Rows(v any) (header []string, rows [][]string, err error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil, fmt.Errorf("output: nil %T", v)
+		}
+		rv = rv.Elem()
+	}
+
+	var elem reflect.Type
+	switch rv.Kind() {
+	case reflect.Struct:
+		elem = rv.Type()
+	case reflect.Slice, reflect.Array:
+		elem = rv.Type().Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+	}
+	if elem == nil || elem.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("output: %T is not a struct or a slice of structs", v)
+	}
+
+	cols := columns(elem, "", nil)
+	for _, c := range cols {
+		header = append(header, c.name)
+	}
+	row := func(item reflect.Value) []string {
+		for item.Kind() == reflect.Pointer {
+			if item.IsNil() {
+				return make([]string, len(cols))
+			}
+			item = item.Elem()
+		}
+		cells := make([]string, len(cols))
+		for i, c := range cols {
+			if f, ok := fieldByIndex(item, c.index); ok {
+				cells[i] = format(f)
+			}
+		}
+		return cells
+	}
+	if rv.Kind() == reflect.Struct {
+		return header, [][]string{row(rv)}, nil
+	}
+	for i := 0; i < rv.Len(); i++ {
+		rows = append(rows, row(rv.Index(i)))
+	}
+	return header, rows, nil
+}
+
+type column struct {
+	name  string
+	index []int
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// columns lists the columns of struct type t, flattening nested structs.
+func columns(t reflect.Type, prefix string, index []int) []column {
+	var cols []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := fieldName(f)
+		if name == "-" {
+			continue
+		}
+		idx := append(append([]int(nil), index...), i)
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Struct && !isTime(ft) && (f.Anonymous || !implementsStringer(ft)):
+			p := prefix
+			if !f.Anonymous {
+				p = prefix + name + "_"
+			}
+			cols = append(cols, columns(ft, p, idx)...)
+		case ft.Kind() == reflect.Slice || ft.Kind() == reflect.Map || ft.Kind() == reflect.Array:
+			// Nested lists don't fit in a row.
+		default:
+			cols = append(cols, column{name: prefix + name, index: idx})
+		}
+	}
+	return cols
+}
+
+// fieldName returns the csv tag of f, or else its json tag, or else its
+// name in lower case.
+func fieldName(f reflect.StructField) string {
+	for _, key := range []string{"csv", "json"} {
+		if tag, ok := f.Tag.Lookup(key); ok {
+			if name, _, _ := strings.Cut(tag, ","); name != "" {
+				return name
+			}
+		}
+	}
+	return strings.ToLower(f.Name)
+}
+
+// isTime reports whether t is time.Time or a struct embedding it, such as
+// wise.Timestamp.
+func isTime(t reflect.Type) bool {
+	if t.ConvertibleTo(timeType) {
+		return true
+	}
+	return t.Kind() == reflect.Struct && t.NumField() > 0 && t.Field(0).Anonymous && t.Field(0).Type == timeType
+}
+
+func implementsStringer(t reflect.Type) bool {
+	stringer := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	return t.Implements(stringer) || reflect.PointerTo(t).Implements(stringer)
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex that reports nil pointers on
+// the way instead of panicking.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// format formats a cell value.
+func format(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if isTime(v.Type()) {
+		var t time.Time
+		if v.Type().ConvertibleTo(timeType) {
+			t = v.Convert(timeType).Interface().(time.Time)
+		} else {
+			t = v.Field(0).Interface().(time.Time)
+		}
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,119 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+	"github.com/joeblew999/plat-wise/commands"
+)
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": Text, "JSON": JSON, " csv": CSV, "table": Table} {
+		if got, err := ParseFormat(in); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected an error for xml")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rates := []commands.RateResult{
+		{From: "EUR", To: "USD", Rate: 1.08},
+		{From: "GBP", To: "INR", Error: "unavailable"},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, CSV, rates); err != nil {
+		t.Fatalf("Write CSV failed: %v", err)
+	}
+	want := "from,to,rate,time,reference,markup,reference_error,error\n" +
+		"EUR,USD,1.08,,0,0,,\n" +
+		"GBP,INR,0,,0,0,,unavailable\n"
+	if buf.String() != want {
+		t.Errorf("got CSV\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, JSON, rates); err != nil {
+		t.Fatalf("Write JSON failed: %v", err)
+	}
+	var decoded []commands.RateResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded) != 2 || decoded[0] != rates[0] {
+		t.Errorf("JSON does not round-trip: %s (%v)", buf.String(), err)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, Table, rates[0]); err != nil {
+		t.Fatalf("Write table failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "FROM  TO   RATE") || !strings.HasPrefix(lines[1], "EUR   USD  1.08") {
+		t.Errorf("unexpected table:\n%s", buf.String())
+	}
+}
+
+func TestRows_Nested(t *testing.T) {
+	type row struct {
+		commands.CurrencyPair
+		Fee     wise.Money
+		When    wise.Timestamp `csv:"when"`
+		Items   []string
+		Skipped string `csv:"-"`
+	}
+	header, rows, err := Rows([]*row{{
+		CurrencyPair: commands.CurrencyPair{From: "EUR", To: "USD"},
+		Fee:          wise.Money{Value: 0.5, Currency: wise.EUR},
+		When:         wise.Timestamp{Time: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}})
+	if err != nil {
+		t.Fatalf("Rows failed: %v", err)
+	}
+	if got := strings.Join(header, ","); got != "from,to,fee_value,fee_currency,when" {
+		t.Errorf("header = %s", got)
+	}
+	if got := strings.Join(rows[0], ","); got != "EUR,USD,0.5,EUR,2025-03-01T12:00:00Z" {
+		t.Errorf("row = %s", got)
+	}
+
+	if _, _, err := Rows([]int{1}); err == nil {
+		t.Error("expected an error for a slice of ints")
+	}
+}
+
+func TestStream(t *testing.T) {
+	updates := []commands.RateUpdate{
+		{RateResult: commands.RateResult{From: "EUR", To: "USD", Rate: 1.08}},
+		{RateResult: commands.RateResult{From: "EUR", To: "USD", Rate: 1.085}, Previous: 1.08},
+	}
+	for f, want := range map[Format][]string{
+		JSON:  {`{"from":"EUR"`, `"previous":1.08`},
+		CSV:   {"from,to,rate", "EUR,USD,1.085"},
+		Table: {"FROM  TO   RATE", "EUR   USD  1.085"},
+	} {
+		var buf bytes.Buffer
+		s := NewStream(&buf, f)
+		for _, u := range updates {
+			if err := s.Write(u); err != nil {
+				t.Fatalf("%s: Write failed: %v", f, err)
+			}
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if f != JSON && len(lines) != 3 {
+			t.Errorf("%s: expected a header and 2 rows, got\n%s", f, buf.String())
+		}
+		for _, w := range want {
+			if !strings.Contains(buf.String(), w) {
+				t.Errorf("%s: expected %q in\n%s", f, w, buf.String())
+			}
+		}
+	}
+	if err := NewStream(&bytes.Buffer{}, Text).Write(updates[0]); err == nil {
+		t.Error("expected an error streaming text")
+	}
+}
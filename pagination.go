@@ -0,0 +1,110 @@
+package wise
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is the page size used by iterators when none is given.
+const DefaultPageSize = 100
+
+// PageFunc fetches one page of results starting at offset.
+type PageFunc[T any] func(ctx context.Context, limit, offset int) ([]T, error)
+
+// Iterator pages through the results of a list endpoint that takes
+// limit/offset parameters, fetching the next page on demand.
+//
+//	it := client.Transfers.Iterate(&wise.ListTransfersParams{ProfileID: id})
+//	for it.Next(ctx) {
+//		t := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	fetch    PageFunc[T]
+	pageSize int
+	offset   int
+
+	page []T
+	idx  int
+	done bool
+	err  error
+}
+
+// NewIterator returns an iterator that fetches pages of pageSize items,
+// starting at offset. A pageSize of zero or less uses DefaultPageSize.
+func NewIterator[T any](pageSize, offset int, fetch PageFunc[T]) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Iterator[T]{fetch: fetch, pageSize: pageSize, offset: offset, idx: -1}
+}
+
+// Next advances to the next item, fetching a new page when needed. It
+// returns false when there are no more items or an error occurred.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.idx+1 < len(it.page) {
+		it.idx++
+		return true
+	}
+	if it.done {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	page, err := it.fetch(ctx, it.pageSize, it.offset)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = page
+	it.idx = 0
+	it.offset += len(page)
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+	return len(page) > 0
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.page[it.idx]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All returns the remaining items as a sequence for use with range.
+// Iteration stops at the first error, which is yielded with a zero item.
+func (it *Iterator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Value(), nil) {
+				return
+			}
+		}
+		if it.err != nil {
+			var zero T
+			yield(zero, it.err)
+		}
+	}
+}
+
+// Collect reads all remaining items into a slice.
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.err
+}
@@ -0,0 +1,67 @@
+package wise
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestIterator_Pages(t *testing.T) {
+	items := make([]int, 250)
+	for i := range items {
+		items[i] = i
+	}
+	fetches := 0
+	it := NewIterator(100, 0, func(ctx context.Context, limit, offset int) ([]int, error) {
+		fetches++
+		end := min(offset+limit, len(items))
+		return items[offset:end], nil
+	})
+
+	got, err := it.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	if len(got) != 250 || got[249] != 249 {
+		t.Errorf("Expected 250 items in order, got %d", len(got))
+	}
+	if fetches != 3 {
+		t.Errorf("Expected 3 fetches, got %d", fetches)
+	}
+}
+
+func TestIterator_Error(t *testing.T) {
+	errBoom := errors.New("boom")
+	it := NewIterator(2, 0, func(ctx context.Context, limit, offset int) ([]string, error) {
+		if offset > 0 {
+			return nil, errBoom
+		}
+		return []string{"a", "b"}, nil
+	})
+
+	var got []string
+	for v, err := range it.All(context.Background()) {
+		if err != nil {
+			if !errors.Is(err, errBoom) {
+				t.Errorf("Expected boom, got %v", err)
+			}
+			break
+		}
+		got = append(got, v)
+	}
+	if len(got) != 2 {
+		t.Errorf("Expected 2 items before the error, got %v", got)
+	}
+}
+
+func TestIterator_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it := NewIterator(10, 0, func(ctx context.Context, limit, offset int) ([]int, error) {
+		t.Fatal("fetch should not be called after cancellation")
+		return nil, nil
+	})
+	if it.Next(ctx) || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+}
@@ -0,0 +1,51 @@
+package wise
+
+import (
+	"context"
+)
+
+// PartnerService handles partner (platform) API calls for provisioning users.
+// These endpoints require a client credentials token, see
+// OAuthClient.ClientCredentials.
+type PartnerService struct {
+	client *Client
+}
+
+// CreatePartnerUserRequest represents the request to create a user on
+// behalf of a partner. The registration code is a secret generated by the
+// partner and kept for the user; it is later exchanged for the user's
+// tokens with OAuthClient.RegistrationCodeToken.
+type CreatePartnerUserRequest struct {
+	Email            string `json:"email"`
+	RegistrationCode string `json:"registrationCode"`
+	Language         string `json:"language,omitempty"` // e.g. en, de, fr
+}
+
+// CreateUser creates a Wise user linked to the partner's client.
+// If the email already belongs to a Wise user the API returns a 409
+// conflict; such users must be linked through OAuthClient.OnboardingURL instead.
+// POST /v1/user/signup/registration_code
+func (s *PartnerService) CreateUser(ctx context.Context, req *CreatePartnerUserRequest) (*User, error) {
+	var user User
+	err := s.client.Post(ctx, "/v1/user/signup/registration_code", req, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UserExists reports whether a Wise user with the given email already exists.
+// POST /v1/users/exists
+func (s *PartnerService) UserExists(ctx context.Context, email string) (bool, error) {
+	req := struct {
+		Email string `json:"email"`
+	}{Email: email}
+	var result struct {
+		Exists bool `json:"exists"`
+	}
+	err := s.client.Post(ctx, "/v1/users/exists", req, &result)
+	if err != nil {
+		return false, err
+	}
+	return result.Exists, nil
+}
@@ -0,0 +1,93 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// PricingService handles fee structure API calls.
+// Unlike quotes, pricing does not reserve a rate and needs no profile.
+type PricingService struct {
+	client *Client
+}
+
+// Price represents the fee structure for a route and payment method.
+type Price struct {
+	PriceSetID         int64    `json:"priceSetId"`
+	SourceCurrency     Currency `json:"sourceCurrency"`
+	TargetCurrency     Currency `json:"targetCurrency"`
+	SourceAmount       float64  `json:"sourceAmount,omitempty"`
+	PayInMethod        string   `json:"payInMethod"`
+	PayOutMethod       string   `json:"payOutMethod"`
+	FixedFee           float64  `json:"fixedFee"`
+	VariableFeePercent float64  `json:"variableFeePercent"`
+	VariableFee        float64  `json:"variableFee,omitempty"`
+	Total              float64  `json:"total,omitempty"`
+	MinInvoiceAmount   float64  `json:"minInvoiceAmount,omitempty"`
+	MaxInvoiceAmount   float64  `json:"maxInvoiceAmount,omitempty"`
+}
+
+// PricingParams represents the parameters for fetching prices.
+type PricingParams struct {
+	SourceCurrency Currency
+	TargetCurrency Currency
+	SourceAmount   float64
+	PayInMethod    string      // BANK_TRANSFER, BALANCE, DEBIT, CREDIT, ...
+	PayOutMethod   string      // BANK_TRANSFER, BALANCE, ...
+	ProfileType    ProfileType // personal or business
+	ProfileCountry string      // ISO 3166-1 alpha-2
+}
+
+// List returns the fee structures for a currency route, one per payment method combination.
+// GET /v1/pricing
+func (s *PricingService) List(ctx context.Context, params *PricingParams) ([]Price, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.SourceCurrency != "" {
+			query.Set("sourceCurrency", string(params.SourceCurrency))
+		}
+		if params.TargetCurrency != "" {
+			query.Set("targetCurrency", string(params.TargetCurrency))
+		}
+		if params.SourceAmount > 0 {
+			query.Set("sourceAmount", strconv.FormatFloat(params.SourceAmount, 'f', -1, 64))
+		}
+		if params.PayInMethod != "" {
+			query.Set("payInMethod", params.PayInMethod)
+		}
+		if params.PayOutMethod != "" {
+			query.Set("payOutMethod", params.PayOutMethod)
+		}
+		if params.ProfileType != "" {
+			query.Set("profileType", string(params.ProfileType))
+		}
+		if params.ProfileCountry != "" {
+			query.Set("profileCountry", params.ProfileCountry)
+		}
+	}
+
+	var prices []Price
+	err := s.client.Get(ctx, "/v1/pricing", query, &prices)
+	if err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// Fee returns the estimated fee for sending amount using this price.
+func (p *Price) Fee(amount float64) float64 {
+	return p.FixedFee + amount*p.VariableFeePercent/100
+}
+
+// ValidateAmount checks amount against the minimum and maximum for this price.
+func (p *Price) ValidateAmount(amount float64) error {
+	if p.MinInvoiceAmount > 0 && amount < p.MinInvoiceAmount {
+		return fmt.Errorf("amount %.2f is below minimum %.2f %s", amount, p.MinInvoiceAmount, p.SourceCurrency)
+	}
+	if p.MaxInvoiceAmount > 0 && amount > p.MaxInvoiceAmount {
+		return fmt.Errorf("amount %.2f exceeds maximum %.2f %s", amount, p.MaxInvoiceAmount, p.SourceCurrency)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package wise
 import (
 	"context"
 	"fmt"
+	"io"
 )
 
 // ProfilesService handles profile-related API calls.
@@ -55,6 +56,18 @@ type CreateBusinessProfileRequest struct {
 	Details *BusinessProfile `json:"details"`
 }
 
+// UpdatePersonalProfileRequest represents the request to update a personal profile.
+type UpdatePersonalProfileRequest struct {
+	Type    ProfileType      `json:"type"`
+	Details *PersonalProfile `json:"details"`
+}
+
+// UpdateBusinessProfileRequest represents the request to update a business profile.
+type UpdateBusinessProfileRequest struct {
+	Type    ProfileType      `json:"type"`
+	Details *BusinessProfile `json:"details"`
+}
+
 // List returns all profiles belonging to the authenticated user.
 // GET /v1/profiles
 func (s *ProfilesService) List(ctx context.Context) ([]Profile, error) {
@@ -107,3 +120,176 @@ func (s *ProfilesService) CreateBusiness(ctx context.Context, details *BusinessP
 	}
 	return &profile, nil
 }
+
+// UpdatePersonal updates the details of a personal profile.
+// PUT /v1/profiles/{profileId}
+func (s *ProfilesService) UpdatePersonal(ctx context.Context, profileID int64, details *PersonalProfile) (*Profile, error) {
+	req := UpdatePersonalProfileRequest{
+		Type:    ProfileTypePersonal,
+		Details: details,
+	}
+	var profile Profile
+	path := fmt.Sprintf("/v1/profiles/%d", profileID)
+	err := s.client.Put(ctx, path, req, &profile)
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// UpdateBusiness updates the details of a business profile.
+// PUT /v1/profiles/{profileId}
+func (s *ProfilesService) UpdateBusiness(ctx context.Context, profileID int64, details *BusinessProfile) (*Profile, error) {
+	req := UpdateBusinessProfileRequest{
+		Type:    ProfileTypeBusiness,
+		Details: details,
+	}
+	var profile Profile
+	path := fmt.Sprintf("/v1/profiles/%d", profileID)
+	err := s.client.Put(ctx, path, req, &profile)
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// VerificationStatus represents the verification (KYC) state of a profile.
+type VerificationStatus struct {
+	ProfileID         int64    `json:"profileId,omitempty"`
+	Status            string   `json:"status"` // VERIFIED, NOT_VERIFIED, PENDING
+	RequiredEvidences []string `json:"required_evidences,omitempty"`
+}
+
+// IsVerified returns true if the profile is verified with no outstanding evidence.
+func (v *VerificationStatus) IsVerified() bool {
+	return v.Status == "VERIFIED" && len(v.RequiredEvidences) == 0
+}
+
+// KYCReview represents a verification review and its outstanding requirements.
+type KYCReview struct {
+	ID           string           `json:"id"`
+	Status       string           `json:"status"` // NEW, PASSED, FAILED, WAITING_CUSTOMER_INPUT, PROCESSING
+	TriggerType  string           `json:"triggerType,omitempty"`
+	RequiredBy   Timestamp        `json:"requiredBy,omitempty"`
+	Link         *KYCReviewLink   `json:"link,omitempty"`
+	Requirements []KYCRequirement `json:"requirements,omitempty"`
+	CreatedAt    Timestamp        `json:"createdAt,omitempty"`
+	UpdatedAt    Timestamp        `json:"updatedAt,omitempty"`
+}
+
+// KYCReviewLink is the hosted page where the user can complete a review.
+type KYCReviewLink struct {
+	Value     string    `json:"value"`
+	ExpiresAt Timestamp `json:"expiresAt,omitempty"`
+}
+
+// KYCRequirement is a single piece of information or evidence a review needs.
+type KYCRequirement struct {
+	Key         string `json:"key"`   // e.g. ID_DOCUMENT, SOURCE_OF_FUNDS
+	State       string `json:"state"` // NOT_PROVIDED, PROVIDED, VERIFIED
+	Description string `json:"description,omitempty"`
+}
+
+// GetVerificationStatus returns the verification status of a profile.
+// GET /v3/profiles/{profileId}/verification-status
+func (s *ProfilesService) GetVerificationStatus(ctx context.Context, profileID int64) (*VerificationStatus, error) {
+	var status VerificationStatus
+	path := fmt.Sprintf("/v3/profiles/%d/verification-status", profileID)
+	err := s.client.Get(ctx, path, nil, &status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetRequiredEvidences returns the evidence still required to verify a profile.
+// GET /v3/profiles/{profileId}/verification-status/required-evidences
+func (s *ProfilesService) GetRequiredEvidences(ctx context.Context, profileID int64) ([]string, error) {
+	var result struct {
+		RequiredEvidences []string `json:"required_evidences"`
+	}
+	path := fmt.Sprintf("/v3/profiles/%d/verification-status/required-evidences", profileID)
+	err := s.client.Get(ctx, path, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.RequiredEvidences, nil
+}
+
+// ListKYCReviews returns the verification reviews for a profile.
+// GET /v1/profiles/{profileId}/kyc-reviews
+func (s *ProfilesService) ListKYCReviews(ctx context.Context, profileID int64) ([]KYCReview, error) {
+	var reviews []KYCReview
+	path := fmt.Sprintf("/v1/profiles/%d/kyc-reviews", profileID)
+	err := s.client.Get(ctx, path, nil, &reviews)
+	if err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// IdentificationDocument describes an identity document held by the profile owner.
+type IdentificationDocument struct {
+	Type             string `json:"type"`                    // PASSPORT, IDENTITY_CARD, DRIVERS_LICENCE, ...
+	UniqueIdentifier string `json:"uniqueIdentifier"`        // Document number
+	IssueDate        string `json:"issueDate,omitempty"`     // YYYY-MM-DD
+	ExpiryDate       string `json:"expiryDate,omitempty"`    // YYYY-MM-DD
+	IssuerCountry    string `json:"issuerCountry,omitempty"` // ISO 3166-1 alpha-2
+	IssuerState      string `json:"issuerState,omitempty"`
+	Nationality      string `json:"nationality,omitempty"`
+}
+
+// VerificationDocument is a document file uploaded for profile verification.
+type VerificationDocument struct {
+	ID           string    `json:"id"`
+	DocumentType string    `json:"documentType"`
+	FileName     string    `json:"fileName,omitempty"`
+	Status       string    `json:"status,omitempty"`
+	CreatedAt    Timestamp `json:"createdAt,omitempty"`
+}
+
+// ProfileExtension holds additional onboarding details Wise may require,
+// keyed by field name (e.g. "occupation", "webpage", "annualTurnover").
+// The required keys are listed by the profile's KYC requirements.
+type ProfileExtension map[string]interface{}
+
+// AddIdentificationDocument records the details of an identity document for a profile.
+// POST /v1/profiles/{profileId}/verification-documents
+func (s *ProfilesService) AddIdentificationDocument(ctx context.Context, profileID int64, doc *IdentificationDocument) error {
+	path := fmt.Sprintf("/v1/profiles/%d/verification-documents", profileID)
+	return s.client.Post(ctx, path, doc, nil)
+}
+
+// UploadVerificationDocument uploads a document file (passport scan, proof
+// of address, company registration, ...) requested during verification.
+// POST /v3/profiles/{profileId}/verification-documents
+func (s *ProfilesService) UploadVerificationDocument(ctx context.Context, profileID int64, documentType, fileName string, content io.Reader) (*VerificationDocument, error) {
+	fields := map[string]string{"documentType": documentType}
+
+	var doc VerificationDocument
+	path := fmt.Sprintf("/v3/profiles/%d/verification-documents", profileID)
+	err := s.client.Upload(ctx, path, &UploadFile{FileName: fileName, Content: content}, fields, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// GetExtension returns the additional onboarding details of a profile.
+// GET /v1/profiles/{profileId}/extensions
+func (s *ProfilesService) GetExtension(ctx context.Context, profileID int64) (ProfileExtension, error) {
+	var ext ProfileExtension
+	path := fmt.Sprintf("/v1/profiles/%d/extensions", profileID)
+	err := s.client.Get(ctx, path, nil, &ext)
+	if err != nil {
+		return nil, err
+	}
+	return ext, nil
+}
+
+// UpdateExtension sets additional onboarding details on a profile.
+// PUT /v1/profiles/{profileId}/extensions
+func (s *ProfilesService) UpdateExtension(ctx context.Context, profileID int64, ext ProfileExtension) error {
+	path := fmt.Sprintf("/v1/profiles/%d/extensions", profileID)
+	return s.client.Put(ctx, path, ext, nil)
+}
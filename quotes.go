@@ -3,6 +3,7 @@ package wise
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // QuotesService handles quote-related API calls.
@@ -10,6 +11,23 @@ type QuotesService struct {
 	client *Client
 }
 
+// RateType represents how the rate of a quote is determined.
+type RateType string
+
+const (
+	// RateTypeFixed is a guaranteed rate, locked until the quote's rate expiration time.
+	RateTypeFixed RateType = "FIXED"
+	// RateTypeFloating follows the market rate until the transfer is funded.
+	RateTypeFloating RateType = "FLOATING"
+)
+
+// Pay-out methods for SWIFT wires. SWIFT_OUR means the sender pays all
+// correspondent bank charges so the recipient gets the full amount.
+const (
+	PayOutSwift    = "SWIFT"
+	PayOutSwiftOur = "SWIFT_OUR"
+)
+
 // Quote represents a quote for a money transfer.
 type Quote struct {
 	ID                   string        `json:"id"`
@@ -22,9 +40,10 @@ type Quote struct {
 	CreatedTime          Timestamp     `json:"createdTime"`
 	User                 int64         `json:"user"`
 	Profile              int64         `json:"profile"`
-	RateType             string        `json:"rateType,omitempty"`
+	RateType             RateType      `json:"rateType,omitempty"`
 	RateExpirationTime   Timestamp     `json:"rateExpirationTime"`
 	GuaranteedTargetAmount bool        `json:"guaranteedTargetAmount,omitempty"`
+	GuaranteedTargetAmountAllowed bool `json:"guaranteedTargetAmountAllowed,omitempty"`
 	ProvidedAmountType   string        `json:"providedAmountType,omitempty"`
 	PaymentOptions       []PaymentOption `json:"paymentOptions,omitempty"`
 	Status               string        `json:"status,omitempty"`
@@ -39,13 +58,35 @@ type PaymentOption struct {
 	PayInProduct               string     `json:"payInProduct,omitempty"`
 	FeePercentage              float64    `json:"feePercentage,omitempty"`
 	EstimatedDeliveryDelays    []string   `json:"estimatedDeliveryDelays,omitempty"`
-	Fee                        Money      `json:"fee,omitempty"`
+	Fee                        PaymentOptionFee `json:"fee,omitempty"`
 	SourceAmount               float64    `json:"sourceAmount,omitempty"`
 	TargetAmount               float64    `json:"targetAmount,omitempty"`
 	PayIn                      string     `json:"payIn,omitempty"`
+	PayOut                     string     `json:"payOut,omitempty"`
 	Disabled                   bool       `json:"disabled,omitempty"`
 }
 
+// SwiftCharges returns the SWIFT charge option of the payment option, or an
+// empty string if it does not pay out over SWIFT.
+func (o *PaymentOption) SwiftCharges() SwiftCharges {
+	switch o.PayOut {
+	case PayOutSwiftOur:
+		return SwiftChargesOur
+	case PayOutSwift:
+		return SwiftChargesShared
+	}
+	return ""
+}
+
+// PaymentOptionFee is the fee breakdown of a payment option.
+type PaymentOptionFee struct {
+	Transferwise float64 `json:"transferwise"`
+	PayIn        float64 `json:"payIn"`
+	Discount     float64 `json:"discount"`
+	Partner      float64 `json:"partner,omitempty"`
+	Total        float64 `json:"total"`
+}
+
 // CreateQuoteRequest represents the request to create a quote.
 type CreateQuoteRequest struct {
 	SourceCurrency     Currency `json:"sourceCurrency"`
@@ -55,6 +96,29 @@ type CreateQuoteRequest struct {
 	Profile            int64    `json:"profile,omitempty"`
 	PayOut             string   `json:"payOut,omitempty"`             // BANK_TRANSFER, BALANCE, etc.
 	PreferredPayIn     string   `json:"preferredPayIn,omitempty"`     // BANK_TRANSFER, BALANCE, etc.
+	RateType           RateType `json:"rateType,omitempty"`           // FIXED to request a guaranteed rate
+	TargetAccount      int64    `json:"targetAccount,omitempty"`      // Recipient ID, for options and fees specific to the recipient
+	PricingConfiguration *PricingConfiguration `json:"pricingConfiguration,omitempty"`
+
+	// PayInMethods is a local filter, not a Wise API field: Create and
+	// CreateV2 drop the payment options of the returned quote whose pay-in
+	// method (e.g. BALANCE, BANK_TRANSFER) is not listed. Wise still prices
+	// the quote for every method; set PreferredPayIn to choose the one the
+	// quote's amounts are for.
+	PayInMethods []string `json:"-"`
+}
+
+// PricingConfiguration overrides the fee charged on a quote. It is only
+// available to partners with a custom pricing agreement.
+type PricingConfiguration struct {
+	Fee PricingConfigurationFee `json:"fee"`
+}
+
+// PricingConfigurationFee describes a partner fee override.
+type PricingConfigurationFee struct {
+	Type     string  `json:"type"`               // OVERRIDE
+	Variable float64 `json:"variable,omitempty"` // Percentage of the source amount, e.g. 0.01 for 1%
+	Fixed    float64 `json:"fixed,omitempty"`    // Fixed fee in the source currency
 }
 
 // UpdateQuoteRequest represents the request to update a quote.
@@ -63,9 +127,11 @@ type UpdateQuoteRequest struct {
 	TargetAmount   *float64 `json:"targetAmount,omitempty"`
 	PayOut         string   `json:"payOut,omitempty"`
 	PreferredPayIn string   `json:"preferredPayIn,omitempty"`
+	TargetAccount  int64    `json:"targetAccount,omitempty"`
 }
 
-// Create creates a new quote.
+// Create creates a new quote, keeping only the payment options of
+// req.PayInMethods if set.
 // POST /v3/profiles/{profileId}/quotes
 func (s *QuotesService) Create(ctx context.Context, profileID int64, req *CreateQuoteRequest) (*Quote, error) {
 	var quote Quote
@@ -74,10 +140,12 @@ func (s *QuotesService) Create(ctx context.Context, profileID int64, req *Create
 	if err != nil {
 		return nil, err
 	}
+	quote.filterPayIn(req.PayInMethods)
 	return &quote, nil
 }
 
 // CreateV2 creates a new quote using the v2 API (simpler, doesn't require profile ID in path).
+// Like Create, it applies req.PayInMethods to the response.
 // POST /v2/quotes
 func (s *QuotesService) CreateV2(ctx context.Context, req *CreateQuoteRequest) (*Quote, error) {
 	var quote Quote
@@ -85,6 +153,7 @@ func (s *QuotesService) CreateV2(ctx context.Context, req *CreateQuoteRequest) (
 	if err != nil {
 		return nil, err
 	}
+	quote.filterPayIn(req.PayInMethods)
 	return &quote, nil
 }
 
@@ -123,3 +192,108 @@ func (s *QuotesService) Update(ctx context.Context, profileID int64, quoteID str
 	}
 	return &quote, nil
 }
+
+// filterPayIn drops payment options whose pay-in method is not in methods.
+// An empty list keeps all options.
+func (q *Quote) filterPayIn(methods []string) {
+	if len(methods) == 0 {
+		return
+	}
+	options := q.PaymentOptions[:0]
+	for _, opt := range q.PaymentOptions {
+		for _, m := range methods {
+			if opt.PayIn == m {
+				options = append(options, opt)
+				break
+			}
+		}
+	}
+	q.PaymentOptions = options
+}
+
+// CheapestOption returns the enabled payment option with the lowest total fee,
+// preferring the higher target amount on ties. It returns nil if none are enabled.
+func (q *Quote) CheapestOption() *PaymentOption {
+	var best *PaymentOption
+	for i := range q.PaymentOptions {
+		opt := &q.PaymentOptions[i]
+		if opt.Disabled {
+			continue
+		}
+		if best == nil || opt.Fee.Total < best.Fee.Total ||
+			(opt.Fee.Total == best.Fee.Total && opt.TargetAmount > best.TargetAmount) {
+			best = opt
+		}
+	}
+	return best
+}
+
+// FastestOption returns the enabled payment option with the earliest estimated
+// delivery. It returns nil if none are enabled.
+func (q *Quote) FastestOption() *PaymentOption {
+	var best *PaymentOption
+	for i := range q.PaymentOptions {
+		opt := &q.PaymentOptions[i]
+		if opt.Disabled || opt.EstimatedDelivery.IsZero() {
+			continue
+		}
+		if best == nil || opt.EstimatedDelivery.Before(best.EstimatedDelivery.Time) {
+			best = opt
+		}
+	}
+	return best
+}
+
+// OptionByPayIn returns the enabled payment option for a pay-in method
+// (e.g. BALANCE, BANK_TRANSFER, DEBIT), matching the quote's pay-out method
+// when one is set. It returns nil if there is no such option.
+func (q *Quote) OptionByPayIn(payIn string) *PaymentOption {
+	for i := range q.PaymentOptions {
+		opt := &q.PaymentOptions[i]
+		if opt.Disabled || opt.PayIn != payIn {
+			continue
+		}
+		if q.PayOut != "" && opt.PayOut != "" && opt.PayOut != q.PayOut {
+			continue
+		}
+		return opt
+	}
+	return nil
+}
+
+// IsRateGuaranteed reports whether the quote has a fixed rate that has not
+// yet expired. Funding the transfer before then locks in the quoted rate.
+func (q *Quote) IsRateGuaranteed() bool {
+	return q.IsRateGuaranteedAt(time.Now())
+}
+
+// IsRateGuaranteedAt is IsRateGuaranteed at the given time, e.g. from a Clock.
+func (q *Quote) IsRateGuaranteedAt(now time.Time) bool {
+	return q.RateType == RateTypeFixed && q.TimeUntilExpiryAt(now) > 0
+}
+
+// TimeUntilExpiry returns how long the quoted rate remains valid, or zero
+// if it has expired or no expiration time is set.
+func (q *Quote) TimeUntilExpiry() time.Duration {
+	return q.TimeUntilExpiryAt(time.Now())
+}
+
+// TimeUntilExpiryAt is TimeUntilExpiry at the given time, e.g. from a Clock.
+func (q *Quote) TimeUntilExpiryAt(now time.Time) time.Duration {
+	if q.RateExpirationTime.IsZero() {
+		return 0
+	}
+	if d := q.RateExpirationTime.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// GuaranteeWindow returns the total length of the rate guarantee, from quote
+// creation to rate expiration. It returns zero for floating-rate quotes.
+func (q *Quote) GuaranteeWindow() time.Duration {
+	if q.RateType != RateTypeFixed || q.CreatedTime.IsZero() || q.RateExpirationTime.IsZero() {
+		return 0
+	}
+	return q.RateExpirationTime.Sub(q.CreatedTime.Time)
+}
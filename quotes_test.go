@@ -0,0 +1,86 @@
+package wise
+
+import (
+	"testing"
+	"time"
+)
+
+func testQuote() *Quote {
+	now := time.Now()
+	return &Quote{
+		PayOut: "BANK_TRANSFER",
+		PaymentOptions: []PaymentOption{
+			{PayIn: "DEBIT", PayOut: "BANK_TRANSFER", Fee: PaymentOptionFee{Total: 5}, TargetAmount: 90, EstimatedDelivery: Timestamp{Time: now.Add(time.Hour)}},
+			{PayIn: "BALANCE", PayOut: "BANK_TRANSFER", Fee: PaymentOptionFee{Total: 2}, TargetAmount: 93, EstimatedDelivery: Timestamp{Time: now.Add(2 * time.Hour)}},
+			{PayIn: "BANK_TRANSFER", PayOut: "BANK_TRANSFER", Fee: PaymentOptionFee{Total: 2}, TargetAmount: 92, EstimatedDelivery: Timestamp{Time: now.Add(48 * time.Hour)}},
+			{PayIn: "CREDIT", PayOut: "BANK_TRANSFER", Fee: PaymentOptionFee{Total: 0}, TargetAmount: 95, Disabled: true},
+		},
+	}
+}
+
+func TestQuote_CheapestOption(t *testing.T) {
+	opt := testQuote().CheapestOption()
+	if opt == nil || opt.PayIn != "BALANCE" {
+		t.Errorf("Expected BALANCE as cheapest, got %+v", opt)
+	}
+}
+
+func TestQuote_FastestOption(t *testing.T) {
+	opt := testQuote().FastestOption()
+	if opt == nil || opt.PayIn != "DEBIT" {
+		t.Errorf("Expected DEBIT as fastest, got %+v", opt)
+	}
+}
+
+func TestQuote_OptionByPayIn(t *testing.T) {
+	q := testQuote()
+	if opt := q.OptionByPayIn("BANK_TRANSFER"); opt == nil || opt.TargetAmount != 92 {
+		t.Errorf("Expected BANK_TRANSFER option, got %+v", opt)
+	}
+	if opt := q.OptionByPayIn("CREDIT"); opt != nil {
+		t.Errorf("Disabled option should not be returned, got %+v", opt)
+	}
+	if opt := (&Quote{}).CheapestOption(); opt != nil {
+		t.Errorf("Expected nil for quote without options, got %+v", opt)
+	}
+}
+
+func TestQuote_IsRateGuaranteed(t *testing.T) {
+	now := time.Now()
+	q := &Quote{
+		RateType:           RateTypeFixed,
+		CreatedTime:        Timestamp{Time: now.Add(-time.Hour)},
+		RateExpirationTime: Timestamp{Time: now.Add(time.Hour)},
+	}
+	if !q.IsRateGuaranteed() {
+		t.Error("Expected fixed, unexpired quote to be guaranteed")
+	}
+	if d := q.TimeUntilExpiry(); d <= 0 || d > time.Hour {
+		t.Errorf("Expected expiry within an hour, got %v", d)
+	}
+	if w := q.GuaranteeWindow(); w != 2*time.Hour {
+		t.Errorf("Expected 2h guarantee window, got %v", w)
+	}
+
+	q.RateExpirationTime = Timestamp{Time: now.Add(-time.Minute)}
+	if q.IsRateGuaranteed() || q.TimeUntilExpiry() != 0 {
+		t.Error("Expected expired quote not to be guaranteed")
+	}
+
+	q.RateType = RateTypeFloating
+	q.RateExpirationTime = Timestamp{Time: now.Add(time.Hour)}
+	if q.IsRateGuaranteed() || q.GuaranteeWindow() != 0 {
+		t.Error("Expected floating quote not to be guaranteed")
+	}
+}
+
+func TestQuote_FilterPayIn(t *testing.T) {
+	q := testQuote()
+	q.filterPayIn([]string{"BALANCE", "DEBIT"})
+	if len(q.PaymentOptions) != 2 {
+		t.Fatalf("Expected 2 options, got %d", len(q.PaymentOptions))
+	}
+	if opt := q.CheapestOption(); opt == nil || opt.PayIn != "BALANCE" {
+		t.Errorf("Expected BALANCE as cheapest, got %+v", opt)
+	}
+}
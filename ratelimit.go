@@ -0,0 +1,99 @@
+package wise
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// lowBudget is the share of the rate limit below which WithAdaptiveRateLimit
+// starts spacing out requests.
+const lowBudget = 0.1
+
+// WithRateLimit throttles requests on the client side to requestsPerSecond,
+// allowing bursts of up to burst requests. Requests wait for a slot, or
+// fail once their context is done. Each retry attempt takes its own slot.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		if requestsPerSecond <= 0 {
+			c.limiter = nil
+			return
+		}
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
+// WithAdaptiveRateLimit slows requests down when less than 10% of the API's
+// rate limit budget is left (see RateLimitStatus), spreading the remaining
+// requests evenly until the limit resets. Use it for long batch jobs that
+// would otherwise run into 429 responses.
+func WithAdaptiveRateLimit() ClientOption {
+	return func(c *Client) {
+		c.adaptiveRateLimit = true
+	}
+}
+
+// RateLimitStatus is the API rate limit budget, from the rate limit headers
+// of the most recent response.
+type RateLimitStatus struct {
+	Limit     int       // Requests allowed per window
+	Remaining int       // Requests left in the current window
+	Reset     time.Time // When the window resets
+	UpdatedAt time.Time // When the headers were received
+}
+
+// Known returns true if a response has reported the rate limit.
+func (s RateLimitStatus) Known() bool {
+	return !s.UpdatedAt.IsZero()
+}
+
+// Low returns true if less than 10% of the budget is left and the window
+// has not reset yet.
+func (s RateLimitStatus) Low() bool {
+	return s.Known() && s.Limit > 0 && float64(s.Remaining) < lowBudget*float64(s.Limit) &&
+		time.Now().Before(s.Reset)
+}
+
+// RateLimitStatus returns the rate limit budget reported by the API on the
+// most recent response that carried rate limit headers.
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	return c.rateLimit
+}
+
+// updateRateLimit records the rate limit headers of a response, if any.
+func (c *Client) updateRateLimit(header http.Header) {
+	remaining, ok := rateLimitHeader(header, "Remaining")
+	if !ok {
+		return
+	}
+	status := RateLimitStatus{Remaining: remaining, UpdatedAt: time.Now()}
+	status.Limit, _ = rateLimitHeader(header, "Limit")
+	if reset, ok := rateLimitHeader(header, "Reset"); ok {
+		status.Reset = rateLimitResetTime(reset)
+	}
+
+	c.rateLimitMu.Lock()
+	defer c.rateLimitMu.Unlock()
+	c.rateLimit = status
+}
+
+// waitForBudget sleeps before a request when WithAdaptiveRateLimit is set and
+// the budget is low, spacing the remaining requests until the reset.
+func (c *Client) waitForBudget(ctx context.Context) error {
+	if !c.adaptiveRateLimit {
+		return nil
+	}
+	status := c.RateLimitStatus()
+	if !status.Low() {
+		return nil
+	}
+	wait := time.Until(status.Reset) / time.Duration(status.Remaining+1)
+	return sleep(ctx, wait)
+}
@@ -3,6 +3,7 @@ package wise
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 )
@@ -22,6 +23,7 @@ type Recipient struct {
 	Country           string                 `json:"country,omitempty"` // ISO 3166-1 alpha-2
 	Active            bool                   `json:"active"`
 	OwnedByCustomer   bool                   `json:"ownedByCustomer,omitempty"`
+	Nickname          string                 `json:"nickname,omitempty"`
 	Details           map[string]interface{} `json:"details"`
 }
 
@@ -35,6 +37,14 @@ type CreateRecipientRequest struct {
 	OwnedByCustomer   bool                   `json:"ownedByCustomer,omitempty"`
 }
 
+// UpdateRecipientRequest represents the request to update a recipient.
+// Only the fields that are set are changed.
+type UpdateRecipientRequest struct {
+	AccountHolderName string                 `json:"accountHolderName,omitempty"`
+	Nickname          string                 `json:"nickname,omitempty"`
+	Details           map[string]interface{} `json:"details,omitempty"`
+}
+
 // RecipientRequirements represents the requirements for creating a recipient.
 type RecipientRequirements struct {
 	Type   string                 `json:"type"`
@@ -64,6 +74,29 @@ type RecipientFieldGroup struct {
 	ValuesAllowed     []ValueAllowed    `json:"valuesAllowed,omitempty"`
 }
 
+// AccountMatch is the outcome of checking an account holder name against the bank.
+type AccountMatch string
+
+const (
+	AccountMatchFull        AccountMatch = "MATCH"
+	AccountMatchClose       AccountMatch = "CLOSE_MATCH"
+	AccountMatchNone        AccountMatch = "NO_MATCH"
+	AccountMatchUnavailable AccountMatch = "UNAVAILABLE" // The bank or corridor does not support verification
+)
+
+// AccountVerification is the result of an account verification (confirmation of payee) check.
+type AccountVerification struct {
+	Result            AccountMatch `json:"result"`
+	AccountHolderName string       `json:"accountHolderName,omitempty"` // Name as submitted
+	MatchedName       string       `json:"matchedName,omitempty"`       // Name held by the bank, on a close match
+	Reason            string       `json:"reason,omitempty"`
+}
+
+// IsMatch returns true if the name fully matches the account.
+func (v *AccountVerification) IsMatch() bool {
+	return v.Result == AccountMatchFull
+}
+
 // ValueAllowed represents an allowed value for a field.
 type ValueAllowed struct {
 	Key  string `json:"key"`
@@ -89,6 +122,22 @@ func (s *RecipientsService) Create(ctx context.Context, req *CreateRecipientRequ
 	return &recipient, nil
 }
 
+// CreateEmail creates an email recipient. Money sent to an email recipient is
+// held until the recipient provides their bank details to Wise.
+// The details map has the shape {"email": "..."}.
+// POST /v1/accounts
+func (s *RecipientsService) CreateEmail(ctx context.Context, profileID int64, accountHolderName string, currency Currency, email string) (*Recipient, error) {
+	return s.Create(ctx, &CreateRecipientRequest{
+		Profile:           profileID,
+		AccountHolderName: accountHolderName,
+		Currency:          currency,
+		Type:              RecipientTypeEmail,
+		Details: map[string]interface{}{
+			"email": email,
+		},
+	})
+}
+
 // Get retrieves a recipient by ID.
 // GET /v1/accounts/{accountId}
 func (s *RecipientsService) Get(ctx context.Context, accountID int64) (*Recipient, error) {
@@ -128,6 +177,37 @@ func (s *RecipientsService) List(ctx context.Context, params *ListRecipientsPara
 	return recipients, nil
 }
 
+// Iterate returns an iterator over all recipients matching params, fetching
+// params.Limit recipients per request (DefaultPageSize if unset).
+func (s *RecipientsService) Iterate(params *ListRecipientsParams) *Iterator[Recipient] {
+	var p ListRecipientsParams
+	if params != nil {
+		p = *params
+	}
+	return NewIterator(p.Limit, p.Offset, func(ctx context.Context, limit, offset int) ([]Recipient, error) {
+		p.Limit, p.Offset = limit, offset
+		return s.List(ctx, &p)
+	})
+}
+
+// ListAll returns all recipients matching params, following pagination.
+func (s *RecipientsService) ListAll(ctx context.Context, params *ListRecipientsParams) ([]Recipient, error) {
+	return s.Iterate(params).Collect(ctx)
+}
+
+// Update updates a recipient in place, keeping its ID so that references
+// from historical transfers stay valid.
+// PUT /v1/accounts/{accountId}
+func (s *RecipientsService) Update(ctx context.Context, accountID int64, req *UpdateRecipientRequest) (*Recipient, error) {
+	var recipient Recipient
+	path := fmt.Sprintf("/v1/accounts/%d", accountID)
+	err := s.client.Put(ctx, path, req, &recipient)
+	if err != nil {
+		return nil, err
+	}
+	return &recipient, nil
+}
+
 // Delete deletes a recipient by ID.
 // DELETE /v1/accounts/{accountId}
 func (s *RecipientsService) Delete(ctx context.Context, accountID int64) error {
@@ -153,3 +233,56 @@ func (s *RecipientsService) GetRequirements(ctx context.Context, quoteID string,
 	}
 	return requirements, nil
 }
+
+// RefreshRequirements posts partially-filled recipient details and returns the
+// updated requirements. Call it whenever a field with RefreshRequirementsOnChange
+// is changed, since corridors such as INR or BRL reveal extra fields that way.
+// When quoteID is empty, requirements are resolved from req.Currency alone.
+// POST /v1/quotes/{quoteId}/account-requirements
+// POST /v1/account-requirements
+func (s *RecipientsService) RefreshRequirements(ctx context.Context, quoteID string, req *CreateRecipientRequest) ([]RecipientRequirements, error) {
+	path := "/v1/account-requirements"
+	var query url.Values
+	if quoteID != "" {
+		path = fmt.Sprintf("/v1/quotes/%s/account-requirements", quoteID)
+	} else if req != nil && req.Currency != "" {
+		query = url.Values{}
+		query.Set("targetCurrency", string(req.Currency))
+	}
+
+	var requirements []RecipientRequirements
+	err := s.client.Request(ctx, http.MethodPost, path, query, req, &requirements)
+	if err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
+// VerifyAccount checks whether the account holder name matches the account
+// details with the receiving bank, where the corridor supports it. Use it
+// before creating a recipient or sending money to catch typos.
+// POST /v1/profiles/{profileId}/account-verifications
+func (s *RecipientsService) VerifyAccount(ctx context.Context, profileID int64, req *CreateRecipientRequest) (*AccountVerification, error) {
+	var verification AccountVerification
+	path := fmt.Sprintf("/v1/profiles/%d/account-verifications", profileID)
+	err := s.client.Post(ctx, path, req, &verification)
+	if err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// VerifyRecipient runs VerifyAccount for a saved recipient.
+func (s *RecipientsService) VerifyRecipient(ctx context.Context, profileID, recipientID int64) (*AccountVerification, error) {
+	recipient, err := s.Get(ctx, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	return s.VerifyAccount(ctx, profileID, &CreateRecipientRequest{
+		Profile:           profileID,
+		AccountHolderName: recipient.AccountHolderName,
+		Currency:          recipient.Currency,
+		Type:              recipient.Type,
+		Details:           recipient.Details,
+	})
+}
@@ -0,0 +1,188 @@
+package wise
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// idempotencyHeader marks a request as safe to send more than once.
+	idempotencyHeader = "X-idempotence-uuid"
+
+	maxRetryBackoff = 30 * time.Second
+)
+
+// RetryPolicy configures how failed requests are retried; see WithRetry.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt; 0 disables retries
+	Backoff    time.Duration // Initial wait, doubled on each attempt
+	MaxBackoff time.Duration // Cap on a single wait (default 30s)
+}
+
+// DefaultRetryPolicy returns the recommended policy for interactive use:
+// 3 retries starting at 500ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, Backoff: 500 * time.Millisecond, MaxBackoff: maxRetryBackoff}
+}
+
+// retryPolicy decides whether and when a failed request is sent again.
+type retryPolicy struct {
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// WithRetry retries requests that fail with 429 Too Many Requests, a 5xx
+// status or a network error, up to maxRetries times. The wait starts at
+// backoff and doubles on each attempt (with jitter), unless the response
+// has a Retry-After header, which is used instead. Waits are capped at the
+// policy's MaxBackoff, and a request is not retried if the wait would end
+// after the context's deadline.
+//
+// GET, PUT, DELETE and other idempotent requests are retried. POST and
+// PATCH requests are only retried when they carry an X-idempotence-uuid
+// header, so money is never moved twice.
+func WithRetry(maxRetries int, backoff time.Duration) ClientOption {
+	return WithRetryPolicy(RetryPolicy{MaxRetries: maxRetries, Backoff: backoff})
+}
+
+// WithRetryPolicy is like WithRetry, configured with a RetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		if policy.MaxRetries <= 0 {
+			c.retry = nil
+			return
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = maxRetryBackoff
+		}
+		c.retry = &retryPolicy{
+			maxRetries: policy.MaxRetries,
+			backoff:    policy.Backoff,
+			maxBackoff: policy.MaxBackoff,
+		}
+	}
+}
+
+// DefaultsForBatch returns the recommended options for unattended bulk jobs
+// such as payouts: 5 retries with a longer backoff, idempotency keys so that
+// POSTs can be retried too, and a client-side rate limit of 10 requests per
+// second that also slows down when the API's budget runs low.
+func DefaultsForBatch() ClientOption {
+	return func(c *Client) {
+		WithRetryPolicy(RetryPolicy{MaxRetries: 5, Backoff: time.Second, MaxBackoff: time.Minute})(c)
+		WithIdempotencyKeys()(c)
+		WithRateLimit(10, 10)(c)
+		WithAdaptiveRateLimit()(c)
+	}
+}
+
+// WithIdempotencyKeys attaches a generated X-idempotence-uuid header to every
+// POST and PATCH request that does not already have one, so that they can be
+// retried by WithRetry. The same key is sent on every attempt of a call.
+// Transfers.Create also fills in an empty CustomerTransactionID.
+func WithIdempotencyKeys() ClientOption {
+	return func(c *Client) {
+		c.idempotencyKeys = true
+	}
+}
+
+// withIdempotencyKey returns header with a new idempotency key added if the
+// client generates keys and the request needs one.
+func (c *Client) withIdempotencyKey(method string, header http.Header) http.Header {
+	if !c.idempotencyKeys || (method != http.MethodPost && method != http.MethodPatch) {
+		return header
+	}
+	if header.Get(idempotencyHeader) != "" {
+		return header
+	}
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(idempotencyHeader, uuid.NewString())
+	return header
+}
+
+// shouldRetry reports whether the request should be sent again after the
+// given attempt (starting at 0), and how long to wait first.
+// A nil policy never retries.
+func (p *retryPolicy) shouldRetry(ctx context.Context, method string, header http.Header, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if p == nil || attempt >= p.maxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+	if !isIdempotent(method, header) {
+		return 0, false
+	}
+
+	if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return 0, false
+	}
+	wait := p.wait(attempt)
+	if err == nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = min(retryAfter, p.maxBackoff)
+		}
+	}
+	// Return the failure now rather than sleeping past the deadline.
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// wait returns the exponential backoff for an attempt, with up to 50% jitter.
+func (p *retryPolicy) wait(attempt int) time.Duration {
+	d := p.backoff << attempt
+	if d <= 0 || d > p.maxBackoff {
+		d = p.maxBackoff
+	}
+	if half := int64(d / 2); half > 0 {
+		d = d/2 + time.Duration(rand.Int64N(half+1))
+	}
+	return d
+}
+
+// isIdempotent reports whether a request can safely be sent more than once.
+func isIdempotent(method string, header http.Header) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return header.Get(idempotencyHeader) != ""
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package wise
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_RetryOnServerError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	if _, err := client.Profiles.List(context.Background()); err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestClient_RetryGivesUp(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRetry(2, time.Millisecond))
+	_, err := client.Profiles.List(context.Background())
+	if apiErr, ok := err.(*APIError); !ok || !apiErr.IsRateLimited() {
+		t.Fatalf("Expected rate limit error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestClient_NoRetryForPOST(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRetry(3, time.Millisecond))
+	client.Transfers.Create(context.Background(), &CreateTransferRequest{})
+	if calls != 1 {
+		t.Errorf("Expected POST without idempotency key to be sent once, got %d calls", calls)
+	}
+
+	calls = 0
+	client.Balances.Open(context.Background(), 1, &OpenBalanceRequest{Currency: EUR})
+	if calls != 4 {
+		t.Errorf("Expected POST with idempotency key to be retried, got %d calls", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter("120"); !ok || d != 2*time.Minute {
+		t.Errorf("Expected 2m, got %v %v", d, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := parseRetryAfter(date); !ok || d <= 0 || d > time.Minute {
+		t.Errorf("Expected up to 1m, got %v %v", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon"); ok {
+		t.Error("Expected invalid Retry-After to be ignored")
+	}
+}
+
+func TestClient_IdempotencyKeys(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(idempotencyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRetry(1, time.Millisecond), WithIdempotencyKeys())
+	req := &CreateTransferRequest{TargetAccount: 1, QuoteUUID: "quote"}
+	if _, err := client.Transfers.Create(context.Background(), req); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if req.CustomerTransactionID == "" {
+		t.Error("Expected CustomerTransactionID to be filled in")
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected the same idempotency key on both attempts, got %q", keys)
+	}
+}
+
+func TestRetryPolicy_MaxBackoff(t *testing.T) {
+	client := NewClient("test-token", WithRetryPolicy(RetryPolicy{MaxRetries: 3, Backoff: time.Second, MaxBackoff: 2 * time.Second}))
+	for attempt := range 3 {
+		if wait := client.retry.wait(attempt); wait > 2*time.Second {
+			t.Errorf("Attempt %d waits %v, over the 2s cap", attempt, wait)
+		}
+	}
+
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"3600"}}}
+	if wait, ok := client.retry.shouldRetry(context.Background(), http.MethodGet, nil, resp, nil, 0); !ok || wait != 2*time.Second {
+		t.Errorf("Expected Retry-After to be capped at 2s, got %v, %v", wait, ok)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, ok := client.retry.shouldRetry(ctx, http.MethodGet, nil, resp, nil, 0); ok {
+		t.Error("Expected no retry when the wait ends after the deadline")
+	}
+
+	client = NewClient("test-token", WithRetryPolicy(RetryPolicy{}))
+	if client.retry != nil {
+		t.Error("Expected a zero policy to disable retries")
+	}
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithRateLimit(20, 1))
+	start := time.Now()
+	for range 3 {
+		if _, err := client.Profiles.List(context.Background()); err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+	}
+	// The burst covers the first request; the next two wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected requests to be throttled, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Profiles.List(ctx); err == nil {
+		t.Error("Expected a cancelled context to fail while waiting for a slot")
+	}
+}
+
+func TestClient_RateLimitStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", "1")
+		w.Header().Set("X-RateLimit-Reset", "1")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithAdaptiveRateLimit())
+	if client.RateLimitStatus().Known() {
+		t.Fatal("Expected no rate limit status before the first response")
+	}
+	client.Profiles.List(context.Background())
+
+	status := client.RateLimitStatus()
+	if status.Limit != 100 || status.Remaining != 1 || !status.Low() {
+		t.Fatalf("Unexpected status: %+v", status)
+	}
+
+	// With 1 request left and ~1s to the reset, the next request waits ~500ms.
+	start := time.Now()
+	client.Profiles.List(context.Background())
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("Expected the request to be slowed down, took %v", elapsed)
+	}
+}
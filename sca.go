@@ -0,0 +1,66 @@
+package wise
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// WithSCAPrivateKey enables Strong Customer Authentication signing.
+// When an endpoint responds with 403 and an x-2fa-approval one-time token,
+// the client signs the token with this key and retries the request with
+// the X-Signature header. The matching public key must be uploaded to Wise.
+func WithSCAPrivateKey(key *rsa.PrivateKey) ClientOption {
+	return func(c *Client) {
+		c.scaKey = key
+	}
+}
+
+// ParseSCAPrivateKey parses a PEM-encoded RSA private key (PKCS#1 or PKCS#8)
+// for use with WithSCAPrivateKey.
+func ParseSCAPrivateKey(pemData []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, errors.New("wise: no PEM block found in private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("wise: private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// LoadSCAPrivateKey reads and parses a PEM-encoded RSA private key file.
+func LoadSCAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading private key: %w", err)
+	}
+	return ParseSCAPrivateKey(data)
+}
+
+// signSCAToken signs a one-time token with SHA256withRSA and returns it base64-encoded.
+func signSCAToken(key *rsa.PrivateKey, token string) (string, error) {
+	hash := sha256.Sum256([]byte(token))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("signing SCA token: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,70 @@
+package wise
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_SCASigning(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	const ott = "one-time-token-123"
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		signature := r.Header.Get("X-Signature")
+		if signature == "" {
+			w.Header().Set("x-2fa-approval", ott)
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if r.Header.Get("x-2fa-approval") != ott {
+			t.Errorf("Wrong x-2fa-approval header: %s", r.Header.Get("x-2fa-approval"))
+		}
+		sig, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			t.Fatalf("decoding signature: %v", err)
+		}
+		hash := sha256.Sum256([]byte(ott))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], sig); err != nil {
+			t.Errorf("Invalid signature: %v", err)
+		}
+		w.Write([]byte(`{"transactions": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithSCAPrivateKey(key))
+	_, err = client.Balances.GetStatement(context.Background(), 1, 2, EUR, "2024-01-01T00:00:00Z", "2024-01-31T00:00:00Z")
+	if err != nil {
+		t.Fatalf("GetStatement failed: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+}
+
+func TestClient_SCAWithoutKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-2fa-approval", "token")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL))
+	_, err := client.Balances.GetStatement(context.Background(), 1, 2, EUR, "", "")
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsForbidden() {
+		t.Fatalf("Expected forbidden APIError, got %v", err)
+	}
+}
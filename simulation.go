@@ -0,0 +1,83 @@
+package wise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrSandboxOnly is returned when a sandbox-only endpoint is called against production.
+var ErrSandboxOnly = errors.New("wise: simulation endpoints are only available in sandbox")
+
+// SimulationService handles sandbox simulation API calls.
+// It can only be used with a client created using WithSandbox.
+type SimulationService struct {
+	client *Client
+}
+
+// SimulateTopUpRequest represents the request to simulate a balance top-up.
+type SimulateTopUpRequest struct {
+	ProfileID int64    `json:"profileId"`
+	BalanceID int64    `json:"balanceId"`
+	Currency  Currency `json:"currency"`
+	Amount    float64  `json:"amount"`
+}
+
+// TransferState moves a sandbox transfer to the given status.
+// Valid statuses are processing, funds_converted, outgoing_payment_sent,
+// bounced_back and funds_refunded, and they must be applied in order.
+// GET /v1/simulation/transfers/{transferId}/{status}
+func (s *SimulationService) TransferState(ctx context.Context, transferID int64, status TransferStatus) (*Transfer, error) {
+	path := fmt.Sprintf("/v1/simulation/transfers/%d/%s", transferID, status)
+	if err := s.sandboxOnly(ctx, path); err != nil {
+		return nil, err
+	}
+
+	var transfer Transfer
+	err := s.client.Get(ctx, path, nil, &transfer)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// CompleteTransfer advances a funded sandbox transfer through processing,
+// funds_converted and outgoing_payment_sent.
+func (s *SimulationService) CompleteTransfer(ctx context.Context, transferID int64) (*Transfer, error) {
+	var transfer *Transfer
+	for _, status := range []TransferStatus{
+		TransferStatusProcessing,
+		TransferStatusFundsConverted,
+		TransferStatusOutgoingPaymentSent,
+	} {
+		var err error
+		transfer, err = s.TransferState(ctx, transferID, status)
+		if err != nil {
+			return nil, fmt.Errorf("simulating %s: %w", status, err)
+		}
+	}
+	return transfer, nil
+}
+
+// TopUpBalance simulates an incoming payment to a sandbox balance.
+// POST /v1/simulation/balance/topup
+func (s *SimulationService) TopUpBalance(ctx context.Context, req *SimulateTopUpRequest) error {
+	const path = "/v1/simulation/balance/topup"
+	if err := s.sandboxOnly(ctx, path); err != nil {
+		return err
+	}
+	return s.client.Post(ctx, path, req, nil)
+}
+
+// sandboxOnly returns ErrSandboxOnly if a request for path would go to the
+// production API, taking WithPathBaseURL and WithCallBaseURL into account.
+func (s *SimulationService) sandboxOnly(ctx context.Context, path string) error {
+	production, _ := url.Parse(ProductionBaseURL)
+	u, err := url.Parse(s.client.baseURLFor(ctx, path))
+	if err != nil || strings.EqualFold(u.Hostname(), production.Hostname()) {
+		return ErrSandboxOnly
+	}
+	return nil
+}
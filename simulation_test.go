@@ -0,0 +1,34 @@
+package wise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSimulation_SandboxOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		client *Client
+		ctx    context.Context
+	}{
+		"production":    {NewClient("t"), ctx},
+		"path override": {NewClient("t", WithSandbox(), WithPathBaseURL("/v1/simulation", ProductionBaseURL)), ctx},
+		"call override": {NewClient("t", WithBaseURL(server.URL)), WithCallBaseURL(ctx, ProductionBaseURL+"/")},
+	}
+	for name, tt := range tests {
+		if _, err := tt.client.Simulation.TransferState(tt.ctx, 1, TransferStatusProcessing); !errors.Is(err, ErrSandboxOnly) {
+			t.Errorf("%s: TransferState returned %v, want ErrSandboxOnly", name, err)
+		}
+		if err := tt.client.Simulation.TopUpBalance(tt.ctx, &SimulateTopUpRequest{}); !errors.Is(err, ErrSandboxOnly) {
+			t.Errorf("%s: TopUpBalance returned %v, want ErrSandboxOnly", name, err)
+		}
+	}
+}
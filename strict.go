@@ -0,0 +1,73 @@
+package wise
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// UnknownFieldError reports a response field that the result type does not
+// model, found by WithStrictDecoding.
+type UnknownFieldError struct {
+	Endpoint string // e.g. "GET /v1/profiles/{id}"
+	Field    string
+}
+
+// Error implements the error interface.
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("wise: unknown field %q in response to %s", e.Field, e.Endpoint)
+}
+
+// strictDecoding holds the WithStrictDecoding settings.
+type strictDecoding struct {
+	report func(*UnknownFieldError)
+}
+
+// WithStrictDecoding checks responses for fields that the SDK's types do not
+// model, to detect API changes early rather than silently dropping data.
+// If report is nil, such responses fail with an *UnknownFieldError.
+// Otherwise report is called and the response is decoded as usual; only the
+// first unknown field of each response is reported.
+func WithStrictDecoding(report func(*UnknownFieldError)) ClientOption {
+	return func(c *Client) {
+		c.strict = &strictDecoding{report: report}
+	}
+}
+
+// decode decodes a JSON response body into result, if both are set.
+func (c *Client) decode(method, rawURL string, respBody []byte, result interface{}) error {
+	if c.strict == nil || result == nil || len(respBody) == 0 {
+		return decodeResponse(respBody, result)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(respBody))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(result)
+	field, unknown := unknownField(err)
+	if !unknown {
+		if err != nil {
+			return fmt.Errorf("unmarshaling response: %w", err)
+		}
+		return nil
+	}
+
+	unknownErr := &UnknownFieldError{Endpoint: method + " " + endpointName(rawURL), Field: field}
+	if c.strict.report == nil {
+		return unknownErr
+	}
+	c.strict.report(unknownErr)
+	return decodeResponse(respBody, result)
+}
+
+// unknownField extracts the field name from encoding/json's unknown field error.
+func unknownField(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	field, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	return strings.Trim(field, `"`), true
+}
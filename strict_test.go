@@ -0,0 +1,35 @@
+package wise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 7, "type": "PERSONAL", "newField": true}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", WithBaseURL(server.URL), WithStrictDecoding(nil))
+	_, err := client.Profiles.Get(context.Background(), 7)
+	var unknown *UnknownFieldError
+	if !errors.As(err, &unknown) || unknown.Field != "newField" || unknown.Endpoint != "GET /v1/profiles/{id}" {
+		t.Fatalf("Expected an unknown field error, got %v", err)
+	}
+
+	var reported []string
+	client = NewClient("test-token", WithBaseURL(server.URL), WithStrictDecoding(func(e *UnknownFieldError) {
+		reported = append(reported, e.Field)
+	}))
+	profile, err := client.Profiles.Get(context.Background(), 7)
+	if err != nil || profile.ID != 7 {
+		t.Fatalf("Expected the response to decode, got %v, %v", profile, err)
+	}
+	if len(reported) != 1 || reported[0] != "newField" {
+		t.Errorf("Expected newField to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,251 @@
+package wise
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ErrNoStoredToken is returned by TokenStore.Load when no token has been saved.
+var ErrNoStoredToken = errors.New("wise: no stored token")
+
+// TokenStore persists OAuth tokens, so that a TokenManager can pick up where
+// it left off after a restart.
+type TokenStore interface {
+	// Load returns the saved token, or ErrNoStoredToken if there is none.
+	Load(ctx context.Context) (*Token, error)
+	Save(ctx context.Context, token *Token) error
+	// Delete removes the saved token. Deleting a missing token is not an error.
+	Delete(ctx context.Context) error
+}
+
+// DefaultTokenFile returns the default token file path,
+// e.g. ~/.config/plat-wise/token.json on Linux.
+func DefaultTokenFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plat-wise", "token.json"), nil
+}
+
+// FileTokenStore stores a token as JSON in a file readable only by the
+// current user, optionally encrypted with AES-GCM.
+type FileTokenStore struct {
+	path       string
+	key        []byte
+	passphrase []byte
+}
+
+// NewFileTokenStore creates a file token store. If key is set it must be
+// 16, 24 or 32 bytes long, and the token is encrypted with it.
+func NewFileTokenStore(path string, key []byte) *FileTokenStore {
+	return &FileTokenStore{path: path, key: key}
+}
+
+// NewPassphraseTokenStore creates a file token store encrypted with a key
+// derived from passphrase with scrypt. Each save uses a new random salt,
+// stored in the file header, so the passphrase cannot be attacked with
+// precomputed tables and each guess is slow.
+func NewPassphraseTokenStore(path, passphrase string) *FileTokenStore {
+	return &FileTokenStore{path: path, passphrase: []byte(passphrase)}
+}
+
+// Passphrase-encrypted token files start with passphraseMagic and a salt
+// of passphraseSaltSize bytes, followed by the nonce and ciphertext.
+const (
+	passphraseMagic    = "WTK1"
+	passphraseSaltSize = 16
+)
+
+// passphraseKey derives the AES-256 key of a passphrase-encrypted file.
+// The scrypt parameters are the ones recommended for interactive logins.
+func passphraseKey(passphrase, salt []byte) ([]byte, error) {
+	return scrypt.Key(passphrase, salt, 1<<15, 8, 1, 32)
+}
+
+// Load implements TokenStore.
+func (s *FileTokenStore) Load(ctx context.Context) (*Token, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoStoredToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading token file: %w", err)
+	}
+
+	if s.key != nil || s.passphrase != nil {
+		if data, err = s.decrypt(data); err != nil {
+			return nil, fmt.Errorf("decrypting token file: %w", err)
+		}
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("parsing token file: %w", err)
+	}
+	return &token, nil
+}
+
+// Save implements TokenStore. The file is replaced atomically.
+func (s *FileTokenStore) Save(ctx context.Context, token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("marshaling token: %w", err)
+	}
+	if s.key != nil || s.passphrase != nil {
+		if data, err = s.encrypt(data); err != nil {
+			return fmt.Errorf("encrypting token: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("creating token directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".token-*")
+	if err != nil {
+		return fmt.Errorf("creating token file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	// CreateTemp uses mode 0600.
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing token file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing token file: %w", err)
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// Delete implements TokenStore.
+func (s *FileTokenStore) Delete(ctx context.Context) error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing token file: %w", err)
+	}
+	return nil
+}
+
+func (s *FileTokenStore) encrypt(plaintext []byte) ([]byte, error) {
+	key, header := s.key, []byte(nil)
+	if s.passphrase != nil {
+		salt := make([]byte, passphraseSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		var err error
+		if key, err = passphraseKey(s.passphrase, salt); err != nil {
+			return nil, err
+		}
+		header = append([]byte(passphraseMagic), salt...)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(append(header, nonce...), nonce, plaintext, nil), nil
+}
+
+func (s *FileTokenStore) decrypt(data []byte) ([]byte, error) {
+	key := s.key
+	if s.passphrase != nil {
+		header := len(passphraseMagic) + passphraseSaltSize
+		if len(data) >= header && string(data[:len(passphraseMagic)]) == passphraseMagic {
+			var err error
+			if key, err = passphraseKey(s.passphrase, data[len(passphraseMagic):header]); err != nil {
+				return nil, err
+			}
+			data = data[header:]
+		} else {
+			// Files saved before key derivation used the passphrase's
+			// SHA-256 as the key. They are rewritten on the next save.
+			sum := sha256.Sum256(s.passphrase)
+			key = sum[:]
+		}
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyringTokenStore stores a token in the OS keychain: Keychain on macOS,
+// the Secret Service on Linux and the Credential Manager on Windows.
+type KeyringTokenStore struct {
+	service string
+	user    string
+}
+
+// NewKeyringTokenStore creates a keyring token store. The service and user
+// identify the keychain entry, e.g. "plat-wise" and the Wise user's email.
+func NewKeyringTokenStore(service, user string) *KeyringTokenStore {
+	return &KeyringTokenStore{service: service, user: user}
+}
+
+// Load implements TokenStore.
+func (s *KeyringTokenStore) Load(ctx context.Context) (*Token, error) {
+	secret, err := keyring.Get(s.service, s.user)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, ErrNoStoredToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring: %w", err)
+	}
+
+	var token Token
+	if err := json.Unmarshal([]byte(secret), &token); err != nil {
+		return nil, fmt.Errorf("parsing keyring token: %w", err)
+	}
+	return &token, nil
+}
+
+// Save implements TokenStore.
+func (s *KeyringTokenStore) Save(ctx context.Context, token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("marshaling token: %w", err)
+	}
+	if err := keyring.Set(s.service, s.user, string(data)); err != nil {
+		return fmt.Errorf("writing keyring: %w", err)
+	}
+	return nil
+}
+
+// Delete implements TokenStore.
+func (s *KeyringTokenStore) Delete(ctx context.Context) error {
+	if err := keyring.Delete(s.service, s.user); err != nil && !errors.Is(err, keyring.ErrNotFound) {
+		return fmt.Errorf("deleting keyring entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package wise
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	ctx := context.Background()
+	for _, key := range [][]byte{nil, bytes.Repeat([]byte("k"), 32)} {
+		path := filepath.Join(t.TempDir(), "wise", "token.json")
+		store := NewFileTokenStore(path, key)
+
+		if _, err := store.Load(ctx); !errors.Is(err, ErrNoStoredToken) {
+			t.Fatalf("Expected ErrNoStoredToken, got %v", err)
+		}
+
+		expires := time.Now().Add(time.Hour).Truncate(time.Second)
+		token := &Token{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: expires}
+		if err := store.Save(ctx, token); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || info.Mode().Perm() != 0o600 {
+			t.Errorf("Expected a 0600 token file, got %v, %v", info.Mode(), err)
+		}
+		data, _ := os.ReadFile(path)
+		if encrypted := !bytes.Contains(data, []byte("access")); encrypted != (key != nil) {
+			t.Errorf("Token file encrypted = %v, want %v", encrypted, key != nil)
+		}
+
+		loaded, err := store.Load(ctx)
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		if loaded.AccessToken != "access" || !loaded.ExpiresAt.Equal(expires) {
+			t.Errorf("Unexpected token: %+v", loaded)
+		}
+	}
+}
+
+func TestPassphraseTokenStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "token.json")
+	store := NewPassphraseTokenStore(path, "correct horse")
+
+	var headers [][]byte
+	for range 2 {
+		if err := store.Save(ctx, &Token{AccessToken: "access"}); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+		data, _ := os.ReadFile(path)
+		if bytes.Contains(data, []byte("access")) || !bytes.HasPrefix(data, []byte(passphraseMagic)) {
+			t.Fatalf("Expected an encrypted file with a salted header, got %q", data)
+		}
+		headers = append(headers, data[:len(passphraseMagic)+passphraseSaltSize])
+	}
+	if bytes.Equal(headers[0], headers[1]) {
+		t.Error("Expected a new salt on each save")
+	}
+
+	if loaded, err := store.Load(ctx); err != nil || loaded.AccessToken != "access" {
+		t.Errorf("Load = %+v, %v", loaded, err)
+	}
+	if _, err := NewPassphraseTokenStore(path, "wrong").Load(ctx); err == nil {
+		t.Error("Expected a wrong passphrase to fail")
+	}
+
+	// Files from before key derivation still load.
+	sum := sha256.Sum256([]byte("correct horse"))
+	if err := NewFileTokenStore(path, sum[:]).Save(ctx, &Token{AccessToken: "legacy"}); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := store.Load(ctx); err != nil || loaded.AccessToken != "legacy" {
+		t.Errorf("Load of a legacy file = %+v, %v", loaded, err)
+	}
+}
+
+func TestTokenManager_SavesRefreshedToken(t *testing.T) {
+	var refreshes atomic.Int32
+	server := newRefreshServer(t, &refreshes, http.StatusOK)
+	oauth := NewOAuthClient(OAuthConfig{ClientID: "id"})
+	oauth.endpoint = server.URL
+
+	ctx := context.Background()
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "token.json"), nil)
+	if err := store.Save(ctx, &Token{AccessToken: "old", RefreshToken: "r1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewTokenManagerFromStore(ctx, oauth, store)
+	if err != nil {
+		t.Fatalf("NewTokenManagerFromStore failed: %v", err)
+	}
+	if _, err := m.GetToken(ctx); err != nil {
+		t.Fatalf("GetToken failed: %v", err)
+	}
+
+	saved, err := store.Load(ctx)
+	if err != nil || saved.AccessToken != "refreshed" {
+		t.Errorf("Expected the refreshed token to be saved, got %+v, %v", saved, err)
+	}
+}
+
+func TestTokenManager_Revoke(t *testing.T) {
+	var revoked string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/revoke" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		r.ParseForm()
+		revoked = r.PostForm.Get("token")
+	}))
+	defer server.Close()
+	oauth := NewOAuthClient(OAuthConfig{ClientID: "id"})
+	oauth.endpoint = server.URL
+
+	ctx := context.Background()
+	store := NewFileTokenStore(filepath.Join(t.TempDir(), "token.json"), nil)
+	store.Save(ctx, &Token{AccessToken: "access", RefreshToken: "refresh"})
+	m, err := NewTokenManagerFromStore(ctx, oauth, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Revoke(ctx); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if revoked != "refresh" {
+		t.Errorf("Expected the refresh token to be revoked, got %q", revoked)
+	}
+	if _, err := store.Load(ctx); !errors.Is(err, ErrNoStoredToken) {
+		t.Errorf("Expected the saved token to be deleted, got %v", err)
+	}
+	if _, err := m.GetToken(ctx); err == nil {
+		t.Error("Expected no token after Revoke")
+	}
+}
@@ -2,9 +2,22 @@ package wise
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned by TransfersService.Cancel. Both wrap the underlying *APIError.
+var (
+	// ErrTransferAlreadyPaidOut means the money has already been sent to the recipient.
+	ErrTransferAlreadyPaidOut = errors.New("wise: transfer already paid out")
+	// ErrTransferNotCancellable means the transfer is in a state that cannot be cancelled.
+	ErrTransferNotCancellable = errors.New("wise: transfer cannot be cancelled")
 )
 
 // TransfersService handles transfer-related API calls.
@@ -33,6 +46,9 @@ type Transfer struct {
 	TargetCurrency        Currency        `json:"targetCurrency"`
 	TargetValue           float64         `json:"targetValue"`
 	CustomerTransactionID string          `json:"customerTransactionId,omitempty"`
+
+	// Swift is set on transfers paid out by SWIFT wire and is nil otherwise.
+	Swift *SwiftDetails `json:"swiftDetails,omitempty"`
 }
 
 // TransferDetails represents additional details of a transfer.
@@ -42,6 +58,82 @@ type TransferDetails struct {
 	SourceOfFunds   string `json:"sourceOfFunds,omitempty"`
 }
 
+// SwiftCharges controls who pays correspondent bank charges on a SWIFT wire.
+// It is chosen through the quote's pay-out method (SWIFT or SWIFT_OUR).
+type SwiftCharges string
+
+const (
+	// SwiftChargesOur means the sender pays all charges.
+	SwiftChargesOur SwiftCharges = "OUR"
+	// SwiftChargesShared means charges are shared; intermediary banks may
+	// deduct fees from the amount received.
+	SwiftChargesShared SwiftCharges = "SHA"
+)
+
+// SwiftDetails holds the SWIFT charge option and correspondent bank details
+// of a cross-border wire, as returned on the transfer.
+type SwiftDetails struct {
+	UETR              string              `json:"uetr,omitempty"`
+	Charges           SwiftCharges        `json:"chargeBearer,omitempty"`
+	SenderBIC         string              `json:"senderBic,omitempty"`
+	BeneficiaryBIC    string              `json:"beneficiaryBic,omitempty"`
+	CorrespondentBank *CorrespondentBank  `json:"correspondentBank,omitempty"`
+	IntermediaryBanks []CorrespondentBank `json:"intermediaryBanks,omitempty"`
+}
+
+// CorrespondentBank is a bank that routes a SWIFT payment on behalf of another.
+type CorrespondentBank struct {
+	Name          string `json:"name,omitempty"`
+	BIC           string `json:"bic"`
+	Country       string `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	AccountNumber string `json:"accountNumber,omitempty"`
+}
+
+// PayInDetails holds the bank account and reference to use when funding a
+// transfer by external bank transfer instead of from a balance.
+type PayInDetails struct {
+	PayInBank         PayInBank        `json:"payinBank"`
+	PayInBankAccount  PayInBankAccount `json:"payinBankAccount"`
+	WiseInformation   WiseInformation  `json:"wiseInformation,omitempty"`
+	BankFeeDisclaimer string           `json:"bankFeeDisclaimer,omitempty"`
+}
+
+// PayInBank is the bank holding the account to pay into.
+type PayInBank struct {
+	BankName string `json:"bankName"`
+	Country  string `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Address  string `json:"address,omitempty"`
+}
+
+// PayInBankAccount is the account to pay into, as labelled details.
+type PayInBankAccount struct {
+	Currency Currency      `json:"currency"`
+	Details  []PayInDetail `json:"details"`
+}
+
+// PayInDetail is a single labelled detail, e.g. account number or payment reference.
+type PayInDetail struct {
+	Type  string `json:"type"` // e.g. recipientName, accountNumber, sortCode, iban, swiftCode, reference
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// WiseInformation identifies the Wise entity receiving the money.
+type WiseInformation struct {
+	LocalCompanyName string `json:"localCompanyName,omitempty"`
+	LocalAddress     string `json:"localAddress,omitempty"`
+}
+
+// Detail returns the value of the detail with the given type, or an empty string.
+func (d *PayInDetails) Detail(detailType string) string {
+	for _, v := range d.PayInBankAccount.Details {
+		if v.Type == detailType {
+			return v.Value
+		}
+	}
+	return ""
+}
+
 // CreateTransferRequest represents the request to create a transfer.
 type CreateTransferRequest struct {
 	TargetAccount         int64           `json:"targetAccount"`
@@ -62,6 +154,38 @@ type TransferIssue struct {
 	Message string `json:"message,omitempty"`
 }
 
+// TransferDocument represents a document uploaded to resolve a transfer issue.
+type TransferDocument struct {
+	ID           string    `json:"id"`
+	FileName     string    `json:"fileName"`
+	DocumentType string    `json:"documentType,omitempty"`
+	CreatedAt    Timestamp `json:"createdAt,omitempty"`
+}
+
+// TransferTracking represents the payout tracking status of a transfer.
+// SWIFT transfers carry gpi details (UETR and the tracking events reported
+// by the banks along the route).
+type TransferTracking struct {
+	TransferID    int64                   `json:"transferId"`
+	Status        string                  `json:"status"`
+	PayoutMethod  string                  `json:"payoutMethod,omitempty"` // e.g. SWIFT, LOCAL
+	UETR          string                  `json:"uetr,omitempty"`
+	BankReference string                  `json:"bankReference,omitempty"`
+	GPIStatus     string                  `json:"gpiStatus,omitempty"` // e.g. ACSP, ACCC, RJCT
+	CompletedAt   Timestamp               `json:"completedAt,omitempty"`
+	Events        []TransferTrackingEvent `json:"events,omitempty"`
+}
+
+// TransferTrackingEvent is a single step reported while paying out a transfer.
+type TransferTrackingEvent struct {
+	Status      string    `json:"status"`
+	Description string    `json:"description,omitempty"`
+	BankName    string    `json:"bankName,omitempty"`
+	BankBIC     string    `json:"bankBic,omitempty"`
+	Reference   string    `json:"reference,omitempty"`
+	OccurredAt  Timestamp `json:"occurredAt"`
+}
+
 // ListTransfersParams represents the parameters for listing transfers.
 type ListTransfersParams struct {
 	ProfileID int64
@@ -73,8 +197,14 @@ type ListTransfersParams struct {
 }
 
 // Create creates a new transfer.
+// With WithIdempotencyKeys, an empty CustomerTransactionID is filled in on
+// req with a new UUID; reuse req to retry the same transfer safely.
 // POST /v1/transfers
 func (s *TransfersService) Create(ctx context.Context, req *CreateTransferRequest) (*Transfer, error) {
+	if s.client.idempotencyKeys && req.CustomerTransactionID == "" {
+		req.CustomerTransactionID = uuid.NewString()
+	}
+
 	var transfer Transfer
 	err := s.client.Post(ctx, "/v1/transfers", req, &transfer)
 	if err != nil {
@@ -83,6 +213,20 @@ func (s *TransfersService) Create(ctx context.Context, req *CreateTransferReques
 	return &transfer, nil
 }
 
+// GetRequirements returns the fields needed to create a transfer to a
+// recipient with a quote, in the same format as recipient requirements.
+// Some corridors require e.g. a transfer purpose or source of funds in
+// req.Details.
+// POST /v1/transfer-requirements
+func (s *TransfersService) GetRequirements(ctx context.Context, req *CreateTransferRequest) ([]RecipientRequirements, error) {
+	var requirements []RecipientRequirements
+	err := s.client.Post(ctx, "/v1/transfer-requirements", req, &requirements)
+	if err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
+
 // Get retrieves a transfer by ID.
 // GET /v1/transfers/{transferId}
 func (s *TransfersService) Get(ctx context.Context, transferID int64) (*Transfer, error) {
@@ -128,18 +272,84 @@ func (s *TransfersService) List(ctx context.Context, params *ListTransfersParams
 	return transfers, nil
 }
 
+// Iterate returns an iterator over all transfers matching params, fetching
+// params.Limit transfers per request (DefaultPageSize if unset).
+func (s *TransfersService) Iterate(params *ListTransfersParams) *Iterator[Transfer] {
+	var p ListTransfersParams
+	if params != nil {
+		p = *params
+	}
+	return NewIterator(p.Limit, p.Offset, func(ctx context.Context, limit, offset int) ([]Transfer, error) {
+		p.Limit, p.Offset = limit, offset
+		return s.List(ctx, &p)
+	})
+}
+
+// ListAll returns all transfers matching params, following pagination.
+func (s *TransfersService) ListAll(ctx context.Context, params *ListTransfersParams) ([]Transfer, error) {
+	return s.Iterate(params).Collect(ctx)
+}
+
 // Cancel cancels a transfer.
+// If Wise refuses the cancellation the error wraps ErrTransferAlreadyPaidOut
+// or ErrTransferNotCancellable together with the underlying *APIError.
 // PUT /v1/transfers/{transferId}/cancel
 func (s *TransfersService) Cancel(ctx context.Context, transferID int64) (*Transfer, error) {
 	var transfer Transfer
 	path := fmt.Sprintf("/v1/transfers/%d/cancel", transferID)
 	err := s.client.Put(ctx, path, nil, &transfer)
 	if err != nil {
-		return nil, err
+		return nil, cancelError(err)
 	}
 	return &transfer, nil
 }
 
+// CanCancel reports whether a transfer can still be cancelled.
+// It fetches the transfer and checks its status, see Transfer.IsCancellable.
+func (s *TransfersService) CanCancel(ctx context.Context, transferID int64) (bool, error) {
+	transfer, err := s.Get(ctx, transferID)
+	if err != nil {
+		return false, err
+	}
+	return transfer.IsCancellable(), nil
+}
+
+// IsCancellable reports whether the transfer is in a status that allows
+// cancellation. Transfers can be cancelled until the money has been sent
+// to the recipient.
+func (t *Transfer) IsCancellable() bool {
+	switch t.Status {
+	case TransferStatusIncomingPaymentWaiting,
+		TransferStatusIncomingPaymentInitiated,
+		TransferStatusProcessing,
+		TransferStatusWaitingRecipientInput:
+		return true
+	}
+	return false
+}
+
+// cancelError maps a refused cancellation to ErrTransferAlreadyPaidOut when
+// the error code says the money has been sent, or else to
+// ErrTransferNotCancellable for a conflict or unprocessable request. Other
+// errors are returned unchanged.
+func cancelError(err error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	if apiErr.StatusCode < 400 || apiErr.StatusCode >= 500 {
+		return err
+	}
+
+	switch {
+	case apiErr.hasCode(transferPaidOutCodes):
+		return fmt.Errorf("%w: %w", ErrTransferAlreadyPaidOut, apiErr)
+	case apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusUnprocessableEntity:
+		return fmt.Errorf("%w: %w", ErrTransferNotCancellable, apiErr)
+	}
+	return err
+}
+
 // Fund funds a transfer from a balance.
 // POST /v3/profiles/{profileId}/transfers/{transferId}/payments
 func (s *TransfersService) Fund(ctx context.Context, profileID, transferID int64) (*Transfer, error) {
@@ -153,6 +363,20 @@ func (s *TransfersService) Fund(ctx context.Context, profileID, transferID int64
 	return &transfer, nil
 }
 
+// GetPayInDetails returns the bank details and reference for funding a
+// transfer by bank transfer. The money must be sent with the reference so
+// Wise can match it to the transfer.
+// GET /v1/profiles/{profileId}/transfers/{transferId}/deposit-details/bank-transfer
+func (s *TransfersService) GetPayInDetails(ctx context.Context, profileID, transferID int64) (*PayInDetails, error) {
+	var details PayInDetails
+	path := fmt.Sprintf("/v1/profiles/%d/transfers/%d/deposit-details/bank-transfer", profileID, transferID)
+	err := s.client.Get(ctx, path, nil, &details)
+	if err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
 // GetIssues retrieves issues for a transfer.
 // GET /v1/transfers/{transferId}/issues
 func (s *TransfersService) GetIssues(ctx context.Context, transferID int64) ([]TransferIssue, error) {
@@ -178,3 +402,43 @@ func (s *TransfersService) GetDeliveryTime(ctx context.Context, transferID int64
 	}
 	return &result.EstimatedDeliveryDate, nil
 }
+
+// UploadDocument uploads a supporting document (invoice, proof of source of
+// funds, ...) for a transfer with active issues.
+// documentType is optional, e.g. INVOICE or SOURCE_OF_FUNDS.
+// POST /v3/profiles/{profileId}/transfers/{transferId}/attachments
+func (s *TransfersService) UploadDocument(ctx context.Context, profileID, transferID int64, documentType, fileName string, content io.Reader) (*TransferDocument, error) {
+	var fields map[string]string
+	if documentType != "" {
+		fields = map[string]string{"documentType": documentType}
+	}
+
+	var doc TransferDocument
+	path := fmt.Sprintf("/v3/profiles/%d/transfers/%d/attachments", profileID, transferID)
+	err := s.client.Upload(ctx, path, &UploadFile{FileName: fileName, Content: content}, fields, &doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// GetTracking retrieves payout tracking details for a transfer, including
+// SWIFT gpi status and the bank-side references.
+// GET /v1/transfers/{transferId}/tracking
+func (s *TransfersService) GetTracking(ctx context.Context, transferID int64) (*TransferTracking, error) {
+	var tracking TransferTracking
+	path := fmt.Sprintf("/v1/transfers/%d/tracking", transferID)
+	err := s.client.Get(ctx, path, nil, &tracking)
+	if err != nil {
+		return nil, err
+	}
+	return &tracking, nil
+}
+
+// DownloadReceipt downloads the PDF receipt (proof of payment) for a transfer.
+// The caller must close the returned reader.
+// GET /v1/transfers/{transferId}/receipt.pdf
+func (s *TransfersService) DownloadReceipt(ctx context.Context, transferID int64) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/v1/transfers/%d/receipt.pdf", transferID)
+	return s.client.stream(ctx, path, nil, "application/pdf")
+}
@@ -1,11 +1,18 @@
 package wise
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Currency represents a currency code (ISO 4217).
 type Currency string
 
-// Common currency codes.
+// Common currency codes. Any ISO 4217 code is accepted; see AllCurrencies
+// for the full registry.
 const (
 	USD Currency = "USD"
 	EUR Currency = "EUR"
@@ -36,53 +43,125 @@ type Money struct {
 }
 
 // Timestamp is a time.Time that marshals to/from ISO 8601 format.
+//
+// Wise is not consistent about timestamps, so UnmarshalJSON also accepts
+// epoch seconds or milliseconds and times without a timezone (taken as UTC).
+// MarshalJSON writes a decoded timestamp back in the layout and precision it
+// was read in. A value in no known format decodes to the zero time, rather
+// than failing the whole response, and marshals back unchanged.
 type Timestamp struct {
 	time.Time
+
+	layout string // layout the value was decoded from, if any
+	raw    string // original JSON of a value in no known format
+}
+
+// Epoch pseudo-layouts for Timestamp.layout.
+const (
+	epochSeconds = "epoch-seconds"
+	epochMillis  = "epoch-millis"
+)
+
+// timestampLayouts are tried in order. Fractional seconds are accepted by
+// all layouts with a seconds field.
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05-0700", // Wise format without colon in timezone
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
 func (t *Timestamp) UnmarshalJSON(data []byte) error {
-	// Remove quotes
+	*t = Timestamp{}
+
 	s := string(data)
-	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+	quoted := len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"'
+	if quoted {
 		s = s[1 : len(s)-1]
 	}
-
 	if s == "null" || s == "" {
 		return nil
 	}
 
-	// Try multiple formats
-	formats := []string{
-		time.RFC3339,
-		time.RFC3339Nano,
-		"2006-01-02T15:04:05-0700",  // Wise format without colon in timezone
-		"2006-01-02T15:04:05+0000",  // Wise UTC format
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05",
-		"2006-01-02",
+	// Epochs, sometimes sent as strings.
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		// 1e11 seconds is in the year 5138, so larger values are millis.
+		if n >= 1e11 || n <= -1e11 {
+			t.Time, t.layout = time.UnixMilli(n).UTC(), epochMillis
+		} else {
+			t.Time, t.layout = time.Unix(n, 0).UTC(), epochSeconds
+		}
+		if quoted {
+			t.layout = `"` + t.layout
+		}
+		return nil
 	}
 
-	var err error
-	for _, format := range formats {
-		t.Time, err = time.Parse(format, s)
-		if err == nil {
-			return nil
+	if quoted {
+		for _, layout := range timestampLayouts {
+			if parsed, err := time.Parse(layout, s); err == nil {
+				t.Time, t.layout = parsed, withFraction(layout, s)
+				return nil
+			}
 		}
 	}
 
-	return err
+	t.raw = string(data)
+	return nil
+}
+
+// withFraction adds the fractional seconds of value, if any, to layout, so
+// that formatting with it keeps the original precision.
+func withFraction(layout, value string) string {
+	i := strings.IndexAny(value, ".,")
+	if i < 0 || !strings.Contains(layout, "05") {
+		return layout
+	}
+	n := 0
+	for _, c := range value[i+1:] {
+		if c < '0' || c > '9' {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return layout
+	}
+	return strings.Replace(layout, "05", "05"+value[i:i+1]+strings.Repeat("0", n), 1)
 }
 
 // MarshalJSON implements json.Marshaler.
 func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.raw != "" && t.IsZero() {
+		return []byte(t.raw), nil
+	}
 	if t.IsZero() {
 		return []byte("null"), nil
 	}
-	return []byte(`"` + t.Format(time.RFC3339) + `"`), nil
+
+	switch strings.TrimPrefix(t.layout, `"`) {
+	case epochSeconds, epochMillis:
+		n := t.Unix()
+		if strings.HasSuffix(t.layout, epochMillis) {
+			n = t.UnixMilli()
+		}
+		if strings.HasPrefix(t.layout, `"`) {
+			return []byte(`"` + strconv.FormatInt(n, 10) + `"`), nil
+		}
+		return []byte(strconv.FormatInt(n, 10)), nil
+	case "":
+		return []byte(`"` + t.Format(time.RFC3339) + `"`), nil
+	}
+	return []byte(`"` + t.Format(t.layout) + `"`), nil
 }
 
 // TransferStatus represents the status of a transfer.
+//
+// Wise adds statuses from time to time. A status this package does not know
+// decodes as is; use IsKnown to detect it. An empty status decodes as
+// TransferStatusUnknown.
 type TransferStatus string
 
 const (
@@ -94,8 +173,70 @@ const (
 	TransferStatusCancelled               TransferStatus = "cancelled"
 	TransferStatusFundsRefunded           TransferStatus = "funds_refunded"
 	TransferStatusBounced                 TransferStatus = "bounced_back"
+	// TransferStatusChargedBack means the payer reversed a card or direct
+	// debit payment after the transfer was sent.
+	TransferStatusChargedBack TransferStatus = "charged_back"
+	// TransferStatusWaitingRecipientInput means Wise needs the recipient to
+	// provide details before it can pay out.
+	TransferStatusWaitingRecipientInput TransferStatus = "waiting_recipient_input_to_proceed"
+	// TransferStatusUnknown is used by Wise, and by this package for an
+	// empty status, when the status cannot be determined.
+	TransferStatusUnknown TransferStatus = "unknown"
 )
 
+// transferStatuses lists the statuses known to this package.
+var transferStatuses = map[TransferStatus]bool{
+	TransferStatusIncomingPaymentWaiting:   true,
+	TransferStatusIncomingPaymentInitiated: true,
+	TransferStatusProcessing:               true,
+	TransferStatusFundsConverted:           true,
+	TransferStatusOutgoingPaymentSent:      true,
+	TransferStatusCancelled:                true,
+	TransferStatusFundsRefunded:            true,
+	TransferStatusBounced:                  true,
+	TransferStatusChargedBack:              true,
+	TransferStatusWaitingRecipientInput:    true,
+	TransferStatusUnknown:                  true,
+}
+
+// IsKnown reports whether s is one of the TransferStatus constants.
+func (s TransferStatus) IsKnown() bool {
+	return transferStatuses[s]
+}
+
+// IsTerminal reports whether a transfer in status s is finished: paid out,
+// cancelled, refunded, bounced or charged back. Unknown statuses are not
+// terminal, so polling loops keep going until Wise reports a final one.
+func (s TransferStatus) IsTerminal() bool {
+	switch s {
+	case TransferStatusOutgoingPaymentSent,
+		TransferStatusCancelled,
+		TransferStatusFundsRefunded,
+		TransferStatusBounced,
+		TransferStatusChargedBack:
+		return true
+	}
+	return false
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Statuses are matched case
+// insensitively, and an empty string becomes TransferStatusUnknown.
+func (s *TransferStatus) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("wise: invalid transfer status %s: %w", data, err)
+	}
+	v = strings.ToLower(strings.TrimSpace(v))
+	if v == "" {
+		v = string(TransferStatusUnknown)
+	}
+	*s = TransferStatus(v)
+	return nil
+}
+
 // ProfileType represents the type of profile (personal or business).
 type ProfileType string
 
@@ -110,4 +251,7 @@ type RecipientType string
 const (
 	RecipientTypePerson  RecipientType = "person"
 	RecipientTypeBusiness RecipientType = "business"
+	// RecipientTypeEmail sends money to an email address without bank details.
+	// The recipient is asked by Wise to provide their bank details.
+	RecipientTypeEmail RecipientType = "email"
 )
@@ -0,0 +1,198 @@
+package wise
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2024, 3, 1, 12, 30, 45, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`"2024-03-01T12:30:45Z"`, want},
+		{`"2024-03-01T13:30:45+01:00"`, want},
+		{`"2024-03-01T12:30:45+0000"`, want},
+		{`"2024-03-01T12:30:45"`, want},
+		{`"2024-03-01 12:30:45"`, want},
+		{`"2024-03-01T12:30:45.123Z"`, want.Add(123 * time.Millisecond)},
+		{`1709296245`, want},
+		{`1709296245123`, want.Add(123 * time.Millisecond)},
+		{`"1709296245123"`, want.Add(123 * time.Millisecond)},
+		{`"2024-03-01"`, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{`null`, time.Time{}},
+		{`""`, time.Time{}},
+		{`"next tuesday"`, time.Time{}},
+	}
+	for _, tt := range tests {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(tt.in), &ts); err != nil {
+			t.Errorf("Unmarshal(%s) failed: %v", tt.in, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, ts.Time, tt.want)
+		}
+	}
+}
+
+func TestTimestamp_MarshalRoundTrip(t *testing.T) {
+	for _, in := range []string{
+		`"2024-03-01T12:30:45Z"`,
+		`"2024-03-01T12:30:45.120+01:00"`,
+		`"2024-03-01T12:30:45.123456+0000"`,
+		`"2024-03-01T12:30:45"`,
+		`"2024-03-01"`,
+		`1709296245`,
+		`1709296245123`,
+		`"next tuesday"`,
+		`{"odd":true}`,
+	} {
+		var ts Timestamp
+		if err := json.Unmarshal([]byte(in), &ts); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %v", in, err)
+		}
+		out, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatalf("Marshal(%s) failed: %v", in, err)
+		}
+		if string(out) != in {
+			t.Errorf("round trip of %s gave %s", in, out)
+		}
+	}
+
+	out, _ := json.Marshal(Timestamp{Time: time.Date(2024, 3, 1, 12, 30, 45, 5, time.UTC)})
+	if string(out) != `"2024-03-01T12:30:45Z"` {
+		t.Errorf("constructed timestamp marshaled to %s", out)
+	}
+}
+
+func TestTimestamp_BadValueInStatement(t *testing.T) {
+	data := `{"transactions":[
+		{"date":"2024-03-01T12:30:45Z","referenceNumber":"a"},
+		{"date":"not a date","referenceNumber":"b"},
+		{"date":1709296245123,"referenceNumber":"c"}]}`
+	var st struct {
+		Transactions []BalanceStatement `json:"transactions"`
+	}
+	if err := json.Unmarshal([]byte(data), &st); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if len(st.Transactions) != 3 || !st.Transactions[1].Date.IsZero() || st.Transactions[2].Date.IsZero() {
+		t.Errorf("unexpected transactions: %+v", st.Transactions)
+	}
+}
+
+func FuzzTimestamp(f *testing.F) {
+	for _, seed := range []string{
+		`"2024-03-01T12:30:45Z"`, `"2024-03-01T12:30:45.123+0100"`, `"2024-03-01 12:30:45,5"`,
+		`"2024-03-01"`, `1709296245`, `-1709296245123`, `"1709296245123"`, `null`, `""`, `"x"`, `1e3`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		var ts Timestamp
+		if err := ts.UnmarshalJSON([]byte(in)); err != nil {
+			t.Fatalf("UnmarshalJSON(%q) failed: %v", in, err)
+		}
+		out, err := ts.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON failed for %q: %v", in, err)
+		}
+		var again Timestamp
+		if err := again.UnmarshalJSON(out); err != nil {
+			t.Fatalf("UnmarshalJSON(%q) failed: %v", out, err)
+		}
+		if !again.Equal(ts.Time) {
+			t.Errorf("%q: round trip via %s gave %v, want %v", in, out, again.Time, ts.Time)
+		}
+	})
+}
+
+func TestTransferStatus_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in    string
+		want  TransferStatus
+		known bool
+	}{
+		{`"processing"`, TransferStatusProcessing, true},
+		{`"CHARGED_BACK"`, TransferStatusChargedBack, true},
+		{`"waiting_recipient_input_to_proceed"`, TransferStatusWaitingRecipientInput, true},
+		{`""`, TransferStatusUnknown, true},
+		{`"teleported"`, "teleported", false},
+	}
+	for _, tt := range tests {
+		var got TransferStatus
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %v", tt.in, err)
+		}
+		if got != tt.want || got.IsKnown() != tt.known {
+			t.Errorf("Unmarshal(%s) = %q (known %v), want %q (known %v)", tt.in, got, got.IsKnown(), tt.want, tt.known)
+		}
+	}
+
+	var transfer Transfer
+	if err := json.Unmarshal([]byte(`{"status":null}`), &transfer); err != nil {
+		t.Fatalf("Unmarshal null status failed: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"status":42}`), &transfer); err == nil {
+		t.Error("expected error for numeric status")
+	}
+}
+
+func TestTransferStatus_IsTerminal(t *testing.T) {
+	for status, terminal := range map[TransferStatus]bool{
+		TransferStatusIncomingPaymentWaiting: false,
+		TransferStatusProcessing:             false,
+		TransferStatusWaitingRecipientInput:  false,
+		TransferStatusOutgoingPaymentSent:    true,
+		TransferStatusCancelled:              true,
+		TransferStatusFundsRefunded:          true,
+		TransferStatusBounced:                true,
+		TransferStatusChargedBack:            true,
+		TransferStatusUnknown:                false,
+		"teleported":                         false,
+	} {
+		if status.IsTerminal() != terminal {
+			t.Errorf("%s.IsTerminal() = %v, want %v", status, !terminal, terminal)
+		}
+	}
+}
+
+func TestActivity_OmitsZeroUpdatedOn(t *testing.T) {
+	out, err := json.Marshal(Activity{ID: "a", CreatedOn: Timestamp{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if strings.Contains(string(out), "updatedOn") {
+		t.Errorf("expected a zero updatedOn to be omitted, got %s", out)
+	}
+}
+
+func TestTransfer_UnmarshalSwiftDetails(t *testing.T) {
+	in := `{"id":1,"status":"outgoing_payment_sent","swiftDetails":{"chargeBearer":"OUR","correspondentBank":{"name":"Citibank","bic":"CITIUS33","country":"US"}}}`
+	var tr Transfer
+	if err := json.Unmarshal([]byte(in), &tr); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if tr.Swift == nil {
+		t.Fatal("expected swiftDetails to be decoded")
+	}
+	if tr.Swift.Charges != SwiftChargesOur {
+		t.Errorf("Charges = %q, want %q", tr.Swift.Charges, SwiftChargesOur)
+	}
+	if tr.Swift.CorrespondentBank == nil || tr.Swift.CorrespondentBank.BIC != "CITIUS33" {
+		t.Errorf("CorrespondentBank = %+v, want BIC CITIUS33", tr.Swift.CorrespondentBank)
+	}
+
+	var local Transfer
+	if err := json.Unmarshal([]byte(`{"id":2,"status":"processing"}`), &local); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if local.Swift != nil {
+		t.Errorf("expected no SWIFT details on a local transfer, got %+v", local.Swift)
+	}
+}
@@ -0,0 +1,63 @@
+package wise
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+)
+
+// UploadFile describes a file sent as part of a multipart upload.
+type UploadFile struct {
+	FieldName   string // Form field name, defaults to "file"
+	FileName    string
+	ContentType string // Defaults to application/octet-stream
+	Content     io.Reader
+}
+
+// Upload performs a multipart/form-data POST with one file and optional
+// form fields, decoding the JSON response into result.
+func (c *Client) Upload(ctx context.Context, path string, file *UploadFile, fields map[string]string, result interface{}) error {
+	rawURL, err := c.buildURL(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	// The body is buffered so it can be resent after an SCA challenge.
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		if err := w.WriteField(k, v); err != nil {
+			return fmt.Errorf("writing form field: %w", err)
+		}
+	}
+
+	fieldName := file.FieldName
+	if fieldName == "" {
+		fieldName = "file"
+	}
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	partHeader := textproto.MIMEHeader{}
+	partHeader.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, fieldName, file.FileName))
+	partHeader.Set("Content-Type", contentType)
+	part, err := w.CreatePart(partHeader)
+	if err != nil {
+		return fmt.Errorf("creating form file: %w", err)
+	}
+	if _, err := io.Copy(part, file.Content); err != nil {
+		return fmt.Errorf("writing form file: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("closing multipart writer: %w", err)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", w.FormDataContentType())
+	return c.exchange(ctx, http.MethodPost, rawURL, buf.Bytes(), header, result)
+}
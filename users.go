@@ -0,0 +1,41 @@
+package wise
+
+import (
+	"context"
+)
+
+// UsersService handles user-related API calls.
+type UsersService struct {
+	client *Client
+}
+
+// User represents the authenticated Wise user.
+type User struct {
+	ID      int64        `json:"id"`
+	Name    string       `json:"name"`
+	Email   string       `json:"email"`
+	Active  bool         `json:"active"`
+	Details *UserDetails `json:"details,omitempty"`
+}
+
+// UserDetails holds the personal details of a user.
+type UserDetails struct {
+	FirstName      string   `json:"firstName,omitempty"`
+	LastName       string   `json:"lastName,omitempty"`
+	PhoneNumber    string   `json:"phoneNumber,omitempty"`
+	DateOfBirth    string   `json:"dateOfBirth,omitempty"` // YYYY-MM-DD
+	Occupation     string   `json:"occupation,omitempty"`
+	Avatar         string   `json:"avatar,omitempty"`
+	PrimaryAddress *Address `json:"primaryAddress,omitempty"`
+}
+
+// Me returns the user the client is authenticated as.
+// GET /v1/me
+func (s *UsersService) Me(ctx context.Context) (*User, error) {
+	var user User
+	err := s.client.Get(ctx, "/v1/me", nil, &user)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
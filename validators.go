@@ -0,0 +1,114 @@
+package wise
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ValidatorsService handles bank detail validation API calls.
+// Validators let recipient forms check individual fields before submitting.
+type ValidatorsService struct {
+	client *Client
+}
+
+// ValidationResult is the outcome of validating a single field.
+type ValidationResult struct {
+	Valid  bool
+	Errors []ValidationError
+}
+
+// Message returns the validation error messages joined together.
+func (r *ValidationResult) Message() string {
+	msgs := make([]string, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		msgs = append(msgs, e.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate runs a named validator with a single query parameter, e.g.
+// Validate(ctx, "iban", "iban", "GB33BUKB20201555555555").
+// A failed validation is reported in the result, not as an error.
+// GET /v1/validators/{validator}
+func (s *ValidatorsService) Validate(ctx context.Context, validator, param, value string) (*ValidationResult, error) {
+	query := url.Values{}
+	query.Set(param, value)
+
+	err := s.client.Get(ctx, "/v1/validators/"+validator, query, nil)
+	if err == nil {
+		return &ValidationResult{Valid: true}, nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		result := &ValidationResult{Errors: apiErr.Errors}
+		if len(result.Errors) == 0 && apiErr.Message != "" {
+			result.Errors = []ValidationError{{Message: apiErr.Message, Path: param}}
+		}
+		return result, nil
+	}
+	return nil, err
+}
+
+// IBAN validates an IBAN.
+func (s *ValidatorsService) IBAN(ctx context.Context, iban string) (*ValidationResult, error) {
+	return s.Validate(ctx, "iban", "iban", iban)
+}
+
+// BIC validates a BIC / SWIFT code.
+func (s *ValidatorsService) BIC(ctx context.Context, bic string) (*ValidationResult, error) {
+	return s.Validate(ctx, "bic", "bic", bic)
+}
+
+// SortCode validates a UK sort code.
+func (s *ValidatorsService) SortCode(ctx context.Context, sortCode string) (*ValidationResult, error) {
+	return s.Validate(ctx, "sort-code", "sortCode", sortCode)
+}
+
+// UKAccountNumber validates a UK account number.
+func (s *ValidatorsService) UKAccountNumber(ctx context.Context, accountNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "sort-code-account-number", "accountNumber", accountNumber)
+}
+
+// ABARoutingNumber validates a US ACH routing number.
+func (s *ValidatorsService) ABARoutingNumber(ctx context.Context, routingNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "abartn", "abartn", routingNumber)
+}
+
+// ACHAccountNumber validates a US account number.
+func (s *ValidatorsService) ACHAccountNumber(ctx context.Context, accountNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "ach-account-number", "accountNumber", accountNumber)
+}
+
+// IFSC validates an Indian IFSC code.
+func (s *ValidatorsService) IFSC(ctx context.Context, ifscCode string) (*ValidationResult, error) {
+	return s.Validate(ctx, "ifsc-code", "ifscCode", ifscCode)
+}
+
+// IndianAccountNumber validates an Indian account number.
+func (s *ValidatorsService) IndianAccountNumber(ctx context.Context, accountNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "indian-account-number", "accountNumber", accountNumber)
+}
+
+// BSB validates an Australian BSB code.
+func (s *ValidatorsService) BSB(ctx context.Context, bsbCode string) (*ValidationResult, error) {
+	return s.Validate(ctx, "bsb-code", "bsbCode", bsbCode)
+}
+
+// AustralianAccountNumber validates an Australian account number.
+func (s *ValidatorsService) AustralianAccountNumber(ctx context.Context, accountNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "australian-account-number", "accountNumber", accountNumber)
+}
+
+// CanadianInstitutionNumber validates a Canadian institution number.
+func (s *ValidatorsService) CanadianInstitutionNumber(ctx context.Context, institutionNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "canadian-institution-number", "institutionNumber", institutionNumber)
+}
+
+// CanadianTransitNumber validates a Canadian transit number.
+func (s *ValidatorsService) CanadianTransitNumber(ctx context.Context, transitNumber string) (*ValidationResult, error) {
+	return s.Validate(ctx, "canadian-transit-number", "transitNumber", transitNumber)
+}
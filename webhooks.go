@@ -0,0 +1,140 @@
+package wise
+
+import (
+	"context"
+	"fmt"
+)
+
+// WebhooksService handles webhook subscription API calls.
+type WebhooksService struct {
+	client *Client
+}
+
+// Webhook event types that can be subscribed to.
+const (
+	EventTransferStateChange            = "transfers#state-change"
+	EventTransferActiveCases            = "transfers#active-cases"
+	EventTransferPayoutFailure          = "transfers#payout-failure"
+	EventBalanceCredit                  = "balances#credit"
+	EventBalanceUpdate                  = "balances#update"
+	EventBalanceAccountStateChange      = "balances#account-state-change"
+	EventProfileVerificationStateChange = "profiles#verification-state-change"
+)
+
+// Subscription represents a webhook subscription.
+type Subscription struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	TriggerOn string               `json:"trigger_on"`
+	Delivery  SubscriptionDelivery `json:"delivery"`
+	Scope     *SubscriptionScope   `json:"scope,omitempty"`
+	CreatedBy *SubscriptionScope   `json:"created_by,omitempty"`
+	CreatedAt Timestamp            `json:"created_at,omitempty"`
+}
+
+// SubscriptionDelivery describes where and in which schema version events are delivered.
+type SubscriptionDelivery struct {
+	Version string `json:"version"` // e.g. "2.0.0"
+	URL     string `json:"url"`
+}
+
+// SubscriptionScope identifies the owner of a subscription (profile or application).
+type SubscriptionScope struct {
+	Domain string `json:"domain,omitempty"`
+	Type   string `json:"type,omitempty"`
+	ID     string `json:"id"`
+}
+
+// CreateSubscriptionRequest represents the request to create a webhook subscription.
+type CreateSubscriptionRequest struct {
+	Name      string               `json:"name"`
+	TriggerOn string               `json:"trigger_on"`
+	Delivery  SubscriptionDelivery `json:"delivery"`
+}
+
+// Create creates a profile-level webhook subscription.
+// POST /v3/profiles/{profileId}/subscriptions
+func (s *WebhooksService) Create(ctx context.Context, profileID int64, req *CreateSubscriptionRequest) (*Subscription, error) {
+	var sub Subscription
+	path := fmt.Sprintf("/v3/profiles/%d/subscriptions", profileID)
+	err := s.client.Post(ctx, path, req, &sub)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// Get retrieves a profile-level webhook subscription by ID.
+// GET /v3/profiles/{profileId}/subscriptions/{subscriptionId}
+func (s *WebhooksService) Get(ctx context.Context, profileID int64, subscriptionID string) (*Subscription, error) {
+	var sub Subscription
+	path := fmt.Sprintf("/v3/profiles/%d/subscriptions/%s", profileID, subscriptionID)
+	err := s.client.Get(ctx, path, nil, &sub)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// List returns all webhook subscriptions for a profile.
+// GET /v3/profiles/{profileId}/subscriptions
+func (s *WebhooksService) List(ctx context.Context, profileID int64) ([]Subscription, error) {
+	var subs []Subscription
+	path := fmt.Sprintf("/v3/profiles/%d/subscriptions", profileID)
+	err := s.client.Get(ctx, path, nil, &subs)
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// Delete deletes a profile-level webhook subscription.
+// DELETE /v3/profiles/{profileId}/subscriptions/{subscriptionId}
+func (s *WebhooksService) Delete(ctx context.Context, profileID int64, subscriptionID string) error {
+	path := fmt.Sprintf("/v3/profiles/%d/subscriptions/%s", profileID, subscriptionID)
+	return s.client.Delete(ctx, path, nil)
+}
+
+// CreateForApplication creates an application-level webhook subscription.
+// Requires a client credentials token.
+// POST /v3/applications/{clientKey}/subscriptions
+func (s *WebhooksService) CreateForApplication(ctx context.Context, clientKey string, req *CreateSubscriptionRequest) (*Subscription, error) {
+	var sub Subscription
+	path := fmt.Sprintf("/v3/applications/%s/subscriptions", clientKey)
+	err := s.client.Post(ctx, path, req, &sub)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// GetForApplication retrieves an application-level webhook subscription by ID.
+// GET /v3/applications/{clientKey}/subscriptions/{subscriptionId}
+func (s *WebhooksService) GetForApplication(ctx context.Context, clientKey, subscriptionID string) (*Subscription, error) {
+	var sub Subscription
+	path := fmt.Sprintf("/v3/applications/%s/subscriptions/%s", clientKey, subscriptionID)
+	err := s.client.Get(ctx, path, nil, &sub)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ListForApplication returns all application-level webhook subscriptions.
+// GET /v3/applications/{clientKey}/subscriptions
+func (s *WebhooksService) ListForApplication(ctx context.Context, clientKey string) ([]Subscription, error) {
+	var subs []Subscription
+	path := fmt.Sprintf("/v3/applications/%s/subscriptions", clientKey)
+	err := s.client.Get(ctx, path, nil, &subs)
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// DeleteForApplication deletes an application-level webhook subscription.
+// DELETE /v3/applications/{clientKey}/subscriptions/{subscriptionId}
+func (s *WebhooksService) DeleteForApplication(ctx context.Context, clientKey, subscriptionID string) error {
+	path := fmt.Sprintf("/v3/applications/%s/subscriptions/%s", clientKey, subscriptionID)
+	return s.client.Delete(ctx, path, nil)
+}
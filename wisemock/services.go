@@ -0,0 +1,593 @@
+package wisemock
+
+import (
+	"context"
+	"io"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// Profiles is a configurable fake of wise.ProfilesAPI.
+type Profiles struct {
+	ListFunc                       func(ctx context.Context) ([]wise.Profile, error)
+	GetFunc                        func(ctx context.Context, profileID int64) (*wise.Profile, error)
+	CreatePersonalFunc             func(ctx context.Context, details *wise.PersonalProfile) (*wise.Profile, error)
+	CreateBusinessFunc             func(ctx context.Context, details *wise.BusinessProfile) (*wise.Profile, error)
+	UpdatePersonalFunc             func(ctx context.Context, profileID int64, details *wise.PersonalProfile) (*wise.Profile, error)
+	UpdateBusinessFunc             func(ctx context.Context, profileID int64, details *wise.BusinessProfile) (*wise.Profile, error)
+	GetVerificationStatusFunc      func(ctx context.Context, profileID int64) (*wise.VerificationStatus, error)
+	GetRequiredEvidencesFunc       func(ctx context.Context, profileID int64) ([]string, error)
+	ListKYCReviewsFunc             func(ctx context.Context, profileID int64) ([]wise.KYCReview, error)
+	AddIdentificationDocumentFunc  func(ctx context.Context, profileID int64, doc *wise.IdentificationDocument) error
+	UploadVerificationDocumentFunc func(ctx context.Context, profileID int64, documentType, fileName string, content io.Reader) (*wise.VerificationDocument, error)
+	GetExtensionFunc               func(ctx context.Context, profileID int64) (wise.ProfileExtension, error)
+	UpdateExtensionFunc            func(ctx context.Context, profileID int64, ext wise.ProfileExtension) error
+}
+
+var _ wise.ProfilesAPI = (*Profiles)(nil)
+
+// List calls ListFunc.
+func (m *Profiles) List(ctx context.Context) ([]wise.Profile, error) {
+	if m.ListFunc == nil {
+		return nil, errNotConfigured("Profiles.List")
+	}
+	return m.ListFunc(ctx)
+}
+
+// Get calls GetFunc.
+func (m *Profiles) Get(ctx context.Context, profileID int64) (*wise.Profile, error) {
+	if m.GetFunc == nil {
+		return nil, errNotConfigured("Profiles.Get")
+	}
+	return m.GetFunc(ctx, profileID)
+}
+
+// CreatePersonal calls CreatePersonalFunc.
+func (m *Profiles) CreatePersonal(ctx context.Context, details *wise.PersonalProfile) (*wise.Profile, error) {
+	if m.CreatePersonalFunc == nil {
+		return nil, errNotConfigured("Profiles.CreatePersonal")
+	}
+	return m.CreatePersonalFunc(ctx, details)
+}
+
+// CreateBusiness calls CreateBusinessFunc.
+func (m *Profiles) CreateBusiness(ctx context.Context, details *wise.BusinessProfile) (*wise.Profile, error) {
+	if m.CreateBusinessFunc == nil {
+		return nil, errNotConfigured("Profiles.CreateBusiness")
+	}
+	return m.CreateBusinessFunc(ctx, details)
+}
+
+// UpdatePersonal calls UpdatePersonalFunc.
+func (m *Profiles) UpdatePersonal(ctx context.Context, profileID int64, details *wise.PersonalProfile) (*wise.Profile, error) {
+	if m.UpdatePersonalFunc == nil {
+		return nil, errNotConfigured("Profiles.UpdatePersonal")
+	}
+	return m.UpdatePersonalFunc(ctx, profileID, details)
+}
+
+// UpdateBusiness calls UpdateBusinessFunc.
+func (m *Profiles) UpdateBusiness(ctx context.Context, profileID int64, details *wise.BusinessProfile) (*wise.Profile, error) {
+	if m.UpdateBusinessFunc == nil {
+		return nil, errNotConfigured("Profiles.UpdateBusiness")
+	}
+	return m.UpdateBusinessFunc(ctx, profileID, details)
+}
+
+// GetVerificationStatus calls GetVerificationStatusFunc.
+func (m *Profiles) GetVerificationStatus(ctx context.Context, profileID int64) (*wise.VerificationStatus, error) {
+	if m.GetVerificationStatusFunc == nil {
+		return nil, errNotConfigured("Profiles.GetVerificationStatus")
+	}
+	return m.GetVerificationStatusFunc(ctx, profileID)
+}
+
+// GetRequiredEvidences calls GetRequiredEvidencesFunc.
+func (m *Profiles) GetRequiredEvidences(ctx context.Context, profileID int64) ([]string, error) {
+	if m.GetRequiredEvidencesFunc == nil {
+		return nil, errNotConfigured("Profiles.GetRequiredEvidences")
+	}
+	return m.GetRequiredEvidencesFunc(ctx, profileID)
+}
+
+// ListKYCReviews calls ListKYCReviewsFunc.
+func (m *Profiles) ListKYCReviews(ctx context.Context, profileID int64) ([]wise.KYCReview, error) {
+	if m.ListKYCReviewsFunc == nil {
+		return nil, errNotConfigured("Profiles.ListKYCReviews")
+	}
+	return m.ListKYCReviewsFunc(ctx, profileID)
+}
+
+// AddIdentificationDocument calls AddIdentificationDocumentFunc.
+func (m *Profiles) AddIdentificationDocument(ctx context.Context, profileID int64, doc *wise.IdentificationDocument) error {
+	if m.AddIdentificationDocumentFunc == nil {
+		return errNotConfigured("Profiles.AddIdentificationDocument")
+	}
+	return m.AddIdentificationDocumentFunc(ctx, profileID, doc)
+}
+
+// UploadVerificationDocument calls UploadVerificationDocumentFunc.
+func (m *Profiles) UploadVerificationDocument(ctx context.Context, profileID int64, documentType, fileName string, content io.Reader) (*wise.VerificationDocument, error) {
+	if m.UploadVerificationDocumentFunc == nil {
+		return nil, errNotConfigured("Profiles.UploadVerificationDocument")
+	}
+	return m.UploadVerificationDocumentFunc(ctx, profileID, documentType, fileName, content)
+}
+
+// GetExtension calls GetExtensionFunc.
+func (m *Profiles) GetExtension(ctx context.Context, profileID int64) (wise.ProfileExtension, error) {
+	if m.GetExtensionFunc == nil {
+		return nil, errNotConfigured("Profiles.GetExtension")
+	}
+	return m.GetExtensionFunc(ctx, profileID)
+}
+
+// UpdateExtension calls UpdateExtensionFunc.
+func (m *Profiles) UpdateExtension(ctx context.Context, profileID int64, ext wise.ProfileExtension) error {
+	if m.UpdateExtensionFunc == nil {
+		return errNotConfigured("Profiles.UpdateExtension")
+	}
+	return m.UpdateExtensionFunc(ctx, profileID, ext)
+}
+
+// Quotes is a configurable fake of wise.QuotesAPI.
+type Quotes struct {
+	CreateFunc   func(ctx context.Context, profileID int64, req *wise.CreateQuoteRequest) (*wise.Quote, error)
+	CreateV2Func func(ctx context.Context, req *wise.CreateQuoteRequest) (*wise.Quote, error)
+	GetFunc      func(ctx context.Context, profileID int64, quoteID string) (*wise.Quote, error)
+	GetV2Func    func(ctx context.Context, quoteID string) (*wise.Quote, error)
+	UpdateFunc   func(ctx context.Context, profileID int64, quoteID string, req *wise.UpdateQuoteRequest) (*wise.Quote, error)
+}
+
+var _ wise.QuotesAPI = (*Quotes)(nil)
+
+// Create calls CreateFunc.
+func (m *Quotes) Create(ctx context.Context, profileID int64, req *wise.CreateQuoteRequest) (*wise.Quote, error) {
+	if m.CreateFunc == nil {
+		return nil, errNotConfigured("Quotes.Create")
+	}
+	return m.CreateFunc(ctx, profileID, req)
+}
+
+// CreateV2 calls CreateV2Func.
+func (m *Quotes) CreateV2(ctx context.Context, req *wise.CreateQuoteRequest) (*wise.Quote, error) {
+	if m.CreateV2Func == nil {
+		return nil, errNotConfigured("Quotes.CreateV2")
+	}
+	return m.CreateV2Func(ctx, req)
+}
+
+// Get calls GetFunc.
+func (m *Quotes) Get(ctx context.Context, profileID int64, quoteID string) (*wise.Quote, error) {
+	if m.GetFunc == nil {
+		return nil, errNotConfigured("Quotes.Get")
+	}
+	return m.GetFunc(ctx, profileID, quoteID)
+}
+
+// GetV2 calls GetV2Func.
+func (m *Quotes) GetV2(ctx context.Context, quoteID string) (*wise.Quote, error) {
+	if m.GetV2Func == nil {
+		return nil, errNotConfigured("Quotes.GetV2")
+	}
+	return m.GetV2Func(ctx, quoteID)
+}
+
+// Update calls UpdateFunc.
+func (m *Quotes) Update(ctx context.Context, profileID int64, quoteID string, req *wise.UpdateQuoteRequest) (*wise.Quote, error) {
+	if m.UpdateFunc == nil {
+		return nil, errNotConfigured("Quotes.Update")
+	}
+	return m.UpdateFunc(ctx, profileID, quoteID, req)
+}
+
+// Recipients is a configurable fake of wise.RecipientsAPI.
+type Recipients struct {
+	CreateFunc              func(ctx context.Context, req *wise.CreateRecipientRequest) (*wise.Recipient, error)
+	CreateEmailFunc         func(ctx context.Context, profileID int64, accountHolderName string, currency wise.Currency, email string) (*wise.Recipient, error)
+	GetFunc                 func(ctx context.Context, accountID int64) (*wise.Recipient, error)
+	ListFunc                func(ctx context.Context, params *wise.ListRecipientsParams) ([]wise.Recipient, error)
+	ListAllFunc             func(ctx context.Context, params *wise.ListRecipientsParams) ([]wise.Recipient, error)
+	UpdateFunc              func(ctx context.Context, accountID int64, req *wise.UpdateRecipientRequest) (*wise.Recipient, error)
+	DeleteFunc              func(ctx context.Context, accountID int64) error
+	GetRequirementsFunc     func(ctx context.Context, quoteID string, currency wise.Currency) ([]wise.RecipientRequirements, error)
+	RefreshRequirementsFunc func(ctx context.Context, quoteID string, req *wise.CreateRecipientRequest) ([]wise.RecipientRequirements, error)
+	VerifyAccountFunc       func(ctx context.Context, profileID int64, req *wise.CreateRecipientRequest) (*wise.AccountVerification, error)
+	VerifyRecipientFunc     func(ctx context.Context, profileID, recipientID int64) (*wise.AccountVerification, error)
+}
+
+var _ wise.RecipientsAPI = (*Recipients)(nil)
+
+// Create calls CreateFunc.
+func (m *Recipients) Create(ctx context.Context, req *wise.CreateRecipientRequest) (*wise.Recipient, error) {
+	if m.CreateFunc == nil {
+		return nil, errNotConfigured("Recipients.Create")
+	}
+	return m.CreateFunc(ctx, req)
+}
+
+// CreateEmail calls CreateEmailFunc.
+func (m *Recipients) CreateEmail(ctx context.Context, profileID int64, accountHolderName string, currency wise.Currency, email string) (*wise.Recipient, error) {
+	if m.CreateEmailFunc == nil {
+		return nil, errNotConfigured("Recipients.CreateEmail")
+	}
+	return m.CreateEmailFunc(ctx, profileID, accountHolderName, currency, email)
+}
+
+// Get calls GetFunc.
+func (m *Recipients) Get(ctx context.Context, accountID int64) (*wise.Recipient, error) {
+	if m.GetFunc == nil {
+		return nil, errNotConfigured("Recipients.Get")
+	}
+	return m.GetFunc(ctx, accountID)
+}
+
+// List calls ListFunc.
+func (m *Recipients) List(ctx context.Context, params *wise.ListRecipientsParams) ([]wise.Recipient, error) {
+	if m.ListFunc == nil {
+		return nil, errNotConfigured("Recipients.List")
+	}
+	return m.ListFunc(ctx, params)
+}
+
+// ListAll calls ListAllFunc.
+func (m *Recipients) ListAll(ctx context.Context, params *wise.ListRecipientsParams) ([]wise.Recipient, error) {
+	if m.ListAllFunc == nil {
+		return nil, errNotConfigured("Recipients.ListAll")
+	}
+	return m.ListAllFunc(ctx, params)
+}
+
+// Update calls UpdateFunc.
+func (m *Recipients) Update(ctx context.Context, accountID int64, req *wise.UpdateRecipientRequest) (*wise.Recipient, error) {
+	if m.UpdateFunc == nil {
+		return nil, errNotConfigured("Recipients.Update")
+	}
+	return m.UpdateFunc(ctx, accountID, req)
+}
+
+// Delete calls DeleteFunc.
+func (m *Recipients) Delete(ctx context.Context, accountID int64) error {
+	if m.DeleteFunc == nil {
+		return errNotConfigured("Recipients.Delete")
+	}
+	return m.DeleteFunc(ctx, accountID)
+}
+
+// GetRequirements calls GetRequirementsFunc.
+func (m *Recipients) GetRequirements(ctx context.Context, quoteID string, currency wise.Currency) ([]wise.RecipientRequirements, error) {
+	if m.GetRequirementsFunc == nil {
+		return nil, errNotConfigured("Recipients.GetRequirements")
+	}
+	return m.GetRequirementsFunc(ctx, quoteID, currency)
+}
+
+// RefreshRequirements calls RefreshRequirementsFunc.
+func (m *Recipients) RefreshRequirements(ctx context.Context, quoteID string, req *wise.CreateRecipientRequest) ([]wise.RecipientRequirements, error) {
+	if m.RefreshRequirementsFunc == nil {
+		return nil, errNotConfigured("Recipients.RefreshRequirements")
+	}
+	return m.RefreshRequirementsFunc(ctx, quoteID, req)
+}
+
+// VerifyAccount calls VerifyAccountFunc.
+func (m *Recipients) VerifyAccount(ctx context.Context, profileID int64, req *wise.CreateRecipientRequest) (*wise.AccountVerification, error) {
+	if m.VerifyAccountFunc == nil {
+		return nil, errNotConfigured("Recipients.VerifyAccount")
+	}
+	return m.VerifyAccountFunc(ctx, profileID, req)
+}
+
+// VerifyRecipient calls VerifyRecipientFunc.
+func (m *Recipients) VerifyRecipient(ctx context.Context, profileID, recipientID int64) (*wise.AccountVerification, error) {
+	if m.VerifyRecipientFunc == nil {
+		return nil, errNotConfigured("Recipients.VerifyRecipient")
+	}
+	return m.VerifyRecipientFunc(ctx, profileID, recipientID)
+}
+
+// Transfers is a configurable fake of wise.TransfersAPI.
+type Transfers struct {
+	CreateFunc          func(ctx context.Context, req *wise.CreateTransferRequest) (*wise.Transfer, error)
+	GetRequirementsFunc func(ctx context.Context, req *wise.CreateTransferRequest) ([]wise.RecipientRequirements, error)
+	GetFunc             func(ctx context.Context, transferID int64) (*wise.Transfer, error)
+	ListFunc            func(ctx context.Context, params *wise.ListTransfersParams) ([]wise.Transfer, error)
+	ListAllFunc         func(ctx context.Context, params *wise.ListTransfersParams) ([]wise.Transfer, error)
+	CancelFunc          func(ctx context.Context, transferID int64) (*wise.Transfer, error)
+	CanCancelFunc       func(ctx context.Context, transferID int64) (bool, error)
+	FundFunc            func(ctx context.Context, profileID, transferID int64) (*wise.Transfer, error)
+	GetPayInDetailsFunc func(ctx context.Context, profileID, transferID int64) (*wise.PayInDetails, error)
+	GetIssuesFunc       func(ctx context.Context, transferID int64) ([]wise.TransferIssue, error)
+	GetDeliveryTimeFunc func(ctx context.Context, transferID int64) (*wise.Timestamp, error)
+	UploadDocumentFunc  func(ctx context.Context, profileID, transferID int64, documentType, fileName string, content io.Reader) (*wise.TransferDocument, error)
+	GetTrackingFunc     func(ctx context.Context, transferID int64) (*wise.TransferTracking, error)
+	DownloadReceiptFunc func(ctx context.Context, transferID int64) (io.ReadCloser, error)
+}
+
+var _ wise.TransfersAPI = (*Transfers)(nil)
+
+// Create calls CreateFunc.
+func (m *Transfers) Create(ctx context.Context, req *wise.CreateTransferRequest) (*wise.Transfer, error) {
+	if m.CreateFunc == nil {
+		return nil, errNotConfigured("Transfers.Create")
+	}
+	return m.CreateFunc(ctx, req)
+}
+
+// GetRequirements calls GetRequirementsFunc.
+func (m *Transfers) GetRequirements(ctx context.Context, req *wise.CreateTransferRequest) ([]wise.RecipientRequirements, error) {
+	if m.GetRequirementsFunc == nil {
+		return nil, errNotConfigured("Transfers.GetRequirements")
+	}
+	return m.GetRequirementsFunc(ctx, req)
+}
+
+// Get calls GetFunc.
+func (m *Transfers) Get(ctx context.Context, transferID int64) (*wise.Transfer, error) {
+	if m.GetFunc == nil {
+		return nil, errNotConfigured("Transfers.Get")
+	}
+	return m.GetFunc(ctx, transferID)
+}
+
+// List calls ListFunc.
+func (m *Transfers) List(ctx context.Context, params *wise.ListTransfersParams) ([]wise.Transfer, error) {
+	if m.ListFunc == nil {
+		return nil, errNotConfigured("Transfers.List")
+	}
+	return m.ListFunc(ctx, params)
+}
+
+// ListAll calls ListAllFunc.
+func (m *Transfers) ListAll(ctx context.Context, params *wise.ListTransfersParams) ([]wise.Transfer, error) {
+	if m.ListAllFunc == nil {
+		return nil, errNotConfigured("Transfers.ListAll")
+	}
+	return m.ListAllFunc(ctx, params)
+}
+
+// Cancel calls CancelFunc.
+func (m *Transfers) Cancel(ctx context.Context, transferID int64) (*wise.Transfer, error) {
+	if m.CancelFunc == nil {
+		return nil, errNotConfigured("Transfers.Cancel")
+	}
+	return m.CancelFunc(ctx, transferID)
+}
+
+// CanCancel calls CanCancelFunc.
+func (m *Transfers) CanCancel(ctx context.Context, transferID int64) (bool, error) {
+	if m.CanCancelFunc == nil {
+		return false, errNotConfigured("Transfers.CanCancel")
+	}
+	return m.CanCancelFunc(ctx, transferID)
+}
+
+// Fund calls FundFunc.
+func (m *Transfers) Fund(ctx context.Context, profileID, transferID int64) (*wise.Transfer, error) {
+	if m.FundFunc == nil {
+		return nil, errNotConfigured("Transfers.Fund")
+	}
+	return m.FundFunc(ctx, profileID, transferID)
+}
+
+// GetPayInDetails calls GetPayInDetailsFunc.
+func (m *Transfers) GetPayInDetails(ctx context.Context, profileID, transferID int64) (*wise.PayInDetails, error) {
+	if m.GetPayInDetailsFunc == nil {
+		return nil, errNotConfigured("Transfers.GetPayInDetails")
+	}
+	return m.GetPayInDetailsFunc(ctx, profileID, transferID)
+}
+
+// GetIssues calls GetIssuesFunc.
+func (m *Transfers) GetIssues(ctx context.Context, transferID int64) ([]wise.TransferIssue, error) {
+	if m.GetIssuesFunc == nil {
+		return nil, errNotConfigured("Transfers.GetIssues")
+	}
+	return m.GetIssuesFunc(ctx, transferID)
+}
+
+// GetDeliveryTime calls GetDeliveryTimeFunc.
+func (m *Transfers) GetDeliveryTime(ctx context.Context, transferID int64) (*wise.Timestamp, error) {
+	if m.GetDeliveryTimeFunc == nil {
+		return nil, errNotConfigured("Transfers.GetDeliveryTime")
+	}
+	return m.GetDeliveryTimeFunc(ctx, transferID)
+}
+
+// UploadDocument calls UploadDocumentFunc.
+func (m *Transfers) UploadDocument(ctx context.Context, profileID, transferID int64, documentType, fileName string, content io.Reader) (*wise.TransferDocument, error) {
+	if m.UploadDocumentFunc == nil {
+		return nil, errNotConfigured("Transfers.UploadDocument")
+	}
+	return m.UploadDocumentFunc(ctx, profileID, transferID, documentType, fileName, content)
+}
+
+// GetTracking calls GetTrackingFunc.
+func (m *Transfers) GetTracking(ctx context.Context, transferID int64) (*wise.TransferTracking, error) {
+	if m.GetTrackingFunc == nil {
+		return nil, errNotConfigured("Transfers.GetTracking")
+	}
+	return m.GetTrackingFunc(ctx, transferID)
+}
+
+// DownloadReceipt calls DownloadReceiptFunc.
+func (m *Transfers) DownloadReceipt(ctx context.Context, transferID int64) (io.ReadCloser, error) {
+	if m.DownloadReceiptFunc == nil {
+		return nil, errNotConfigured("Transfers.DownloadReceipt")
+	}
+	return m.DownloadReceiptFunc(ctx, transferID)
+}
+
+// ExchangeRates is a configurable fake of wise.ExchangeRatesAPI.
+type ExchangeRates struct {
+	GetFunc           func(ctx context.Context, source, target wise.Currency) (*wise.ExchangeRate, error)
+	ListFunc          func(ctx context.Context, params *wise.GetRateParams) ([]wise.ExchangeRate, error)
+	GetHistoricalFunc func(ctx context.Context, source, target wise.Currency, time string) (*wise.ExchangeRate, error)
+	GetHistoryFunc    func(ctx context.Context, params *wise.HistoryParams) ([]wise.ExchangeRate, error)
+	GetMultipleFunc   func(ctx context.Context, pairs [][2]wise.Currency) (map[string]float64, error)
+}
+
+var _ wise.ExchangeRatesAPI = (*ExchangeRates)(nil)
+
+// Get calls GetFunc.
+func (m *ExchangeRates) Get(ctx context.Context, source, target wise.Currency) (*wise.ExchangeRate, error) {
+	if m.GetFunc == nil {
+		return nil, errNotConfigured("ExchangeRates.Get")
+	}
+	return m.GetFunc(ctx, source, target)
+}
+
+// List calls ListFunc.
+func (m *ExchangeRates) List(ctx context.Context, params *wise.GetRateParams) ([]wise.ExchangeRate, error) {
+	if m.ListFunc == nil {
+		return nil, errNotConfigured("ExchangeRates.List")
+	}
+	return m.ListFunc(ctx, params)
+}
+
+// GetHistorical calls GetHistoricalFunc.
+func (m *ExchangeRates) GetHistorical(ctx context.Context, source, target wise.Currency, time string) (*wise.ExchangeRate, error) {
+	if m.GetHistoricalFunc == nil {
+		return nil, errNotConfigured("ExchangeRates.GetHistorical")
+	}
+	return m.GetHistoricalFunc(ctx, source, target, time)
+}
+
+// GetHistory calls GetHistoryFunc.
+func (m *ExchangeRates) GetHistory(ctx context.Context, params *wise.HistoryParams) ([]wise.ExchangeRate, error) {
+	if m.GetHistoryFunc == nil {
+		return nil, errNotConfigured("ExchangeRates.GetHistory")
+	}
+	return m.GetHistoryFunc(ctx, params)
+}
+
+// GetMultiple calls GetMultipleFunc.
+func (m *ExchangeRates) GetMultiple(ctx context.Context, pairs [][2]wise.Currency) (map[string]float64, error) {
+	if m.GetMultipleFunc == nil {
+		return nil, errNotConfigured("ExchangeRates.GetMultiple")
+	}
+	return m.GetMultipleFunc(ctx, pairs)
+}
+
+// Balances is a configurable fake of wise.BalancesAPI.
+type Balances struct {
+	ListFunc                   func(ctx context.Context, profileID int64, params *wise.ListBalancesParams) ([]wise.Balance, error)
+	ListAllFunc                func(ctx context.Context, profileID int64) ([]wise.Balance, error)
+	GetFunc                    func(ctx context.Context, profileID, balanceID int64) (*wise.Balance, error)
+	OpenFunc                   func(ctx context.Context, profileID int64, req *wise.OpenBalanceRequest) (*wise.Balance, error)
+	CreateJarFunc              func(ctx context.Context, profileID int64, currency wise.Currency, name string) (*wise.Balance, error)
+	MoveFunc                   func(ctx context.Context, profileID int64, req *wise.MoveBalanceRequest) (*wise.BalanceMovement, error)
+	CloseFunc                  func(ctx context.Context, profileID, balanceID int64) error
+	GetByCurrencyFunc          func(ctx context.Context, profileID int64, currency wise.Currency) (*wise.Balance, error)
+	ConvertFunc                func(ctx context.Context, profileID int64, quoteID string) error
+	GetStatementFunc           func(ctx context.Context, profileID, balanceID int64, currency wise.Currency, intervalStart, intervalEnd string) ([]wise.BalanceStatement, error)
+	GetFundingInstructionsFunc func(ctx context.Context, profileID, balanceID int64, amount float64) (*wise.FundingInstructions, error)
+	DownloadStatementFunc      func(ctx context.Context, profileID, balanceID int64, currency wise.Currency, intervalStart, intervalEnd string, format wise.StatementFormat) (io.ReadCloser, error)
+	StreamStatementFunc        func(ctx context.Context, profileID, balanceID int64, currency wise.Currency, intervalStart, intervalEnd string) (*wise.StatementStream, error)
+}
+
+var _ wise.BalancesAPI = (*Balances)(nil)
+
+// List calls ListFunc.
+func (m *Balances) List(ctx context.Context, profileID int64, params *wise.ListBalancesParams) ([]wise.Balance, error) {
+	if m.ListFunc == nil {
+		return nil, errNotConfigured("Balances.List")
+	}
+	return m.ListFunc(ctx, profileID, params)
+}
+
+// ListAll calls ListAllFunc.
+func (m *Balances) ListAll(ctx context.Context, profileID int64) ([]wise.Balance, error) {
+	if m.ListAllFunc == nil {
+		return nil, errNotConfigured("Balances.ListAll")
+	}
+	return m.ListAllFunc(ctx, profileID)
+}
+
+// Get calls GetFunc.
+func (m *Balances) Get(ctx context.Context, profileID, balanceID int64) (*wise.Balance, error) {
+	if m.GetFunc == nil {
+		return nil, errNotConfigured("Balances.Get")
+	}
+	return m.GetFunc(ctx, profileID, balanceID)
+}
+
+// Open calls OpenFunc.
+func (m *Balances) Open(ctx context.Context, profileID int64, req *wise.OpenBalanceRequest) (*wise.Balance, error) {
+	if m.OpenFunc == nil {
+		return nil, errNotConfigured("Balances.Open")
+	}
+	return m.OpenFunc(ctx, profileID, req)
+}
+
+// CreateJar calls CreateJarFunc.
+func (m *Balances) CreateJar(ctx context.Context, profileID int64, currency wise.Currency, name string) (*wise.Balance, error) {
+	if m.CreateJarFunc == nil {
+		return nil, errNotConfigured("Balances.CreateJar")
+	}
+	return m.CreateJarFunc(ctx, profileID, currency, name)
+}
+
+// Move calls MoveFunc.
+func (m *Balances) Move(ctx context.Context, profileID int64, req *wise.MoveBalanceRequest) (*wise.BalanceMovement, error) {
+	if m.MoveFunc == nil {
+		return nil, errNotConfigured("Balances.Move")
+	}
+	return m.MoveFunc(ctx, profileID, req)
+}
+
+// Close calls CloseFunc.
+func (m *Balances) Close(ctx context.Context, profileID, balanceID int64) error {
+	if m.CloseFunc == nil {
+		return errNotConfigured("Balances.Close")
+	}
+	return m.CloseFunc(ctx, profileID, balanceID)
+}
+
+// GetByCurrency calls GetByCurrencyFunc.
+func (m *Balances) GetByCurrency(ctx context.Context, profileID int64, currency wise.Currency) (*wise.Balance, error) {
+	if m.GetByCurrencyFunc == nil {
+		return nil, errNotConfigured("Balances.GetByCurrency")
+	}
+	return m.GetByCurrencyFunc(ctx, profileID, currency)
+}
+
+// Convert calls ConvertFunc.
+func (m *Balances) Convert(ctx context.Context, profileID int64, quoteID string) error {
+	if m.ConvertFunc == nil {
+		return errNotConfigured("Balances.Convert")
+	}
+	return m.ConvertFunc(ctx, profileID, quoteID)
+}
+
+// GetStatement calls GetStatementFunc.
+func (m *Balances) GetStatement(ctx context.Context, profileID, balanceID int64, currency wise.Currency, intervalStart, intervalEnd string) ([]wise.BalanceStatement, error) {
+	if m.GetStatementFunc == nil {
+		return nil, errNotConfigured("Balances.GetStatement")
+	}
+	return m.GetStatementFunc(ctx, profileID, balanceID, currency, intervalStart, intervalEnd)
+}
+
+// GetFundingInstructions calls GetFundingInstructionsFunc.
+func (m *Balances) GetFundingInstructions(ctx context.Context, profileID, balanceID int64, amount float64) (*wise.FundingInstructions, error) {
+	if m.GetFundingInstructionsFunc == nil {
+		return nil, errNotConfigured("Balances.GetFundingInstructions")
+	}
+	return m.GetFundingInstructionsFunc(ctx, profileID, balanceID, amount)
+}
+
+// DownloadStatement calls DownloadStatementFunc.
+func (m *Balances) DownloadStatement(ctx context.Context, profileID, balanceID int64, currency wise.Currency, intervalStart, intervalEnd string, format wise.StatementFormat) (io.ReadCloser, error) {
+	if m.DownloadStatementFunc == nil {
+		return nil, errNotConfigured("Balances.DownloadStatement")
+	}
+	return m.DownloadStatementFunc(ctx, profileID, balanceID, currency, intervalStart, intervalEnd, format)
+}
+
+// StreamStatement calls StreamStatementFunc.
+func (m *Balances) StreamStatement(ctx context.Context, profileID, balanceID int64, currency wise.Currency, intervalStart, intervalEnd string) (*wise.StatementStream, error) {
+	if m.StreamStatementFunc == nil {
+		return nil, errNotConfigured("Balances.StreamStatement")
+	}
+	return m.StreamStatementFunc(ctx, profileID, balanceID, currency, intervalStart, intervalEnd)
+}
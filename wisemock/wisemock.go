@@ -0,0 +1,26 @@
+// Package wisemock provides configurable fakes of the wise service
+// interfaces for unit tests.
+//
+// Each fake has a function field per method. Set the ones a test needs;
+// calling a method whose function is nil returns an error wrapping
+// ErrNotConfigured.
+//
+//	profiles := &wisemock.Profiles{
+//		ListFunc: func(ctx context.Context) ([]wise.Profile, error) {
+//			return []wise.Profile{{ID: 1, Type: wise.ProfileTypePersonal}}, nil
+//		},
+//	}
+//	runReport(ctx, profiles) // takes a wise.ProfilesAPI
+package wisemock
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotConfigured is returned by fake methods that have no function set.
+var ErrNotConfigured = errors.New("wisemock: method not configured")
+
+func errNotConfigured(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotConfigured, method)
+}
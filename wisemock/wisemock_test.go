@@ -0,0 +1,27 @@
+package wisemock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+func TestProfiles(t *testing.T) {
+	var profiles wise.ProfilesAPI = &Profiles{
+		ListFunc: func(ctx context.Context) ([]wise.Profile, error) {
+			return []wise.Profile{{ID: 42, Type: wise.ProfileTypePersonal}}, nil
+		},
+	}
+
+	list, err := profiles.List(context.Background())
+	if err != nil || len(list) != 1 || list[0].ID != 42 {
+		t.Errorf("Expected configured profile, got %+v, %v", list, err)
+	}
+
+	_, err = profiles.Get(context.Background(), 42)
+	if !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("Expected ErrNotConfigured, got %v", err)
+	}
+}
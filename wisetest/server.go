@@ -0,0 +1,958 @@
+// Package wisetest provides an in-process fake of the Wise API for tests.
+//
+// The fake Server keeps profiles, balances, rates, quotes, recipients,
+// transfers and activities in memory and implements the endpoints the wise
+// client uses for them, so integration tests can run offline and
+// deterministically:
+//
+//	srv := wisetest.NewServer()
+//	defer srv.Close()
+//	client := srv.Client()
+//	balances, err := client.Balances.List(ctx, wisetest.DefaultProfileID, nil)
+package wisetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// DefaultProfileID is the ID of the personal profile created by NewServer.
+const DefaultProfileID int64 = 1
+
+// DefaultFeeRate is the fee charged on quotes, as a fraction of the source amount.
+const DefaultFeeRate = 0.005
+
+// cardFeeSurcharge is added to the fee rate of card (DEBIT) payment options.
+const cardFeeSurcharge = 0.01
+
+// Server is a fake Wise API backed by httptest.Server. Seed it with the
+// Add and Set methods; all methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// Now returns the current time. Set it for deterministic timestamps.
+	Now func() time.Time
+	// FeeRate is the fee charged on quotes, as a fraction of the source
+	// amount. Card payment options cost one percentage point more.
+	FeeRate float64
+	// RecipientRequirements are the account requirements by currency.
+	RecipientRequirements map[wise.Currency][]wise.RecipientRequirements
+	// TransferRequirements is returned by the transfer requirements
+	// endpoint. Fields marked required must be set in transfer details.
+	TransferRequirements []wise.RecipientRequirements
+
+	mu         sync.Mutex
+	nextID     int64
+	profiles   []wise.Profile
+	balances   map[int64][]*wise.Balance         // by profile ID
+	statements map[int64][]wise.BalanceStatement // by balance ID
+	rates      map[[2]wise.Currency]float64
+	quotes     map[string]*wise.Quote
+	recipients []*wise.Recipient
+	transfers  []*wise.Transfer
+	activities map[int64][]wise.Activity // by profile ID
+}
+
+// NewServer starts a fake server seeded with a personal profile
+// (DefaultProfileID) holding EUR, GBP and USD balances, and rates between
+// them and JPY.
+func NewServer() *Server {
+	s := NewEmptyServer()
+	s.AddProfile(wise.Profile{ID: DefaultProfileID, Type: wise.ProfileTypePersonal})
+	s.AddBalance(DefaultProfileID, wise.EUR, 1000)
+	s.AddBalance(DefaultProfileID, wise.GBP, 500)
+	s.AddBalance(DefaultProfileID, wise.USD, 250)
+	s.SetRate(wise.EUR, wise.USD, 1.08)
+	s.SetRate(wise.GBP, wise.USD, 1.27)
+	s.SetRate(wise.EUR, wise.GBP, 0.85)
+	s.SetRate(wise.USD, wise.JPY, 150)
+	return s
+}
+
+// NewEmptyServer starts a fake server without any data.
+func NewEmptyServer() *Server {
+	s := &Server{
+		Now:        time.Now,
+		FeeRate:    DefaultFeeRate,
+		nextID:     1000,
+		balances:   make(map[int64][]*wise.Balance),
+		statements: make(map[int64][]wise.BalanceStatement),
+		rates:      make(map[[2]wise.Currency]float64),
+		quotes:     make(map[string]*wise.Quote),
+		activities: make(map[int64][]wise.Activity),
+		RecipientRequirements: map[wise.Currency][]wise.RecipientRequirements{
+			wise.GBP: {{Type: "sort_code", Fields: []wise.RecipientField{
+				{Name: "UK sort code", Group: []wise.RecipientFieldGroup{{Key: "sortCode", Type: "text", Required: true, ValidationRegexp: `^\d{6}$`, Example: "231470"}}},
+				{Name: "Account number", Group: []wise.RecipientFieldGroup{{Key: "accountNumber", Type: "text", Required: true, ValidationRegexp: `^\d{8}$`, Example: "28821822"}}},
+			}}},
+			wise.EUR: {{Type: "iban", Fields: []wise.RecipientField{
+				{Name: "IBAN", Group: []wise.RecipientFieldGroup{{Key: "IBAN", Type: "text", Required: true, ValidationRegexp: `^[A-Z]{2}\d{2}[A-Z0-9]{11,30}$`, Example: "DE89370400440532013000"}}},
+			}}},
+			wise.USD: {{Type: "aba", Fields: []wise.RecipientField{
+				{Name: "Routing number", Group: []wise.RecipientFieldGroup{{Key: "abartn", Type: "text", Required: true, ValidationRegexp: `^\d{9}$`, Example: "026009593"}}},
+				{Name: "Account number", Group: []wise.RecipientFieldGroup{{Key: "accountNumber", Type: "text", Required: true, MinLength: 4, MaxLength: 17}}},
+				{Name: "Account type", Group: []wise.RecipientFieldGroup{{Key: "accountType", Type: "radio", Required: true, ValuesAllowed: []wise.ValueAllowed{{Key: "CHECKING", Name: "Checking"}, {Key: "SAVINGS", Name: "Savings"}}}}},
+			}}},
+		},
+		TransferRequirements: []wise.RecipientRequirements{{
+			Type: "transfer",
+			Fields: []wise.RecipientField{{
+				Name:  "Reference",
+				Group: []wise.RecipientFieldGroup{{Key: "reference", Name: "Reference", Type: "text", MaxLength: 35}},
+			}},
+		}},
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Client returns a wise.Client that talks to the fake server.
+func (s *Server) Client(opts ...wise.ClientOption) *wise.Client {
+	opts = append([]wise.ClientOption{wise.WithBaseURL(s.URL)}, opts...)
+	return wise.NewClient("wisetest-token", opts...)
+}
+
+// AddProfile adds a profile.
+func (s *Server) AddProfile(p wise.Profile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profiles = append(s.profiles, p)
+}
+
+// AddBalance adds a standard balance to a profile and returns its ID.
+func (s *Server) AddBalance(profileID int64, currency wise.Currency, amount float64) int64 {
+	return s.addBalance(profileID, currency, amount, wise.BalanceTypeStandard, "")
+}
+
+// AddSavings adds a savings jar to a profile and returns its ID. Savings
+// jars are only listed when SAVINGS balances are asked for.
+func (s *Server) AddSavings(profileID int64, currency wise.Currency, name string, amount float64) int64 {
+	return s.addBalance(profileID, currency, amount, wise.BalanceTypeSavings, name)
+}
+
+func (s *Server) addBalance(profileID int64, currency wise.Currency, amount float64, typ wise.BalanceType, name string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := &wise.Balance{
+		ID:           s.newID(),
+		ProfileID:    profileID,
+		Currency:     currency,
+		Amount:       wise.Money{Value: amount, Currency: currency},
+		Type:         typ,
+		Name:         name,
+		CreationTime: wise.Timestamp{Time: s.Now()},
+		Visible:      true,
+	}
+	s.balances[profileID] = append(s.balances[profileID], b)
+	return b.ID
+}
+
+// SetRate sets the rate from source to target. The inverse rate is set too.
+func (s *Server) SetRate(source, target wise.Currency, rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rates[[2]wise.Currency{source, target}] = rate
+	s.rates[[2]wise.Currency{target, source}] = round(1/rate, 6)
+}
+
+// AddActivity adds an activity to a profile's activity list. A missing ID
+// is generated.
+func (s *Server) AddActivity(profileID int64, a wise.Activity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a.ID == "" {
+		a.ID = strconv.FormatInt(s.newID(), 10)
+	}
+	s.activities[profileID] = append(s.activities[profileID], a)
+}
+
+// AddRecipient adds an active recipient and returns its ID.
+func (s *Server) AddRecipient(r wise.Recipient) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r.ID = s.newID()
+	r.Active = true
+	s.recipients = append(s.recipients, &r)
+	return r.ID
+}
+
+// Recipients returns a copy of the active recipients.
+func (s *Server) Recipients() []wise.Recipient {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recipients := make([]wise.Recipient, 0, len(s.recipients))
+	for _, r := range s.recipients {
+		if r.Active {
+			recipients = append(recipients, *r)
+		}
+	}
+	return recipients
+}
+
+// Transfers returns a copy of the transfers created on the server.
+func (s *Server) Transfers() []wise.Transfer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	transfers := make([]wise.Transfer, 0, len(s.transfers))
+	for _, t := range s.transfers {
+		transfers = append(transfers, *t)
+	}
+	return transfers
+}
+
+// SetTransferStatus changes the status of a transfer, e.g. to simulate payout.
+func (s *Server) SetTransferStatus(transferID int64, status wise.TransferStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t := s.transfer(transferID); t != nil {
+		t.Status = status
+	}
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/me", s.handleMe)
+	mux.HandleFunc("GET /v1/profiles", s.handleListProfiles)
+	mux.HandleFunc("GET /v1/profiles/{profileId}", s.handleGetProfile)
+	mux.HandleFunc("GET /v4/profiles/{profileId}/balances", s.handleListBalances)
+	mux.HandleFunc("GET /v4/profiles/{profileId}/balances/{balanceId}", s.handleGetBalance)
+	mux.HandleFunc("GET /v1/profiles/{profileId}/balance-statements/{balanceId}/statement.json", s.handleStatement)
+	mux.HandleFunc("GET /v1/profiles/{profileId}/balance-statements/{balanceId}/statement.pdf", s.handleStatementPDF)
+	mux.HandleFunc("POST /v2/profiles/{profileId}/balance-movements", s.handleConvert)
+	mux.HandleFunc("GET /v1/profiles/{profileId}/activities", s.handleActivities)
+	mux.HandleFunc("GET /v1/rates", s.handleRates)
+	mux.HandleFunc("POST /v2/quotes", s.handleCreateQuote)
+	mux.HandleFunc("POST /v3/profiles/{profileId}/quotes", s.handleCreateQuote)
+	mux.HandleFunc("GET /v2/quotes/{quoteId}", s.handleGetQuote)
+	mux.HandleFunc("GET /v3/profiles/{profileId}/quotes/{quoteId}", s.handleGetQuote)
+	mux.HandleFunc("PATCH /v3/profiles/{profileId}/quotes/{quoteId}", s.handleUpdateQuote)
+	mux.HandleFunc("POST /v1/accounts", s.handleCreateRecipient)
+	mux.HandleFunc("GET /v1/accounts", s.handleListRecipients)
+	mux.HandleFunc("GET /v1/accounts/{accountId}", s.handleGetRecipient)
+	mux.HandleFunc("DELETE /v1/accounts/{accountId}", s.handleDeleteRecipient)
+	mux.HandleFunc("GET /v1/account-requirements", s.handleAccountRequirements)
+	mux.HandleFunc("POST /v1/account-requirements", s.handleAccountRequirements)
+	mux.HandleFunc("POST /v1/transfer-requirements", s.handleTransferRequirements)
+	mux.HandleFunc("POST /v1/transfers", s.handleCreateTransfer)
+	mux.HandleFunc("GET /v1/transfers", s.handleListTransfers)
+	mux.HandleFunc("GET /v1/transfers/{transferId}", s.handleGetTransfer)
+	mux.HandleFunc("PUT /v1/transfers/{transferId}/cancel", s.handleCancelTransfer)
+	mux.HandleFunc("GET /v1/transfers/{transferId}/issues", s.handleTransferIssues)
+	mux.HandleFunc("GET /v1/delivery-estimates/{transferId}", s.handleDeliveryEstimate)
+	mux.HandleFunc("POST /v3/profiles/{profileId}/transfers/{transferId}/payments", s.handleFundTransfer)
+	return mux
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, wise.User{ID: 1, Name: "Test User", Email: "test@example.com", Active: true})
+}
+
+func (s *Server) handleListProfiles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, s.profiles)
+}
+
+func (s *Server) handleGetProfile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := pathInt(r, "profileId")
+	for _, p := range s.profiles {
+		if p.ID == id {
+			writeJSON(w, http.StatusOK, p)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "profile.not.found", "Profile not found")
+}
+
+func (s *Server) handleListBalances(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	types := strings.Split(r.URL.Query().Get("types"), ",")
+	balances := []wise.Balance{}
+	for _, b := range s.balances[pathInt(r, "profileId")] {
+		if slices.Contains(types, string(b.Type)) {
+			balances = append(balances, *b)
+		}
+	}
+	writeJSON(w, http.StatusOK, balances)
+}
+
+func (s *Server) handleGetBalance(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.balance(pathInt(r, "profileId"), pathInt(r, "balanceId"))
+	if b == nil {
+		writeError(w, http.StatusNotFound, "balance.not.found", "Balance not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, b)
+}
+
+func (s *Server) handleStatement(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	balanceID := pathInt(r, "balanceId")
+	if s.balance(pathInt(r, "profileId"), balanceID) == nil {
+		writeError(w, http.StatusNotFound, "balance.not.found", "Balance not found")
+		return
+	}
+	start, _ := time.Parse(time.RFC3339, r.URL.Query().Get("intervalStart"))
+	end, _ := time.Parse(time.RFC3339, r.URL.Query().Get("intervalEnd"))
+	if !start.IsZero() && end.Sub(start) > wise.MaxStatementInterval {
+		writeError(w, http.StatusBadRequest, "interval.too.long", "Statement interval is too long")
+		return
+	}
+
+	transactions := []wise.BalanceStatement{}
+	for _, st := range s.statements[balanceID] {
+		if (!start.IsZero() && st.Date.Before(start)) || (!end.IsZero() && st.Date.After(end)) {
+			continue
+		}
+		transactions = append(transactions, st)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"transactions": transactions})
+}
+
+// handleStatementPDF serves a stand-in PDF statement: a PDF header and one
+// line per transaction, enough to check a download arrives intact.
+func (s *Server) handleStatementPDF(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	balanceID := pathInt(r, "balanceId")
+	if s.balance(pathInt(r, "profileId"), balanceID) == nil {
+		writeError(w, http.StatusNotFound, "balance.not.found", "Balance not found")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Write([]byte("%PDF-1.4\n"))
+	for _, st := range s.statements[balanceID] {
+		fmt.Fprintf(w, "%% %s %s %.2f\n", st.Date.Format(time.RFC3339), st.ReferenceNumber, st.Amount.Value)
+	}
+	w.Write([]byte("%%EOF\n"))
+}
+
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-idempotence-uuid") == "" {
+		writeError(w, http.StatusBadRequest, "idempotence.uuid.missing", "X-idempotence-uuid header is required")
+		return
+	}
+	var req wise.ConvertBalanceRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profileID := pathInt(r, "profileId")
+	q := s.quotes[req.QuoteID]
+	if q == nil {
+		writeError(w, http.StatusNotFound, "quote.not.found", "Quote not found")
+		return
+	}
+	from := s.balanceByCurrency(profileID, q.SourceCurrency)
+	to := s.balanceByCurrency(profileID, q.TargetCurrency)
+	if from == nil || to == nil {
+		writeError(w, http.StatusUnprocessableEntity, "balance.not.found", "Both balances must exist")
+		return
+	}
+	if from.Amount.Value < q.SourceAmount {
+		writeError(w, http.StatusUnprocessableEntity, "balance.insufficient", "Insufficient funds")
+		return
+	}
+
+	exchange := &wise.ExchangeDetails{
+		FromAmount: wise.Money{Value: q.SourceAmount, Currency: q.SourceCurrency},
+		ToAmount:   wise.Money{Value: q.TargetAmount, Currency: q.TargetCurrency},
+		Rate:       q.Rate,
+	}
+	s.debit(from, q.SourceAmount, "CONVERSION", "Converted to "+string(q.TargetCurrency), exchange)
+	s.credit(to, q.TargetAmount, "CONVERSION", "Converted from "+string(q.SourceCurrency), exchange)
+
+	writeJSON(w, http.StatusOK, wise.BalanceMovement{
+		ID:            s.newID(),
+		Type:          "CONVERSION",
+		State:         "COMPLETED",
+		SourceAmount:  exchange.FromAmount,
+		TargetAmount:  exchange.ToAmount,
+		Rate:          q.Rate,
+		BalancesAfter: []wise.Balance{*from, *to},
+		CreationTime:  wise.Timestamp{Time: s.Now()},
+	})
+}
+
+func (s *Server) handleActivities(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := r.URL.Query()
+	typ := wise.ActivityType(query.Get("monetaryResourceType"))
+	status := wise.ActivityStatus(query.Get("status"))
+
+	activities := []wise.Activity{}
+	for _, a := range s.activities[pathInt(r, "profileId")] {
+		if (typ == "" || a.Type == typ) && (status == "" || a.Status == status) {
+			activities = append(activities, a)
+		}
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].CreatedOn.After(activities[j].CreatedOn.Time)
+	})
+
+	// The cursor is the offset of the next page.
+	offset, _ := strconv.Atoi(query.Get("nextCursor"))
+	size, _ := strconv.Atoi(query.Get("size"))
+	if size <= 0 {
+		size = 10
+	}
+	offset = min(offset, len(activities))
+	page := wise.ActivitiesPage{Activities: activities[offset:]}
+	if len(page.Activities) > size {
+		page.Activities = page.Activities[:size]
+		page.Cursor = strconv.Itoa(offset + size)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) handleRates(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := r.URL.Query()
+	source, target := wise.Currency(query.Get("source")), wise.Currency(query.Get("target"))
+	now := s.Now().UTC()
+
+	rates := []wise.ExchangeRate{}
+	if query.Get("from") != "" {
+		rate, ok := s.rates[[2]wise.Currency{source, target}]
+		if !ok {
+			writeJSON(w, http.StatusOK, rates)
+			return
+		}
+		from, _ := time.Parse(time.RFC3339, query.Get("from"))
+		to, _ := time.Parse(time.RFC3339, query.Get("to"))
+		step := 24 * time.Hour
+		switch query.Get("group") {
+		case "hour":
+			step = time.Hour
+		case "minute":
+			step = time.Minute
+		}
+		for t := from; !t.After(to); t = t.Add(step) {
+			rates = append(rates, wise.ExchangeRate{Rate: rate, Source: source, Target: target, Time: wise.Timestamp{Time: t}})
+		}
+		writeJSON(w, http.StatusOK, rates)
+		return
+	}
+
+	for pair, rate := range s.rates {
+		if (source == "" || pair[0] == source) && (target == "" || pair[1] == target) {
+			rates = append(rates, wise.ExchangeRate{Rate: rate, Source: pair[0], Target: pair[1], Time: wise.Timestamp{Time: now}})
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].Source+rates[i].Target < rates[j].Source+rates[j].Target
+	})
+	writeJSON(w, http.StatusOK, rates)
+}
+
+func (s *Server) handleCreateQuote(w http.ResponseWriter, r *http.Request) {
+	var req wise.CreateQuoteRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rate, ok := s.rates[[2]wise.Currency{req.SourceCurrency, req.TargetCurrency}]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "route.not.supported", "Currency route not supported")
+		return
+	}
+	if req.SourceAmount == nil && req.TargetAmount == nil {
+		writeError(w, http.StatusUnprocessableEntity, "amount.missing", "Either sourceAmount or targetAmount is required")
+		return
+	}
+
+	source, target, _ := quoteAmounts(req, rate, s.FeeRate)
+
+	profileID := req.Profile
+	if id := pathInt(r, "profileId"); id != 0 {
+		profileID = id
+	}
+	now := s.Now()
+	q := &wise.Quote{
+		ID:                 strconv.FormatInt(s.newID(), 10),
+		SourceCurrency:     req.SourceCurrency,
+		TargetCurrency:     req.TargetCurrency,
+		SourceAmount:       source,
+		TargetAmount:       target,
+		PayOut:             "BANK_TRANSFER",
+		Rate:               rate,
+		CreatedTime:        wise.Timestamp{Time: now},
+		Profile:            profileID,
+		RateType:           wise.RateTypeFixed,
+		RateExpirationTime: wise.Timestamp{Time: now.Add(30 * time.Minute)},
+		ExpirationTime:     wise.Timestamp{Time: now.Add(30 * time.Minute)},
+		Status:             "PENDING",
+	}
+	// Paying from a balance or by bank transfer costs the same, but a bank
+	// transfer takes longer to arrive. Cards are fastest and cost more.
+	for _, o := range []struct {
+		payIn    string
+		feeRate  float64
+		delivery time.Duration
+	}{
+		{"BALANCE", s.FeeRate, 24 * time.Hour},
+		{"BANK_TRANSFER", s.FeeRate, 72 * time.Hour},
+		{"DEBIT", s.FeeRate + cardFeeSurcharge, time.Hour},
+	} {
+		source, target, fee := quoteAmounts(req, rate, o.feeRate)
+		q.PaymentOptions = append(q.PaymentOptions, wise.PaymentOption{
+			PayIn:             o.payIn,
+			PayOut:            "BANK_TRANSFER",
+			SourceAmount:      source,
+			TargetAmount:      target,
+			Fee:               wise.PaymentOptionFee{Transferwise: fee, Total: fee},
+			EstimatedDelivery: wise.Timestamp{Time: now.Add(o.delivery)},
+		})
+	}
+	s.quotes[q.ID] = q
+	writeJSON(w, http.StatusOK, q)
+}
+
+// quoteAmounts returns the amounts of a quote. The fee is charged on the
+// source amount: target = (source - fee) * rate.
+func quoteAmounts(req wise.CreateQuoteRequest, rate, feeRate float64) (source, target, fee float64) {
+	if req.SourceAmount != nil {
+		source = *req.SourceAmount
+		target = round((source-source*feeRate)*rate, 2)
+	} else {
+		target = *req.TargetAmount
+		source = round(target/rate/(1-feeRate), 2)
+	}
+	return source, target, round(source*feeRate, 2)
+}
+
+func (s *Server) handleGetQuote(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := s.quotes[r.PathValue("quoteId")]
+	if q == nil {
+		writeError(w, http.StatusNotFound, "quote.not.found", "Quote not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+// handleUpdateQuote only supports setting the target account; amounts are
+// not recalculated.
+func (s *Server) handleUpdateQuote(w http.ResponseWriter, r *http.Request) {
+	var req wise.UpdateQuoteRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := s.quotes[r.PathValue("quoteId")]
+	if q == nil {
+		writeError(w, http.StatusNotFound, "quote.not.found", "Quote not found")
+		return
+	}
+	if req.TargetAccount != 0 && s.recipient(req.TargetAccount) == nil {
+		writeError(w, http.StatusUnprocessableEntity, "targetAccount.invalid", "Recipient not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+func (s *Server) handleCreateRecipient(w http.ResponseWriter, r *http.Request) {
+	var req wise.CreateRecipientRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.AccountHolderName == "" || req.Currency == "" {
+		writeError(w, http.StatusUnprocessableEntity, "recipient.invalid", "accountHolderName and currency are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recipient := &wise.Recipient{
+		ID:                s.newID(),
+		Profile:           req.Profile,
+		AccountHolderName: req.AccountHolderName,
+		Type:              req.Type,
+		Currency:          req.Currency,
+		Active:            true,
+		OwnedByCustomer:   req.OwnedByCustomer,
+		Details:           req.Details,
+	}
+	s.recipients = append(s.recipients, recipient)
+	writeJSON(w, http.StatusOK, recipient)
+}
+
+func (s *Server) handleListRecipients(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := r.URL.Query()
+	currency := wise.Currency(query.Get("currency"))
+	profileID, _ := strconv.ParseInt(query.Get("profile"), 10, 64)
+
+	recipients := []wise.Recipient{}
+	for _, rec := range s.recipients {
+		if !rec.Active || (currency != "" && rec.Currency != currency) {
+			continue
+		}
+		if profileID != 0 && rec.Profile != 0 && rec.Profile != profileID {
+			continue
+		}
+		recipients = append(recipients, *rec)
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset = min(offset, len(recipients))
+	recipients = recipients[offset:]
+	if limit > 0 && limit < len(recipients) {
+		recipients = recipients[:limit]
+	}
+	writeJSON(w, http.StatusOK, recipients)
+}
+
+func (s *Server) handleGetRecipient(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recipient := s.recipient(pathInt(r, "accountId"))
+	if recipient == nil {
+		writeError(w, http.StatusNotFound, "recipient.not.found", "Recipient not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, recipient)
+}
+
+// handleDeleteRecipient deactivates the recipient, as Wise does.
+func (s *Server) handleDeleteRecipient(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	recipient := s.recipient(pathInt(r, "accountId"))
+	if recipient == nil || !recipient.Active {
+		writeError(w, http.StatusNotFound, "recipient.not.found", "Recipient not found")
+		return
+	}
+	recipient.Active = false
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleAccountRequirements(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	requirements := s.RecipientRequirements[wise.Currency(r.URL.Query().Get("targetCurrency"))]
+	if requirements == nil {
+		requirements = []wise.RecipientRequirements{}
+	}
+	writeJSON(w, http.StatusOK, requirements)
+}
+
+func (s *Server) handleTransferRequirements(w http.ResponseWriter, r *http.Request) {
+	var req wise.CreateTransferRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.quotes[req.QuoteUUID] == nil {
+		writeError(w, http.StatusUnprocessableEntity, "quote.not.found", "Quote not found")
+		return
+	}
+	if s.recipient(req.TargetAccount) == nil {
+		writeError(w, http.StatusUnprocessableEntity, "targetAccount.invalid", "Recipient not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.TransferRequirements)
+}
+
+func (s *Server) handleCreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var req wise.CreateTransferRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	q := s.quotes[req.QuoteUUID]
+	if q == nil {
+		writeError(w, http.StatusUnprocessableEntity, "quote.not.found", "Quote not found")
+		return
+	}
+	if req.CustomerTransactionID == "" {
+		writeError(w, http.StatusUnprocessableEntity, "customerTransactionId.missing", "customerTransactionId is required")
+		return
+	}
+	// A repeated customerTransactionId returns the existing transfer.
+	for _, t := range s.transfers {
+		if t.CustomerTransactionID == req.CustomerTransactionID {
+			writeJSON(w, http.StatusOK, t)
+			return
+		}
+	}
+
+	t := &wise.Transfer{
+		ID:                    s.newID(),
+		User:                  1,
+		TargetAccount:         req.TargetAccount,
+		QuoteUUID:             q.ID,
+		Status:                wise.TransferStatusIncomingPaymentWaiting,
+		Rate:                  q.Rate,
+		Reference:             req.Details.Reference,
+		Created:               wise.Timestamp{Time: s.Now()},
+		Details:               req.Details,
+		SourceCurrency:        q.SourceCurrency,
+		SourceValue:           q.SourceAmount,
+		TargetCurrency:        q.TargetCurrency,
+		TargetValue:           q.TargetAmount,
+		CustomerTransactionID: req.CustomerTransactionID,
+	}
+	s.transfers = append(s.transfers, t)
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) handleListTransfers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := r.URL.Query()
+	status := wise.TransferStatus(query.Get("status"))
+	profileID, _ := strconv.ParseInt(query.Get("profile"), 10, 64)
+	start, _ := time.Parse(time.RFC3339, query.Get("createdDateStart"))
+	end, _ := time.Parse(time.RFC3339, query.Get("createdDateEnd"))
+
+	transfers := []wise.Transfer{}
+	for _, t := range s.transfers {
+		if status != "" && t.Status != status {
+			continue
+		}
+		if t.Created.Before(start) || (!end.IsZero() && t.Created.After(end)) {
+			continue
+		}
+		if profileID != 0 && s.quotes[t.QuoteUUID].Profile != profileID {
+			continue
+		}
+		transfers = append(transfers, *t)
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	offset = min(offset, len(transfers))
+	transfers = transfers[offset:]
+	if limit > 0 && limit < len(transfers) {
+		transfers = transfers[:limit]
+	}
+	writeJSON(w, http.StatusOK, transfers)
+}
+
+func (s *Server) handleGetTransfer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.transfer(pathInt(r, "transferId"))
+	if t == nil {
+		writeError(w, http.StatusNotFound, "transfer.not.found", "Transfer not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+// handleTransferIssues reports no issues: transfers on the fake server
+// never need attention.
+func (s *Server) handleTransferIssues(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.transfer(pathInt(r, "transferId")) == nil {
+		writeError(w, http.StatusNotFound, "transfer.not.found", "Transfer not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, []wise.TransferIssue{})
+}
+
+// handleDeliveryEstimate estimates delivery as quoted for paying from a
+// balance.
+func (s *Server) handleDeliveryEstimate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.transfer(pathInt(r, "transferId"))
+	if t == nil {
+		writeError(w, http.StatusNotFound, "transfer.not.found", "Transfer not found")
+		return
+	}
+	var eta wise.Timestamp
+	if q := s.quotes[t.QuoteUUID]; q != nil {
+		for _, o := range q.PaymentOptions {
+			if o.PayIn == "BALANCE" {
+				eta = o.EstimatedDelivery
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"estimatedDeliveryDate": eta})
+}
+
+func (s *Server) handleCancelTransfer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.transfer(pathInt(r, "transferId"))
+	if t == nil {
+		writeError(w, http.StatusNotFound, "transfer.not.found", "Transfer not found")
+		return
+	}
+	if t.Status == wise.TransferStatusOutgoingPaymentSent {
+		writeError(w, http.StatusConflict, "transfer.paid_out", "Transfer has already been paid out")
+		return
+	}
+	if !t.IsCancellable() {
+		writeError(w, http.StatusConflict, "transfer.not.cancellable", "Transfer cannot be cancelled")
+		return
+	}
+
+	// Money taken from a balance is refunded.
+	if t.Status == wise.TransferStatusProcessing {
+		if b := s.balanceByCurrency(s.quotes[t.QuoteUUID].Profile, t.SourceCurrency); b != nil {
+			s.credit(b, t.SourceValue, "TRANSFER", "Refund for cancelled transfer", nil)
+		}
+	}
+	t.Status = wise.TransferStatusCancelled
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) handleFundTransfer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := s.transfer(pathInt(r, "transferId"))
+	if t == nil {
+		writeError(w, http.StatusNotFound, "transfer.not.found", "Transfer not found")
+		return
+	}
+	if t.Status != wise.TransferStatusIncomingPaymentWaiting {
+		writeError(w, http.StatusConflict, "transfer.already.funded", "Transfer is already funded")
+		return
+	}
+	b := s.balanceByCurrency(pathInt(r, "profileId"), t.SourceCurrency)
+	if b == nil || b.Amount.Value < t.SourceValue {
+		writeError(w, http.StatusUnprocessableEntity, "balance.insufficient", "Insufficient funds")
+		return
+	}
+
+	s.debit(b, t.SourceValue, "TRANSFER", fmt.Sprintf("Sent money (transfer %d)", t.ID), nil)
+	if rec := s.recipient(t.TargetAccount); rec != nil {
+		entries := s.statements[b.ID]
+		entries[len(entries)-1].Details.Recipient = &wise.StatementRecipient{Name: rec.AccountHolderName}
+	}
+	t.Status = wise.TransferStatusProcessing
+	writeJSON(w, http.StatusOK, t)
+}
+
+// The helpers below must be called with s.mu held.
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) balance(profileID, balanceID int64) *wise.Balance {
+	for _, b := range s.balances[profileID] {
+		if b.ID == balanceID {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *Server) balanceByCurrency(profileID int64, currency wise.Currency) *wise.Balance {
+	for _, b := range s.balances[profileID] {
+		if b.Currency == currency && b.Type == wise.BalanceTypeStandard {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *Server) recipient(id int64) *wise.Recipient {
+	for _, r := range s.recipients {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (s *Server) transfer(id int64) *wise.Transfer {
+	for _, t := range s.transfers {
+		if t.ID == id {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *Server) debit(b *wise.Balance, amount float64, typ, description string, exchange *wise.ExchangeDetails) {
+	s.post(b, -amount, "DEBIT", typ, description, exchange)
+}
+
+func (s *Server) credit(b *wise.Balance, amount float64, typ, description string, exchange *wise.ExchangeDetails) {
+	s.post(b, amount, "CREDIT", typ, description, exchange)
+}
+
+// post changes a balance and records the statement entry.
+func (s *Server) post(b *wise.Balance, amount float64, direction, typ, description string, exchange *wise.ExchangeDetails) {
+	b.Amount.Value = round(b.Amount.Value+amount, 2)
+	b.ModificationTime = wise.Timestamp{Time: s.Now()}
+	s.statements[b.ID] = append(s.statements[b.ID], wise.BalanceStatement{
+		Type:            direction,
+		Date:            wise.Timestamp{Time: s.Now()},
+		Amount:          wise.Money{Value: amount, Currency: b.Currency},
+		Details:         wise.StatementDetails{Type: typ, Description: description},
+		ExchangeDetails: exchange,
+		RunningBalance:  b.Amount,
+		ReferenceNumber: fmt.Sprintf("%s-%d", typ, s.newID()),
+	})
+}
+
+func pathInt(r *http.Request, name string) int64 {
+	id, _ := strconv.ParseInt(r.PathValue(name), 10, 64)
+	return id
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "request.invalid", err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, wise.APIError{
+		Message: message,
+		Errors:  []wise.ValidationError{{Code: code, Message: message}},
+	})
+}
+
+func round(v float64, decimals int) float64 {
+	p := math.Pow(10, float64(decimals))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,74 @@
+package wisetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+func TestServer_TransferFlow(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	amount := 100.0
+	quote, err := client.Quotes.Create(ctx, DefaultProfileID, &wise.CreateQuoteRequest{
+		SourceCurrency: wise.EUR,
+		TargetCurrency: wise.USD,
+		SourceAmount:   &amount,
+	})
+	if err != nil {
+		t.Fatalf("Create quote failed: %v", err)
+	}
+	if quote.TargetAmount != 107.46 {
+		t.Errorf("Expected target amount 107.46, got %v", quote.TargetAmount)
+	}
+
+	transfer, err := client.Transfers.Create(ctx, &wise.CreateTransferRequest{
+		TargetAccount:         1,
+		QuoteUUID:             quote.ID,
+		CustomerTransactionID: "tx-1",
+	})
+	if err != nil {
+		t.Fatalf("Create transfer failed: %v", err)
+	}
+	if _, err := client.Transfers.Fund(ctx, DefaultProfileID, transfer.ID); err != nil {
+		t.Fatalf("Fund failed: %v", err)
+	}
+
+	eur, err := client.Balances.GetByCurrency(ctx, DefaultProfileID, wise.EUR)
+	if err != nil {
+		t.Fatalf("GetByCurrency failed: %v", err)
+	}
+	if eur.Amount.Value != 900 {
+		t.Errorf("Expected EUR balance 900 after funding, got %v", eur.Amount.Value)
+	}
+
+	now := time.Now().UTC()
+	statement, err := client.Balances.GetStatement(ctx, DefaultProfileID, eur.ID, wise.EUR,
+		now.Add(-time.Hour).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339))
+	if err != nil || len(statement) != 1 || statement[0].Amount.Value != -100 {
+		t.Errorf("Expected one -100 statement entry, got %+v, %v", statement, err)
+	}
+
+	srv.SetTransferStatus(transfer.ID, wise.TransferStatusOutgoingPaymentSent)
+	_, err = client.Transfers.Cancel(ctx, transfer.ID)
+	if !errors.Is(err, wise.ErrTransferAlreadyPaidOut) {
+		t.Errorf("Expected ErrTransferAlreadyPaidOut, got %v", err)
+	}
+}
+
+func TestServer_Rates(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.SetRate(wise.EUR, wise.CHF, 0.95)
+
+	rate, err := srv.Client().ExchangeRates.Get(context.Background(), wise.EUR, wise.CHF)
+	if err != nil || rate.Rate != 0.95 {
+		t.Errorf("Expected seeded rate 0.95, got %+v, %v", rate, err)
+	}
+}
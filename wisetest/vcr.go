@@ -0,0 +1,379 @@
+package wisetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	wise "github.com/joeblew999/plat-wise"
+)
+
+// Mode selects whether a VCR records live traffic or replays a cassette.
+type Mode int
+
+const (
+	// ModeReplay answers requests from the cassette and fails on requests
+	// that were not recorded. No network access is needed.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the API and records them.
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord if WISE_VCR is "record", else ModeReplay,
+// so fixtures can be refreshed with e.g. WISE_VCR=record go test ./...
+func ModeFromEnv() Mode {
+	if os.Getenv("WISE_VCR") == "record" {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a recorded request. The URL is stored without the
+// host, so a cassette recorded against the sandbox replays anywhere.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// VolatileFields are the JSON request fields that differ on every run, so
+// the default body matcher ignores them.
+var VolatileFields = []string{"customerTransactionId"}
+
+// IgnoreFields returns a body matcher for VCR.MatchBody that compares JSON
+// bodies without the given fields, at any depth. Other bodies must be
+// equal.
+func IgnoreFields(fields ...string) func(recorded, actual string) bool {
+	strip := func(body string) (any, bool) {
+		var v any
+		if err := json.Unmarshal([]byte(body), &v); err != nil {
+			return nil, false
+		}
+		return withoutFields(v, fields), true
+	}
+	return func(recorded, actual string) bool {
+		if recorded == actual {
+			return true
+		}
+		r, ok := strip(recorded)
+		if !ok {
+			return false
+		}
+		a, ok := strip(actual)
+		return ok && reflect.DeepEqual(r, a)
+	}
+}
+
+// withoutFields removes the given keys from the objects in v.
+func withoutFields(v any, fields []string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, f := range fields {
+			delete(v, f)
+		}
+		for k, e := range v {
+			v[k] = withoutFields(e, fields)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = withoutFields(e, fields)
+		}
+	}
+	return v
+}
+
+// PIIFields are the JSON fields holding personal data, such as names and
+// account numbers, that the default sanitizer redacts. Keys are matched
+// ignoring case.
+var PIIFields = []string{
+	"accountHolderName", "name", "firstName", "lastName", "fullName", "dateOfBirth",
+	"email", "phoneNumber", "IBAN", "BIC", "swiftCode", "accountNumber", "sortCode",
+	"routingNumber", "abartn", "bsbCode", "ifscCode", "firstLine", "postCode",
+}
+
+// Redacted replaces the values of redacted fields.
+const Redacted = "REDACTED"
+
+// RedactFields returns a sanitizer for VCR.Sanitize that replaces the values
+// of the given fields, at any depth, in JSON request and response bodies
+// with Redacted. Objects and arrays under a redacted field are redacted
+// throughout, and numbers become 0, so bodies still decode. Other bodies
+// are left unchanged.
+func RedactFields(fields ...string) func(*Interaction) {
+	redactBody := func(body string) string {
+		dec := json.NewDecoder(strings.NewReader(body))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return body
+		}
+		v, changed := redactFields(v, fields)
+		if !changed {
+			return body
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return body
+		}
+		return string(data)
+	}
+	return func(in *Interaction) {
+		in.Request.Body = redactBody(in.Request.Body)
+		in.Response.Body = redactBody(in.Response.Body)
+	}
+}
+
+// redactFields redacts the values of the given keys in the objects in v and
+// reports whether anything was redacted.
+func redactFields(v any, fields []string) (any, bool) {
+	changed := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			var c bool
+			if slices.ContainsFunc(fields, func(f string) bool { return strings.EqualFold(f, k) }) {
+				v[k], c = redactValue(e), true
+			} else {
+				v[k], c = redactFields(e, fields)
+			}
+			changed = changed || c
+		}
+	case []any:
+		for i, e := range v {
+			var c bool
+			v[i], c = redactFields(e, fields)
+			changed = changed || c
+		}
+	}
+	return v, changed
+}
+
+// redactValue redacts every string and number in v, keeping its shape.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return Redacted
+	case json.Number:
+		return json.Number("0")
+	case map[string]any:
+		for k, e := range v {
+			v[k] = redactValue(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = redactValue(e)
+		}
+	}
+	return v
+}
+
+// cassette is the fixture file format.
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// recordedHeaders are the response headers kept in cassettes. Others, such
+// as cookies, are dropped.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-Request-Id", "x-2fa-approval"}
+
+// VCR is an http.RoundTripper that records API interactions to a cassette
+// file and replays them in later test runs:
+//
+//	vcr, err := wisetest.NewVCR("testdata/profiles.json", wisetest.ModeFromEnv())
+//	client := vcr.Client(os.Getenv("WISE_API_TOKEN"), wise.WithSandbox())
+//	defer vcr.Save()
+//
+// Credentials are never recorded: request headers are not stored and only
+// a few response headers are. Personal data such as names and account
+// numbers is redacted from bodies before they are saved; set Sanitize to
+// scrub more, or differently.
+//
+// In ModeReplay, requests are sanitized the same way before they are
+// matched, and bodies are compared with MatchBody, so requests with random
+// fields such as customerTransactionId still find their recording.
+type VCR struct {
+	// Sanitize is called on each interaction before it is recorded, and
+	// on each request before it is replayed; RedactFields(PIIFields...) if
+	// nil.
+	Sanitize func(*Interaction)
+	// MatchBody reports whether a request body matches a recorded one;
+	// IgnoreFields(VolatileFields...) if nil.
+	MatchBody func(recorded, actual string) bool
+	// Transport sends requests in ModeRecord; http.DefaultTransport if nil.
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	path         string
+	mode         Mode
+	interactions []Interaction
+	used         []bool
+}
+
+// NewVCR creates a VCR for the cassette at path. In ModeReplay the cassette
+// must exist; in ModeRecord it is overwritten by Save.
+func NewVCR(path string, mode Mode) (*VCR, error) {
+	v := &VCR{path: path, mode: mode}
+	if mode == ModeRecord {
+		return v, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cassette: %w", err)
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+	}
+	v.interactions = c.Interactions
+	v.used = make([]bool, len(c.Interactions))
+	return v, nil
+}
+
+// Client returns a wise.Client that sends its requests through the VCR.
+func (v *VCR) Client(token string, opts ...wise.ClientOption) *wise.Client {
+	return wise.NewClient(token, append(opts, wise.WithTransport(v))...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (v *VCR) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	recorded := RecordedRequest{Method: req.Method, URL: req.URL.RequestURI(), Body: string(body)}
+
+	if v.mode == ModeReplay {
+		return v.replay(req, recorded)
+	}
+	return v.record(req, recorded)
+}
+
+// sanitize scrubs an interaction with Sanitize or the default sanitizer.
+func (v *VCR) sanitize(in *Interaction) {
+	if v.Sanitize != nil {
+		v.Sanitize(in)
+		return
+	}
+	defaultSanitize(in)
+}
+
+// defaultSanitize is the sanitizer used when VCR.Sanitize is nil.
+var defaultSanitize = RedactFields(PIIFields...)
+
+// replay returns the first unused recorded response for a request with the
+// same method, URL and body, once sanitized.
+func (v *VCR) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	in := Interaction{Request: recorded}
+	v.sanitize(&in)
+	recorded = in.Request
+	matchBody := v.MatchBody
+	if matchBody == nil {
+		matchBody = IgnoreFields(VolatileFields...)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for i, in := range v.interactions {
+		if v.used[i] || in.Request.Method != recorded.Method || in.Request.URL != recorded.URL {
+			continue
+		}
+		if in.Request.Body != "" && !matchBody(in.Request.Body, recorded.Body) {
+			continue
+		}
+		v.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(in.Response.Body))),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("wisetest: no recorded interaction for %s %s in %s", recorded.Method, recorded.URL, v.path)
+}
+
+// record sends the request and keeps a sanitized copy of the exchange.
+func (v *VCR) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	transport := v.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := http.Header{}
+	for _, k := range recordedHeaders {
+		if values := resp.Header.Values(k); len(values) > 0 {
+			header[http.CanonicalHeaderKey(k)] = values
+		}
+	}
+	in := Interaction{
+		Request:  recorded,
+		Response: RecordedResponse{StatusCode: resp.StatusCode, Header: header, Body: string(body)},
+	}
+	v.sanitize(&in)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.interactions = append(v.interactions, in)
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing in ModeReplay.
+func (v *VCR) Save() error {
+	if v.mode != ModeRecord {
+		return nil
+	}
+
+	v.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: v.interactions}, "", "  ")
+	v.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(v.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(v.path, append(data, '\n'), 0o644)
+}
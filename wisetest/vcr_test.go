@@ -0,0 +1,178 @@
+package wisetest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	wise "github.com/joeblew999/plat-wise"
+)
+
+func TestVCR_RecordReplay(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "profiles.json")
+	ctx := context.Background()
+
+	srv := NewServer()
+	rec, err := NewVCR(cassette, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewVCR failed: %v", err)
+	}
+	rec.Sanitize = func(in *Interaction) {
+		in.Response.Body = strings.ReplaceAll(in.Response.Body, "Test", "Redacted")
+	}
+	live, err := rec.Client("secret-token", wise.WithBaseURL(srv.URL)).Profiles.List(ctx)
+	srv.Close()
+	if err != nil {
+		t.Fatalf("List (record) failed: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("cassette contains the API token")
+	}
+
+	// The server is gone, so this only passes if the cassette is used.
+	play, err := NewVCR(cassette, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewVCR failed: %v", err)
+	}
+	client := play.Client("other-token", wise.WithBaseURL("http://127.0.0.1:1"))
+	replayed, err := client.Profiles.List(ctx)
+	if err != nil {
+		t.Fatalf("List (replay) failed: %v", err)
+	}
+	if len(replayed) != len(live) || replayed[0].ID != live[0].ID {
+		t.Errorf("replayed %+v, recorded %+v", replayed, live)
+	}
+
+	// Each interaction is replayed once.
+	if _, err := client.Profiles.List(ctx); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected unmatched request error, got %v", err)
+	}
+}
+
+func TestVCR_DefaultSanitize(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "recipients.json")
+	ctx := context.Background()
+	newReq := func(name string) *wise.CreateRecipientRequest {
+		return &wise.CreateRecipientRequest{
+			Profile:           DefaultProfileID,
+			AccountHolderName: name,
+			Currency:          wise.GBP,
+			Type:              "sort_code",
+			Details: map[string]interface{}{
+				"IBAN":          "GB29NWBK60161331926819",
+				"accountNumber": "31926819",
+				"sortCode":      "601613",
+				"email":         "jane@example.com",
+				"address":       map[string]interface{}{"firstLine": "1 High Street", "postCode": "N1 1AA", "country": "GB"},
+			},
+		}
+	}
+
+	srv := NewServer()
+	rec, err := NewVCR(cassette, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewVCR failed: %v", err)
+	}
+	client := rec.Client("token", wise.WithBaseURL(srv.URL))
+	if _, err := client.Recipients.Create(ctx, newReq("Jane Doe")); err != nil {
+		t.Fatalf("Create (record) failed: %v", err)
+	}
+	recipients, err := client.Recipients.List(ctx, &wise.ListRecipientsParams{ProfileID: DefaultProfileID})
+	srv.Close()
+	if err != nil || len(recipients) == 0 {
+		t.Fatalf("List (record) = %+v, %v", recipients, err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("reading cassette: %v", err)
+	}
+	for _, v := range []string{"Jane Doe", "GB29NWBK60161331926819", "31926819", "601613", "jane@example.com", "1 High Street", "N1 1AA"} {
+		if strings.Contains(string(data), v) {
+			t.Errorf("cassette contains %q", v)
+		}
+	}
+
+	// Requests are redacted the same way before they are matched, so
+	// other personal data finds the recording.
+	play, err := NewVCR(cassette, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewVCR failed: %v", err)
+	}
+	replayed, err := play.Client("token", wise.WithBaseURL("http://127.0.0.1:1")).Recipients.Create(ctx, newReq("John Smith"))
+	if err != nil {
+		t.Fatalf("Create (replay) failed: %v", err)
+	}
+	if replayed.AccountHolderName != Redacted {
+		t.Errorf("AccountHolderName = %q, want %q", replayed.AccountHolderName, Redacted)
+	}
+}
+
+func TestVCR_ReplayVolatileBody(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "transfer.json")
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 42}`))
+	}))
+	sanitize := func(in *Interaction) {
+		in.Request.Body = strings.ReplaceAll(in.Request.Body, "Jane Doe", "Redacted")
+	}
+	newReq := func() *wise.CreateTransferRequest {
+		return &wise.CreateTransferRequest{
+			TargetAccount:         7,
+			QuoteUUID:             "quote",
+			CustomerTransactionID: uuid.NewString(),
+			Details:               wise.TransferDetails{Reference: "Rent Jane Doe"},
+		}
+	}
+
+	rec, err := NewVCR(cassette, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewVCR failed: %v", err)
+	}
+	rec.Sanitize = sanitize
+	_, err = rec.Client("token", wise.WithBaseURL(srv.URL)).Transfers.Create(ctx, newReq())
+	srv.Close()
+	if err != nil {
+		t.Fatalf("Create (record) failed: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	play, err := NewVCR(cassette, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewVCR failed: %v", err)
+	}
+	play.Sanitize = sanitize
+	client := play.Client("token", wise.WithBaseURL("http://127.0.0.1:1"))
+	transfer, err := client.Transfers.Create(ctx, newReq())
+	if err != nil || transfer.ID != 42 {
+		t.Fatalf("Create (replay) = %+v, %v; want the recording despite a new customerTransactionId", transfer, err)
+	}
+
+	play, _ = NewVCR(cassette, ModeReplay)
+	play.Sanitize = sanitize
+	req := newReq()
+	req.TargetAccount = 8
+	if _, err := play.Client("token", wise.WithBaseURL("http://127.0.0.1:1")).Transfers.Create(ctx, req); err == nil {
+		t.Error("expected a request with another body not to match")
+	}
+}